@@ -30,30 +30,55 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
+	"sync"
 	"time"
 	
+	"github.com/rehanog/seq2b/pkg/config"
+	"github.com/rehanog/seq2b/pkg/graph"
+	"github.com/rehanog/seq2b/pkg/history"
 	"github.com/rehanog/seq2b/pkg/parser"
+	"github.com/rehanog/seq2b/pkg/watcher"
 )
 
 // App struct
 type App struct {
 	ctx context.Context
 	pages map[string]*parser.Page
-	pageNameMap map[string]string // lowercase -> actual case mapping
 	backlinks *parser.BacklinkIndex
+	unlinked *parser.UnlinkedIndex // Built on demand, reset when pages reload
+	namespaces *parser.NamespaceTree // Built on demand, reset when pages reload
+	linkGraph *graph.Graph // Built on demand, reset when links change
 	currentDir string
 	pagesDir string // Directory where pages are stored
+	journalsDir string // Directory where journal pages are stored (empty for flat libraries)
 	TestMode bool // Enable output capture for testing
 	LibraryPath string // Path to the library directory
+	journalFormat parser.JournalFormat // Journal title/filename formats for this vault
+	fileNameFormat parser.FileNameFormat // How page titles are encoded in filenames
+	ignore *parser.IgnoreRules // Paths under the library root that aren't pages
+	now parser.Clock // Current time, injectable for tests
+	files map[string]fileState // Page files as last read or written, guarded by fileMu
+	pending map[string]bool // Files reported changed by the watcher, guarded by fileMu
+	fileMu sync.Mutex
+	watcher *watcher.Watcher // Watches the page directories, nil when not watching
+	watchFiles bool // Start a watcher when a directory is loaded
+	watchDelay time.Duration // Quiet period before the watcher reports changes
+	onEvent func(event string, data ...interface{}) // Replaces runtime events in tests
+	conflicts map[string]*PageConflict // Refused saves by page key, until resolved
+	lastRename *pageRename // Most recent rename, until it's undone
+	trashRetention time.Duration // Age at which deleted pages are purged on load, 0 to keep them
+	config *config.Config // Settings of the loaded library
+	history *history.Store // Versions of pages saved in the library
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
 	return &App{
 		pages: make(map[string]*parser.Page),
-		pageNameMap: make(map[string]string),
+		journalFormat: parser.DefaultJournalFormat,
+		fileNameFormat: parser.DefaultFileNameFormat,
+		now: time.Now,
 	}
 }
 
@@ -61,6 +86,7 @@ func NewApp() *App {
 // so we can call the runtime methods
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
+	a.watchFiles = true
 	
 	// Initialize test capture if enabled
 	a.InitTestCapture()
@@ -87,88 +113,160 @@ func (a *App) startup(ctx context.Context) {
 
 // LoadDirectory loads all markdown files from a directory
 func (a *App) LoadDirectory(dirPath string) error {
-	// Check if this is a pages subdirectory or library root
+	a.journalsDir = ""
+	useCache := false
+	
 	if filepath.Base(dirPath) == "pages" {
-		// Store the library root (parent of pages)
+		// A pages subdirectory: the library root is its parent
 		a.currentDir = filepath.Dir(dirPath)
+		if err := a.loadConfig(); err != nil {
+			return err
+		}
 		a.pagesDir = dirPath
-		// But parse the pages directory
-		result, err := parser.ParseDirectoryWithCache(dirPath)
-		if err != nil {
-			return fmt.Errorf("error parsing directory: %w", err)
+		a.journalsDir = a.config.JournalsPath(a.currentDir)
+		useCache = true
+	} else {
+		a.currentDir = dirPath
+		if err := a.loadConfig(); err != nil {
+			return err
 		}
-		a.pages = result.Pages
-		a.backlinks = result.Backlinks
-		
-		// Build case-insensitive lookup map
-		a.pageNameMap = make(map[string]string)
-		for pageName := range a.pages {
-			a.pageNameMap[strings.ToLower(pageName)] = pageName
+		pagesDir := a.config.PagesPath(dirPath)
+		journalsDir := a.config.JournalsPath(dirPath)
+		if isDir(pagesDir) || isDir(journalsDir) {
+			// A Logseq-style library root with pages/ and journals/
+			a.pagesDir = pagesDir
+			a.journalsDir = journalsDir
+		} else {
+			// Fall back to parsing the directory itself
+			a.pagesDir = dirPath
 		}
-		
-		return nil
 	}
 	
-	// Otherwise assume it's the library root
-	a.currentDir = dirPath
-	
-	// Check if there's a pages subdirectory
-	pagesDir := filepath.Join(dirPath, "pages")
-	if info, err := os.Stat(pagesDir); err == nil && info.IsDir() {
-		a.pagesDir = pagesDir
-		// Parse the pages subdirectory
-		result, err := parser.ParseDirectory(pagesDir)
-		if err != nil {
-			return fmt.Errorf("error parsing directory: %w", err)
-		}
-		a.pages = result.Pages
-		a.backlinks = result.Backlinks
-		
-		// Build case-insensitive lookup map
-		a.pageNameMap = make(map[string]string)
-		for pageName := range a.pages {
-			a.pageNameMap[strings.ToLower(pageName)] = pageName
-		}
-		
-		return nil
+	a.conflicts = nil
+	a.lastRename = nil
+	a.history = history.New(filepath.Join(a.currentDir, filepath.FromSlash(historyDir)))
+	a.loadIgnoreRules()
+	if err := a.loadPages(useCache); err != nil {
+		return err
 	}
+	a.purgeExpiredTrash()
 	
-	// Fall back to parsing the directory itself
-	a.pagesDir = dirPath
-	result, err := parser.ParseDirectory(dirPath)
+	if a.watchFiles {
+		a.startWatching()
+	}
+	return nil
+}
+
+// shutdown is called when the app is closing
+func (a *App) shutdown(ctx context.Context) {
+	a.stopWatching()
+}
+
+// loadPages parses the pages and journals directories into the app state
+func (a *App) loadPages(useCache bool) error {
+	opts := a.parseOptions(useCache)
+	result, err := parser.ParseDirectoryWithOptions(a.pagesDir, opts)
 	if err != nil {
 		return fmt.Errorf("error parsing directory: %w", err)
 	}
 	
+	if a.journalsDir != "" && isDir(a.journalsDir) {
+		opts.Journal = true
+		journals, err := parser.ParseDirectoryWithOptions(a.journalsDir, opts)
+		if err != nil {
+			return fmt.Errorf("error parsing journals directory: %w", err)
+		}
+		result.Merge(journals)
+	}
+	
 	a.pages = result.Pages
 	a.backlinks = result.Backlinks
-	
-	// Build case-insensitive lookup map
-	a.pageNameMap = make(map[string]string)
-	for pageName := range a.pages {
-		a.pageNameMap[strings.ToLower(pageName)] = pageName
+	a.recordFiles(result)
+	for _, err := range result.Errors {
+		fmt.Printf("Warning: %v\n", err)
 	}
+	a.unlinked = nil
+	a.namespaces = nil
+	a.linkGraph = nil
 	
 	return nil
 }
 
-// RefreshPages reloads all pages from the current directory
+// pageKey returns the normalised key identifying a page name, without
+// resolving aliases
+func (a *App) pageKey(pageName string) string {
+	return a.journalFormat.PageKey(pageName)
+}
+
+// findPage looks up a page by any spelling of its name: case, whitespace,
+// date format or alias
+func (a *App) findPage(pageName string) (*parser.Page, bool) {
+	if a.backlinks == nil {
+		return nil, false
+	}
+	page, exists := a.pages[a.backlinks.Key(pageName)]
+	return page, exists
+}
+
+// isDir reports whether path exists and is a directory
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// loadConfig reads the vault's settings from seq2b.yaml and
+// logseq/config.edn. An invalid config stops the library loading, so a
+// mistake isn't silently replaced by defaults.
+func (a *App) loadConfig() error {
+	cfg, err := config.Load(a.currentDir)
+	if err != nil {
+		return err
+	}
+	a.config = cfg
+	a.journalFormat = cfg.JournalFormat()
+	a.fileNameFormat = cfg.FileNameFormat
+	a.trashRetention = time.Duration(cfg.TrashRetentionDays) * 24 * time.Hour
+	return nil
+}
+
+// loadIgnoreRules reads the library's .seq2bignore, and skips the page
+// cache wherever the vault's settings put it
+func (a *App) loadIgnoreRules() {
+	rules, err := parser.LoadIgnoreRules(a.currentDir)
+	if err != nil {
+		fmt.Printf("Warning: %v, using default ignore rules\n", err)
+		rules = parser.NewIgnoreRules(a.currentDir, "")
+	}
+	opts := a.parseOptions(false)
+	for _, dir := range a.pageDirs() {
+		rules.SkipDir(opts.CachePath(dir))
+	}
+	a.ignore = rules
+}
+
+// parseOptions returns the parser options for the loaded vault
+func (a *App) parseOptions(useCache bool) parser.ParseOptions {
+	opts := parser.DefaultParseOptions()
+	if a.config != nil {
+		opts = a.config.ParseOptions(a.currentDir)
+	}
+	opts.JournalFormat = a.journalFormat
+	opts.UseCache = useCache
+	opts.Ignore = a.ignore
+	opts.FileNameFormat = a.fileNameFormat
+	return opts
+}
+
+// GetJournalFormat returns the journal title and filename formats in use
+func (a *App) GetJournalFormat() parser.JournalFormat {
+	return a.journalFormat
+}
+
+// RefreshPages reloads all pages from the current directory. Most calls
+// only need syncPages, which reparses just the files that changed.
 func (a *App) RefreshPages() error {
 	if a.pagesDir != "" {
-		// If we have a specific pages directory, parse that
-		result, err := parser.ParseDirectoryWithCache(a.pagesDir)
-		if err != nil {
-			return fmt.Errorf("error parsing directory: %w", err)
-		}
-		a.pages = result.Pages
-		a.backlinks = result.Backlinks
-		
-		// Rebuild case-insensitive lookup map
-		a.pageNameMap = make(map[string]string)
-		for pageName := range a.pages {
-			a.pageNameMap[strings.ToLower(pageName)] = pageName
-		}
-		return nil
+		return a.loadPages(true)
 	}
 	
 	if a.currentDir == "" {
@@ -179,25 +277,21 @@ func (a *App) RefreshPages() error {
 
 // GetPage returns page data for display
 func (a *App) GetPage(pageName string) (*PageData, error) {
-	// Always refresh before getting a page to ensure we have latest content
-	if err := a.RefreshPages(); err != nil {
-		// Log error but continue with cached version
-		fmt.Printf("Warning: failed to refresh pages: %v\n", err)
-	}
+	// Pick up pages edited outside the app
+	a.syncPages()
 	
-	// Try exact match first
-	page, exists := a.pages[pageName]
+	page, exists := a.findPage(pageName)
 	if !exists {
-		// Try case-insensitive lookup
-		if actualName, found := a.pageNameMap[strings.ToLower(pageName)]; found {
-			page = a.pages[actualName]
-			exists = true
+		// Journal pages are titled with the vault's format, whatever
+		// spelling of the date was used to link to them
+		if date, err := a.journalFormat.ParseTitle(pageName); err == nil {
+			pageName = a.journalFormat.FormatTitle(date)
 		}
 	}
 	
 	if !exists {
 		// Auto-create the page
-		if parser.IsDatePage(pageName) {
+		if a.journalFormat.IsJournalTitle(pageName) {
 			// Create date page with special handling
 			if err := a.createDatePage(pageName); err != nil {
 				return nil, fmt.Errorf("failed to create date page: %w", err)
@@ -209,27 +303,36 @@ func (a *App) GetPage(pageName string) (*PageData, error) {
 			}
 		}
 		
-		// Refresh pages to load the new page
-		if err := a.RefreshPages(); err != nil {
-			return nil, fmt.Errorf("failed to refresh after creating page: %w", err)
-		}
-		
-		// Try to get the page again with case-insensitive lookup
-		page, exists = a.pages[pageName]
-		if !exists {
-			// Try case-insensitive lookup
-			if actualName, found := a.pageNameMap[strings.ToLower(pageName)]; found {
-				page = a.pages[actualName]
-				exists = true
-			}
-		}
+		// Try to get the page again
+		page, exists = a.findPage(pageName)
 		if !exists {
 			return nil, fmt.Errorf("page not found after creation: %s", pageName)
 		}
 	}
 	
-	// Get backlinks for this page (use actual page name for correct lookup)
-	backlinks := a.backlinks.GetBacklinks(page.Title)
+	result := a.buildPageData(pageName, page)
+	
+	// Navigating to an alias lands on the canonical page
+	if a.pageKey(pageName) != a.pageKey(page.Title) {
+		result.Name = page.Title
+		result.RedirectedFrom = pageName
+	}
+	
+	// Log API call if in test mode
+	if a.TestMode && testCapture != nil {
+		testCapture.LogAPICall("GetPage", 
+			map[string]string{"pageName": pageName}, 
+			map[string]interface{}{"data": result, "error": nil})
+	}
+	
+	return result, nil
+}
+
+// buildPageData assembles the frontend representation of a page
+func (a *App) buildPageData(pageName string, page *parser.Page) *PageData {
+	// Get backlinks for this page (use actual page name for correct lookup),
+	// leaving out those hidden by the page's filters
+	backlinks, filters, hidden := a.filterBacklinks(page, a.backlinks.GetBacklinks(page.Title))
 	
 	// Collect page-level properties from all blocks
 	pageProperties := make(map[string]string)
@@ -237,60 +340,57 @@ func (a *App) GetPage(pageName string) (*PageData, error) {
 		collectPageProperties(block, pageProperties)
 	}
 	
-	result := &PageData{
+	return &PageData{
 		Name: pageName,
 		Title: page.Title,
 		Blocks: convertBlocks(page.Blocks),
-		Backlinks: convertBacklinks(backlinks),
+		Backlinks: a.convertBacklinks(backlinks, false),
+		BacklinkFilters: filters,
+		HiddenBacklinks: hidden,
 		Properties: pageProperties,
+		IsJournal: page.IsJournal,
+		Breadcrumbs: a.breadcrumbs(page.Title),
 	}
-	
-	// Log API call if in test mode
-	if a.TestMode && testCapture != nil {
-		testCapture.LogAPICall("GetPage", 
-			map[string]string{"pageName": pageName}, 
-			map[string]interface{}{"data": result, "error": nil})
-	}
-	
-	return result, nil
 }
 
 // GetPageList returns all available pages
 func (a *App) GetPageList() []string {
-	// Refresh pages to get latest list
-	if err := a.RefreshPages(); err != nil {
-		fmt.Printf("Warning: failed to refresh pages: %v\n", err)
-	}
+	// Pick up pages added or removed outside the app
+	a.syncPages()
 	
 	pages := make([]string, 0, len(a.pages))
-	for name := range a.pages {
-		pages = append(pages, name)
+	for _, page := range a.pages {
+		pages = append(pages, page.Title)
 	}
 	return pages
 }
 
 // GetBacklinks returns backlinks for a page
 func (a *App) GetBacklinks(pageName string) map[string][]string {
-	// Use case-insensitive lookup to find actual page name
-	actualPageName := pageName
-	if actualName, found := a.pageNameMap[strings.ToLower(pageName)]; found {
-		actualPageName = actualName
-	}
-	
-	backlinks := a.backlinks.GetBacklinks(actualPageName)
+	// Keys are case-insensitive, so any spelling finds the backlinks
+	backlinks := a.backlinks.GetBacklinks(pageName)
 	result := make(map[string][]string)
 	
-	for sourcePage, refs := range backlinks {
+	for sourceKey, refs := range backlinks {
 		blockIDs := make([]string, len(refs))
 		for i, ref := range refs {
 			blockIDs[i] = ref.BlockID
 		}
-		result[sourcePage] = blockIDs
+		result[a.backlinks.Title(sourceKey)] = blockIDs
 	}
 	
 	return result
 }
 
+// GetAliasConflicts returns aliases claimed by several pages or clashing
+// with an existing page; such aliases don't resolve until fixed
+func (a *App) GetAliasConflicts() []parser.AliasConflict {
+	if a.backlinks == nil {
+		return []parser.AliasConflict{}
+	}
+	return a.backlinks.AliasConflicts()
+}
+
 // PageData represents page data for frontend
 type PageData struct {
 	Name string `json:"name"`
@@ -298,6 +398,11 @@ type PageData struct {
 	Blocks []BlockData `json:"blocks"`
 	Backlinks []BacklinkData `json:"backlinks"`
 	Properties map[string]string `json:"properties"`
+	IsJournal bool `json:"isJournal"`
+	RedirectedFrom string `json:"redirectedFrom,omitempty"` // Alias used to reach the page
+	Breadcrumbs []BreadcrumbData `json:"breadcrumbs"` // Enclosing namespaces, outermost first
+	BacklinkFilters []BacklinkFilterData `json:"backlinkFilters"` // From the page's filters:: property
+	HiddenBacklinks int `json:"hiddenBacklinks"` // References left out by the filters
 }
 
 // SegmentData represents a text segment for frontend
@@ -327,6 +432,8 @@ type BacklinkData struct {
 	SourcePage string `json:"sourcePage"`
 	BlockIDs []string `json:"blockIds"`
 	Count int `json:"count"`
+	IsJournal bool `json:"isJournal"`
+	Blocks []BacklinkBlockData `json:"blocks"` // Referencing blocks in page order
 }
 
 // collectPageProperties collects properties from top-level blocks
@@ -387,7 +494,7 @@ func convertSegments(segments []parser.Segment) []SegmentData {
 
 // UpdateBlock updates a block's content in a page
 func (a *App) UpdateBlock(pageName string, blockID string, newContent string) error {
-	page, exists := a.pages[pageName]
+	page, exists := a.findPage(pageName)
 	if !exists {
 		return fmt.Errorf("page '%s' not found", pageName)
 	}
@@ -399,8 +506,8 @@ func (a *App) UpdateBlock(pageName string, blockID string, newContent string) er
 			return fmt.Errorf("failed to save page: %w", err)
 		}
 		
-		// Reparse the entire directory to update backlinks
-		return a.RefreshPages()
+		a.reindexPage(page)
+		return nil
 	}
 	
 	return fmt.Errorf("block '%s' not found in page '%s'", blockID, pageName)
@@ -409,7 +516,7 @@ func (a *App) UpdateBlock(pageName string, blockID string, newContent string) er
 // UpdateBlockAtPath updates a block's content using positional addressing
 func (a *App) UpdateBlockAtPath(pageName string, path BlockPath, newContent string) (map[string]interface{}, error) {
 	// Work with current state for incremental updates
-	page, exists := a.pages[pageName]
+	page, exists := a.findPage(pageName)
 	if !exists {
 		return nil, fmt.Errorf("page '%s' not found", pageName)
 	}
@@ -449,139 +556,130 @@ func (a *App) UpdateBlockAtPath(pageName string, path BlockPath, newContent stri
 		"oldContent": oldContent,
 	}
 	
-	// Update backlinks incrementally if references changed
-	oldRefs := extractPageReferences(oldContent)
-	newRefs := extractPageReferences(newContent)
-	
-	// Update backlinks for changed references
-	for _, ref := range oldRefs {
-		if !contains(newRefs, ref) {
-			// Reference removed
-			a.removeBacklink(ref, pageName)
-		}
-	}
-	for _, ref := range newRefs {
-		if !contains(oldRefs, ref) {
-			// Reference added
-			a.addBacklink(ref, pageName)
-		}
-	}
+	// Update backlinks incrementally
+	a.reindexPage(page)
 	
 	return delta, nil
 }
 
-// Helper functions for incremental backlink updates
-func (a *App) addBacklink(targetPage, sourcePage string) {
+// reindexPage brings the backlink index up to date after a page has been
+// edited in memory, without reparsing the directory
+func (a *App) reindexPage(page *parser.Page) {
+	page.AllBlocks = page.GetAllBlocks()
 	if a.backlinks == nil {
-		a.backlinks = &parser.BacklinkIndex{
-			ForwardLinks:  make(map[string]map[string][]parser.BlockReference),
-			BackwardLinks: make(map[string]map[string][]parser.BlockReference),
-		}
-	}
-	
-	// Add to backward links
-	if _, exists := a.backlinks.BackwardLinks[targetPage]; !exists {
-		a.backlinks.BackwardLinks[targetPage] = make(map[string][]parser.BlockReference)
-	}
-	if _, exists := a.backlinks.BackwardLinks[targetPage][sourcePage]; !exists {
-		a.backlinks.BackwardLinks[targetPage][sourcePage] = []parser.BlockReference{}
+		a.backlinks = parser.NewBacklinkIndex()
+		a.backlinks.KeyFunc = a.journalFormat.PageKey
 	}
+	a.backlinks.UpdatePage(page)
 	
-	// Add to forward links
-	if _, exists := a.backlinks.ForwardLinks[sourcePage]; !exists {
-		a.backlinks.ForwardLinks[sourcePage] = make(map[string][]parser.BlockReference)
-	}
-	if _, exists := a.backlinks.ForwardLinks[sourcePage][targetPage]; !exists {
-		a.backlinks.ForwardLinks[sourcePage][targetPage] = []parser.BlockReference{}
-	}
+	// Mentions are found in block content, so the unlinked index is stale
+	a.unlinked = nil
+	a.linkGraph = nil
 }
 
-func (a *App) removeBacklink(targetPage, sourcePage string) {
-	if a.backlinks == nil {
-		return
+// savePage writes a page back to disk
+func (a *App) savePage(page *parser.Page) error {
+	// Reconstruct the markdown content
+	content := a.pageToMarkdown(page)
+	
+	// Use pagesDir if available, otherwise currentDir
+	dir := a.pagesDir
+	if dir == "" {
+		dir = a.currentDir
 	}
 	
-	// Remove from backward links
-	if sources, exists := a.backlinks.BackwardLinks[targetPage]; exists {
-		delete(sources, sourcePage)
-		if len(sources) == 0 {
-			delete(a.backlinks.BackwardLinks, targetPage)
+	// Determine the filename
+	var filePath string
+	if page.Path != "" {
+		// Loaded pages are written back in place, whichever subdirectory
+		// they're in
+		filePath = page.Path
+	} else if date, err := a.journalFormat.ParseTitle(page.Title); err == nil {
+		// Date pages keep whichever journal file they already have
+		filePath = a.journalFilePath(date)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			return fmt.Errorf("failed to create journals directory: %w", err)
+		}
+	} else {
+		// Regular pages use title-based filenames
+		filePath = a.pageFilePath(dir, page.Title)
+		if err := a.checkFileCollision(filePath, page.Title); err != nil {
+			return err
 		}
 	}
 	
-	// Remove from forward links
-	if targets, exists := a.backlinks.ForwardLinks[sourcePage]; exists {
-		delete(targets, targetPage)
-		if len(targets) == 0 {
-			delete(a.backlinks.ForwardLinks, sourcePage)
-		}
+	// Refuse to overwrite changes made outside the app since the page was
+	// read, then write atomically so a crash can't truncate the file
+	if err := a.checkConflict(filePath, page, []byte(content)); err != nil {
+		return err
 	}
+	a.recordOriginal(filePath, page.Title)
+	if err := writeFileAtomic(filePath, []byte(content), 0644); err != nil {
+		return err
+	}
+	a.fileWritten(filePath, page, parser.ContentHash([]byte(content)))
+	a.recordVersion(page.Title, []byte(content))
+	return nil
 }
 
-// extractPageReferences finds all [[page]] references in text
-func extractPageReferences(text string) []string {
-	pattern := regexp.MustCompile(`\[\[(.*?)\]\]`)
-	matches := pattern.FindAllStringSubmatch(text, -1)
-	references := make([]string, 0, len(matches))
-	
-	for _, match := range matches {
-		if len(match) > 1 {
-			references = append(references, match[1])
+// pageFilePath returns the file for a regular page in dir, keeping any file
+// written under another filename encoding. A file holding a different page
+// is passed over, as "projects-legacy.md" is for "Projects-Legacy" when it
+// holds "Projects/Legacy".
+func (a *App) pageFilePath(dir string, title string) string {
+	key := a.pageKey(title)
+	candidates := a.fileNameFormat.CandidateFileNames(title)
+	for _, filename := range candidates {
+		filePath := filepath.Join(dir, filename)
+		if fileKey, ok := a.fileKey(filePath); ok && fileKey == key {
+			return filePath
 		}
 	}
-	
-	return references
+	return filepath.Join(dir, candidates[0])
 }
 
-// contains checks if a string slice contains a value
-func contains(slice []string, value string) bool {
-	for _, v := range slice {
-		if v == value {
-			return true
-		}
+// fileKey returns the key of the page a file holds, reparsing it if it
+// hasn't been loaded
+func (a *App) fileKey(filePath string) (string, bool) {
+	if state, ok := a.recordedFile(filePath); ok {
+		return state.key, true
 	}
-	return false
+	page, err := parser.ParsePageFile(filePath, a.parseOptions(false))
+	if err != nil {
+		return "", false
+	}
+	return a.pageKey(page.Title), true
 }
 
-// savePage writes a page back to disk
-func (a *App) savePage(page *parser.Page) error {
-	// Reconstruct the markdown content
-	content := a.pageToMarkdown(page)
-	
-	// Determine the filename
-	var filename string
-	if parser.IsDatePage(page.Title) {
-		// For date pages, parse the title and use ISO format
-		date, err := parser.ParseDateTitle(page.Title)
-		if err != nil {
-			// Fall back to regular filename conversion
-			filename = parser.TitleToFilename(page.Title)
-		} else {
-			filename = parser.GetDatePageFilename(date)
-		}
-	} else {
-		// Regular pages use title-based filenames
-		filename = parser.TitleToFilename(page.Title)
+// checkFileCollision returns a FileNameCollisionError if filePath already
+// holds a page other than title
+func (a *App) checkFileCollision(filePath string, title string) error {
+	key, ok := a.fileKey(filePath)
+	if !ok || key == a.pageKey(title) {
+		return nil
 	}
-	
-	// Use pagesDir if available, otherwise currentDir
-	dir := a.pagesDir
-	if dir == "" {
-		dir = a.currentDir
+	existing := key
+	if page, exists := a.pages[key]; exists {
+		existing = page.Title
 	}
-	filePath := filepath.Join(dir, filename)
-	
-	// Write to file
-	return os.WriteFile(filePath, []byte(content), 0644)
+	return &parser.FileNameCollisionError{Title: title, Path: filePath, ExistingTitle: existing}
 }
 
 // pageToMarkdown converts a page back to markdown format
 func (a *App) pageToMarkdown(page *parser.Page) string {
 	var lines []string
 	
-	// Add title as header
-	lines = append(lines, "# " + page.Title)
-	lines = append(lines, "")
+	// Keep the page's header if it had one, followed by page-level
+	// properties
+	if page.Header != "" {
+		lines = append(lines, page.Header)
+	}
+	for _, key := range page.PropertyKeys() {
+		lines = append(lines, key + ":: " + page.Properties[key])
+	}
+	if len(lines) > 0 {
+		lines = append(lines, "")
+	}
 	
 	// Convert blocks to markdown
 	a.blocksToMarkdown(page.Blocks, &lines, 0)
@@ -591,14 +689,33 @@ func (a *App) pageToMarkdown(page *parser.Page) string {
 	if len(content) > 0 && content[len(content)-1] != '\n' {
 		content += "\n"
 	}
-	return content
+	
+	// Keep the character set, BOM and line endings of the page's file
+	return string(page.Encoding.EncodeText(content))
+}
+
+// todoKeywords returns the words marking a block as a task in the vault
+func (a *App) todoKeywords() *parser.TodoKeywords {
+	if a.config == nil {
+		return parser.DefaultTodoKeywords
+	}
+	return a.config.Keywords()
+}
+
+// indentWidth returns the number of spaces per nesting level in the vault
+func (a *App) indentWidth() int {
+	if a.config == nil {
+		return parser.DefaultIndentWidth
+	}
+	return a.config.IndentWidth
 }
 
 // blocksToMarkdown recursively converts blocks to markdown lines
 func (a *App) blocksToMarkdown(blocks []*parser.Block, lines *[]string, depth int) {
 	for _, block := range blocks {
 		// Create indentation
-		indent := strings.Repeat("  ", depth)
+		width := a.indentWidth()
+		indent := strings.Repeat(" ", width * depth)
 		
 		// Add the block content with proper indentation
 		blockLines := strings.Split(block.Content, "\n")
@@ -606,7 +723,8 @@ func (a *App) blocksToMarkdown(blocks []*parser.Block, lines *[]string, depth in
 			if i == 0 {
 				*lines = append(*lines, indent + "- " + line)
 			} else {
-				*lines = append(*lines, indent + "  " + line)
+				// Continuation lines sit one level in, under the bullet
+				*lines = append(*lines, indent + strings.Repeat(" ", width) + line)
 			}
 		}
 		
@@ -619,70 +737,90 @@ func (a *App) blocksToMarkdown(blocks []*parser.Block, lines *[]string, depth in
 
 // createPage creates a new regular page with default content
 func (a *App) createPage(pageTitle string) error {
-	// Generate the filename
-	filename := parser.TitleToFilename(pageTitle)
 	// Use pagesDir if available, otherwise currentDir
 	dir := a.pagesDir
 	if dir == "" {
 		dir = a.currentDir
 	}
-	filePath := filepath.Join(dir, filename)
+	filePath := a.pageFilePath(dir, pageTitle)
+	if err := a.checkFileCollision(filePath, pageTitle); err != nil {
+		return err
+	}
 	
 	// Check if file already exists
 	if _, err := os.Stat(filePath); err == nil {
-		// File already exists, no need to create
+		// File already exists, no need to create, but it may not be loaded
+		a.applyFileChanges([]string{filePath})
 		return nil
 	}
 	
-	// Create the file
-	file, err := os.Create(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer file.Close()
-	
 	// Write default content
 	content := fmt.Sprintf(`# %s
 
 - 
 `, pageTitle)
 	
-	if _, err := file.WriteString(content); err != nil {
-		return fmt.Errorf("failed to write content: %w", err)
+	if err := writeFileAtomic(filePath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
 	}
 	
+	// Load the new page
+	a.applyFileChanges([]string{filePath})
 	return nil
 }
 
-// createDatePage creates a new date page with default content
-func (a *App) createDatePage(dateTitle string) error {
-	// Parse the date from the title
-	date, err := parser.ParseDateTitle(dateTitle)
-	if err != nil {
-		return fmt.Errorf("invalid date title: %w", err)
+// journalFilePath returns the file for a journal date. An existing file in
+// any known journal filename format, in either the journals or pages
+// directory, is reused so imported journals aren't duplicated; otherwise
+// the configured format in the journals directory is used.
+func (a *App) journalFilePath(date time.Time) string {
+	candidates := a.journalFormat.CandidateFileNames(date)
+	for _, dir := range a.journalDirs() {
+		for _, name := range candidates {
+			filePath := filepath.Join(dir, name)
+			if _, err := os.Stat(filePath); err == nil {
+				return filePath
+			}
+		}
 	}
-	
-	// Generate the filename
-	filename := parser.GetDatePageFilename(date)
+	return filepath.Join(a.journalDirs()[0], candidates[0])
+}
+
+// journalDirs returns the directories that may hold journal pages, the one
+// new journals are written to first
+func (a *App) journalDirs() []string {
 	// Use pagesDir if available, otherwise currentDir
 	dir := a.pagesDir
 	if dir == "" {
 		dir = a.currentDir
 	}
-	filePath := filepath.Join(dir, filename)
+	if a.journalsDir != "" {
+		return []string{a.journalsDir, dir}
+	}
+	return []string{dir}
+}
+
+// createDatePage creates a new date page with default content
+func (a *App) createDatePage(dateTitle string) error {
+	// Parse the date from the title
+	date, err := a.journalFormat.ParseTitle(dateTitle)
+	if err != nil {
+		return fmt.Errorf("invalid date title: %w", err)
+	}
+	dateTitle = a.journalFormat.FormatTitle(date)
+	filePath := a.journalFilePath(date)
 	
 	// Check if file already exists
 	if _, err := os.Stat(filePath); err == nil {
-		// File already exists, no need to create
+		// File already exists, no need to create, but it may not be loaded
+		a.applyFileChanges([]string{filePath})
 		return nil
 	}
 	
-	// Create the file
-	file, err := os.Create(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+	// The journals directory may not exist yet in a fresh library
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create journals directory: %w", err)
 	}
-	defer file.Close()
 	
 	// Write default content for a journal page
 	content := fmt.Sprintf(`# %s
@@ -691,10 +829,12 @@ func (a *App) createDatePage(dateTitle string) error {
 
 `, dateTitle)
 	
-	if _, err := file.WriteString(content); err != nil {
-		return fmt.Errorf("failed to write content: %w", err)
+	if err := writeFileAtomic(filePath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
 	}
 	
+	// Load the new page
+	a.applyFileChanges([]string{filePath})
 	return nil
 }
 
@@ -713,25 +853,6 @@ func updateBlockContent(blocks []*parser.Block, targetID string, newContent stri
 	return false
 }
 
-func convertBacklinks(backlinks map[string][]parser.BlockReference) []BacklinkData {
-	result := make([]BacklinkData, 0, len(backlinks))
-	
-	for sourcePage, refs := range backlinks {
-		blockIDs := make([]string, len(refs))
-		for i, ref := range refs {
-			blockIDs[i] = ref.BlockID
-		}
-		
-		result = append(result, BacklinkData{
-			SourcePage: sourcePage,
-			BlockIDs: blockIDs,
-			Count: len(refs),
-		})
-	}
-	
-	return result
-}
-
 // generateBlockID generates a unique block ID
 func generateBlockID() string {
 	// Generate 16 random bytes
@@ -746,12 +867,10 @@ func generateBlockID() string {
 
 // AddBlock adds a new block to a page
 func (a *App) AddBlock(pageName string, parentBlockID string, afterBlockID string, content string, depth int) (map[string]interface{}, error) {
-	// Refresh to ensure we have latest content
-	if err := a.RefreshPages(); err != nil {
-		return nil, fmt.Errorf("failed to refresh pages: %w", err)
-	}
+	// Pick up pages edited outside the app
+	a.syncPages()
 	
-	page, exists := a.pages[pageName]
+	page, exists := a.findPage(pageName)
 	if !exists {
 		return nil, fmt.Errorf("page '%s' not found", pageName)
 	}
@@ -764,15 +883,9 @@ func (a *App) AddBlock(pageName string, parentBlockID string, afterBlockID strin
 		Children: []*parser.Block{},
 	}
 	
-	// Parse the content into lines
-	lines := strings.Split(content, "\n")
-	newBlock.Lines = make([]parser.Line, len(lines))
-	for i, line := range lines {
-		newBlock.Lines[i] = parser.ParseLine(i+1, line)
-	}
-	
-	// Update block content to parse TODO info and segments
+	// Parse the content, with the vault's TODO keywords
 	newBlock.SetContent(content)
+	a.todoKeywords().Apply([]*parser.Block{newBlock})
 	
 	// Find insertion point
 	if parentBlockID != "" {
@@ -831,10 +944,8 @@ func (a *App) AddBlock(pageName string, parentBlockID string, afterBlockID strin
 		return nil, fmt.Errorf("failed to save page: %w", err)
 	}
 	
-	// Refresh to update backlinks
-	if err := a.RefreshPages(); err != nil {
-		return nil, fmt.Errorf("failed to refresh after save: %w", err)
-	}
+	// Update backlinks
+	a.reindexPage(page)
 	
 	// Return the new block data
 	blockData := BlockData{
@@ -858,7 +969,7 @@ func (a *App) AddBlock(pageName string, parentBlockID string, afterBlockID strin
 // AddBlockAtPath adds a new block using positional addressing
 func (a *App) AddBlockAtPath(pageName string, insertPath BlockPath, content string) (map[string]interface{}, error) {
 	// Work with current state for incremental updates
-	page, exists := a.pages[pageName]
+	page, exists := a.findPage(pageName)
 	if !exists {
 		return nil, fmt.Errorf("page '%s' not found", pageName)
 	}
@@ -869,15 +980,9 @@ func (a *App) AddBlockAtPath(pageName string, insertPath BlockPath, content stri
 		Children: []*parser.Block{},
 	}
 	
-	// Parse the content into lines
-	lines := strings.Split(content, "\n")
-	newBlock.Lines = make([]parser.Line, len(lines))
-	for i, line := range lines {
-		newBlock.Lines[i] = parser.ParseLine(i+1, line)
-	}
-	
-	// Update block content to parse TODO info and segments
+	// Parse the content, with the vault's TODO keywords
 	newBlock.SetContent(content)
+	a.todoKeywords().Apply([]*parser.Block{newBlock})
 	
 	// Handle insertion based on path
 	if len(insertPath) == 0 {
@@ -955,10 +1060,7 @@ func (a *App) AddBlockAtPath(pageName string, insertPath BlockPath, content stri
 	}
 	
 	// Update backlinks for any references in the new block
-	refs := extractPageReferences(content)
-	for _, ref := range refs {
-		a.addBacklink(ref, pageName)
-	}
+	a.reindexPage(page)
 	
 	return delta, nil
 }
@@ -1032,4 +1134,4 @@ func (a *App) GetAsset(assetPath string) (string, error) {
 	}
 	
 	return fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(data)), nil
-}
+}
\ No newline at end of file
@@ -0,0 +1,75 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestAliasNavigationAndBacklinks verifies aliases resolve to the canonical page
+func TestAliasNavigationAndBacklinks(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "seq2b-alias-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	files := map[string]string{
+		"syntax-tree.md": "# Syntax Tree\nalias:: AST, Parse Tree\n\n- Tree of tokens",
+		"notes.md":       "# Notes\n\n- Built the [[AST]]\n- Printed the [[parse tree]]\n- Read about [[Syntax Tree]]",
+		"other.md":       "# Other\nalias:: Parse Tree\n\n- Also claims the alias",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	app := NewApp()
+	if err := app.LoadDirectory(tempDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	pageData, err := app.GetPage("ast")
+	if err != nil {
+		t.Fatalf("GetPage failed: %v", err)
+	}
+	if pageData.Title != "Syntax Tree" || pageData.Name != "Syntax Tree" || pageData.RedirectedFrom != "ast" {
+		t.Errorf("Expected redirect to Syntax Tree, got name=%q title=%q from=%q",
+			pageData.Name, pageData.Title, pageData.RedirectedFrom)
+	}
+	if len(pageData.Backlinks) != 1 || pageData.Backlinks[0].Count != 2 {
+		t.Errorf("Expected 2 merged backlinks from Notes, got %+v", pageData.Backlinks)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "ast.md")); err == nil {
+		t.Error("Navigating to an alias should not create a page")
+	}
+
+	// Parse Tree is claimed twice, so it is reported and not resolved
+	conflicts := app.GetAliasConflicts()
+	if len(conflicts) != 1 || conflicts[0].Alias != "Parse Tree" || len(conflicts[0].Pages) != 2 {
+		t.Errorf("Unexpected alias conflicts: %+v", conflicts)
+	}
+}
@@ -0,0 +1,165 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// BacklinkBlockData is a block that references a page, with enough context
+// to read it without opening the source page
+type BacklinkBlockData struct {
+	ID string `json:"id"`
+	Content string `json:"content"`
+	Segments []SegmentData `json:"segments"`
+	TodoState string `json:"todoState"`
+	Path BlockPath `json:"path"` // Position of the block in the source page
+	Breadcrumb []string `json:"breadcrumb"` // Ancestor block contents, outermost first
+	Children []BlockData `json:"children,omitempty"` // Only when requested
+}
+
+// GetLinkedReferences returns the blocks linking to a page, grouped by source
+// page, optionally with the children of each referencing block
+func (a *App) GetLinkedReferences(pageName string, includeChildren bool) ([]BacklinkData, error) {
+	a.syncPages()
+	if a.backlinks == nil {
+		return nil, fmt.Errorf("no directory loaded")
+	}
+	
+	return a.convertBacklinks(a.backlinks.GetBacklinks(pageName), includeChildren), nil
+}
+
+// convertBacklinks groups references by source page with the context of each
+// referencing block. Journal pages come first, newest first, followed by
+// other pages in title order.
+func (a *App) convertBacklinks(backlinks map[string][]parser.BlockReference, includeChildren bool) []BacklinkData {
+	result := make([]BacklinkData, 0, len(backlinks))
+	dates := make(map[string]int64, len(backlinks))
+	
+	for sourceKey, refs := range backlinks {
+		blockIDs := make([]string, len(refs))
+		for i, ref := range refs {
+			blockIDs[i] = ref.BlockID
+		}
+		
+		data := BacklinkData{
+			SourcePage: a.backlinks.Title(sourceKey),
+			BlockIDs: blockIDs,
+			Count: len(refs),
+			Blocks: []BacklinkBlockData{},
+		}
+		if date, err := a.journalFormat.ParseTitle(data.SourcePage); err == nil {
+			data.IsJournal = true
+			dates[data.SourcePage] = date.Unix()
+		}
+		if page, ok := a.pages[sourceKey]; ok {
+			data.Blocks = backlinkBlocks(page, blockIDs, includeChildren)
+		}
+		result = append(result, data)
+	}
+	
+	sort.Slice(result, func(i, j int) bool {
+		left, right := result[i], result[j]
+		if left.IsJournal != right.IsJournal {
+			return left.IsJournal
+		}
+		if left.IsJournal && dates[left.SourcePage] != dates[right.SourcePage] {
+			return dates[left.SourcePage] > dates[right.SourcePage]
+		}
+		leftTitle, rightTitle := strings.ToLower(left.SourcePage), strings.ToLower(right.SourcePage)
+		if leftTitle != rightTitle {
+			return leftTitle < rightTitle
+		}
+		return left.SourcePage < right.SourcePage
+	})
+	return result
+}
+
+// backlinkBlocks resolves the referencing blocks of a page, once each even if
+// a block links several times, in page order
+func backlinkBlocks(page *parser.Page, blockIDs []string, includeChildren bool) []BacklinkBlockData {
+	blocks := []BacklinkBlockData{}
+	seen := make(map[string]bool, len(blockIDs))
+	
+	for _, blockID := range blockIDs {
+		if seen[blockID] {
+			continue
+		}
+		seen[blockID] = true
+		
+		block := findBlockByID(page.Blocks, blockID)
+		if block == nil {
+			continue
+		}
+		path, found := GetBlockPath(page.Blocks, block)
+		if !found {
+			continue
+		}
+		
+		data := BacklinkBlockData{
+			ID: block.ID,
+			Content: block.Content,
+			Segments: convertSegments(block.Segments),
+			TodoState: string(block.TodoInfo.TodoState),
+			Path: path,
+			Breadcrumb: blockBreadcrumb(page.Blocks, path),
+		}
+		if includeChildren {
+			data.Children = convertBlocks(block.Children)
+		}
+		blocks = append(blocks, data)
+	}
+	
+	sort.Slice(blocks, func(i, j int) bool {
+		return comparePaths(blocks[i].Path, blocks[j].Path) < 0
+	})
+	return blocks
+}
+
+// blockBreadcrumb returns the content of the blocks enclosing the block at
+// path, outermost first
+func blockBreadcrumb(blocks []*parser.Block, path BlockPath) []string {
+	breadcrumb := []string{}
+	for depth := 1; depth < len(path); depth++ {
+		ancestor, err := FindBlockByPath(blocks, path[:depth])
+		if err != nil {
+			break
+		}
+		breadcrumb = append(breadcrumb, ancestor.Content)
+	}
+	return breadcrumb
+}
+
+// comparePaths orders block paths as the blocks appear in the page
+func comparePaths(a, b BlockPath) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] - b[i]
+		}
+	}
+	return len(a) - len(b)
+}
\ No newline at end of file
@@ -0,0 +1,98 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestLinkedReferencesContext verifies backlinks carry block content,
+// breadcrumbs and paths, and are grouped in a stable order
+func TestLinkedReferencesContext(t *testing.T) {
+	libDir := setupJournalLibrary(t)
+	pages := map[string]string{
+		"pages/zeta.md":  "# zeta\n\n- Mentions [[Project]]",
+		"pages/alpha.md": "# Alpha\n\n- Meetings\n  - Weekly sync\n    - Discussed [[Project]] twice: [[project]]\n      - Action items\n- Unrelated",
+	}
+	for name, content := range pages {
+		if err := os.WriteFile(filepath.Join(libDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	backlinks, err := app.GetLinkedReferences("Project", true)
+	if err != nil {
+		t.Fatalf("GetLinkedReferences failed: %v", err)
+	}
+
+	// Journals newest first, then pages by title regardless of case
+	var order []string
+	for _, backlink := range backlinks {
+		order = append(order, backlink.SourcePage)
+	}
+	want := []string{"Jan 15th, 2025", "Jan 14th, 2025", "Jan 13th, 2025", "Alpha", "zeta"}
+	if !reflect.DeepEqual(order, want) {
+		t.Fatalf("Expected order %v, got %v", want, order)
+	}
+	if !backlinks[0].IsJournal || backlinks[3].IsJournal {
+		t.Error("Expected only journal groups to be marked as journals")
+	}
+
+	alpha := backlinks[3]
+	if alpha.Count != 2 || len(alpha.Blocks) != 1 {
+		t.Fatalf("Expected 2 references in one block from Alpha, got count=%d blocks=%d", alpha.Count, len(alpha.Blocks))
+	}
+	block := alpha.Blocks[0]
+	if block.Content != "Discussed [[Project]] twice: [[project]]" {
+		t.Errorf("Unexpected block content %q", block.Content)
+	}
+	if !reflect.DeepEqual(block.Path, BlockPath{0, 0, 0}) {
+		t.Errorf("Expected path [0 0 0], got %v", block.Path)
+	}
+	if !reflect.DeepEqual(block.Breadcrumb, []string{"Meetings", "Weekly sync"}) {
+		t.Errorf("Unexpected breadcrumb %v", block.Breadcrumb)
+	}
+	if len(block.Segments) == 0 {
+		t.Error("Expected block segments")
+	}
+	if len(block.Children) != 1 || block.Children[0].Content != "Action items" {
+		t.Errorf("Expected child block when requested, got %+v", block.Children)
+	}
+
+	// Page data leaves children out
+	pageData, err := app.GetPage("Project")
+	if err != nil {
+		t.Fatalf("GetPage failed: %v", err)
+	}
+	if children := pageData.Backlinks[3].Blocks[0].Children; children != nil {
+		t.Errorf("Expected no children in page backlinks, got %+v", children)
+	}
+}
@@ -62,7 +62,7 @@ func TestBugFixedWithPositionalAPI(t *testing.T) {
 	}
 	
 	// Verify the block was added
-	page := app.pages["Test Page"]
+	page, _ := app.findPage("Test Page")
 	if len(page.Blocks) != 2 {
 		t.Fatalf("Expected 2 blocks after add, got %d", len(page.Blocks))
 	}
@@ -0,0 +1,283 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+	
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// CalendarTask is a dated TODO shown on the calendar
+type CalendarTask struct {
+	PageName string `json:"pageName"`
+	BlockID string `json:"blockId"`
+	Content string `json:"content"`
+	TodoState string `json:"todoState"`
+	CheckboxState string `json:"checkboxState"`
+	Kind string `json:"kind"` // scheduled, deadline, journal or reference
+}
+
+// CalendarDay is one cell of the month grid
+type CalendarDay struct {
+	Date string `json:"date"` // ISO date
+	Day int `json:"day"`
+	Week int `json:"week"` // ISO week number
+	InMonth bool `json:"inMonth"` // False for padding days from adjacent months
+	IsToday bool `json:"isToday"`
+	JournalTitle string `json:"journalTitle,omitempty"` // Set when a journal page exists
+	BlockCount int `json:"blockCount"`
+	Tasks []CalendarTask `json:"tasks"`
+}
+
+// CalendarMonth is a Monday-first month grid
+type CalendarMonth struct {
+	Year int `json:"year"`
+	Month int `json:"month"`
+	Weeks [][]CalendarDay `json:"weeks"`
+}
+
+// WeekDay is one day of a week summary
+type WeekDay struct {
+	CalendarDay
+	Blocks []BlockData `json:"blocks"` // Top-level blocks of the journal page
+}
+
+// WeekSummary aggregates the journals and tasks of an ISO week
+type WeekSummary struct {
+	Year int `json:"year"`
+	Week int `json:"week"`
+	Start string `json:"start"` // Monday, ISO date
+	End string `json:"end"` // Sunday, ISO date
+	Days []WeekDay `json:"days"`
+	ReviewPage string `json:"reviewPage,omitempty"` // Title of the generated review page
+}
+
+// OnThisDayEntry is a journal from the same calendar day in an earlier year
+type OnThisDayEntry struct {
+	Date string `json:"date"`
+	YearsAgo int `json:"yearsAgo"`
+	Page PageData `json:"page"`
+}
+
+// today returns the current date at midnight UTC, matching parsed dates
+func (a *App) today() time.Time {
+	now := a.now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// GetCalendarMonth returns the month grid with journal pages and dated tasks
+func (a *App) GetCalendarMonth(year int, month int) (*CalendarMonth, error) {
+	if month < 1 || month > 12 {
+		return nil, fmt.Errorf("invalid month: %d", month)
+	}
+	
+	a.syncPages()
+	
+	first := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1)
+	gridStart := parser.WeekStart(first)
+	gridEnd := parser.WeekStart(last).AddDate(0, 0, 6)
+	
+	days := a.calendarDays(gridStart, gridEnd)
+	
+	result := &CalendarMonth{
+		Year:  year,
+		Month: month,
+		Weeks: [][]CalendarDay{},
+	}
+	for i := 0; i < len(days); i += 7 {
+		week := days[i : i+7]
+		for j := range week {
+			week[j].InMonth = week[j].Date[:7] == first.Format("2006-01")
+		}
+		result.Weeks = append(result.Weeks, week)
+	}
+	
+	return result, nil
+}
+
+// calendarDays builds a CalendarDay for every date in [start, end]
+func (a *App) calendarDays(start, end time.Time) []CalendarDay {
+	journals := parser.JournalPagesByDate(a.pages, a.journalFormat)
+	tasks := parser.FindDatedTasks(a.pages, a.journalFormat, start, end)
+	today := a.today()
+	
+	tasksByDate := make(map[string][]CalendarTask)
+	for _, task := range tasks {
+		key := parser.FormatDateISO(task.Date)
+		tasksByDate[key] = append(tasksByDate[key], CalendarTask{
+			PageName:      task.PageName,
+			BlockID:       task.Block.ID,
+			Content:       parser.RemoveTodoPrefix(task.Block.Content),
+			TodoState:     string(task.Block.TodoInfo.TodoState),
+			CheckboxState: string(task.Block.TodoInfo.CheckboxState),
+			Kind:          string(task.Kind),
+		})
+	}
+	
+	var days []CalendarDay
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		key := parser.FormatDateISO(date)
+		day := CalendarDay{
+			Date:    key,
+			Day:     date.Day(),
+			Week:    parser.GetWeekNumber(date),
+			IsToday: date.Equal(today),
+			Tasks:   tasksByDate[key],
+		}
+		if day.Tasks == nil {
+			day.Tasks = []CalendarTask{}
+		}
+		if page, ok := journals[key]; ok {
+			day.JournalTitle = page.Title
+			day.BlockCount = len(page.AllBlocks)
+		}
+		days = append(days, day)
+	}
+	return days
+}
+
+// GetWeek aggregates the journals and tasks of an ISO week. With
+// generateReview, a "Weekly Review" page collecting each day's top-level
+// blocks is created if it doesn't exist yet; an existing review page is
+// left untouched so notes added to it are never overwritten.
+func (a *App) GetWeek(year int, week int, generateReview bool) (*WeekSummary, error) {
+	if week < 1 || week > 53 {
+		return nil, fmt.Errorf("invalid week: %d", week)
+	}
+	
+	a.syncPages()
+	
+	start := parser.ISOWeekStart(year, week)
+	end := start.AddDate(0, 0, 6)
+	journals := parser.JournalPagesByDate(a.pages, a.journalFormat)
+	
+	summary := &WeekSummary{
+		Year:  year,
+		Week:  week,
+		Start: parser.FormatDateISO(start),
+		End:   parser.FormatDateISO(end),
+		Days:  []WeekDay{},
+	}
+	for _, day := range a.calendarDays(start, end) {
+		weekDay := WeekDay{CalendarDay: day, Blocks: []BlockData{}}
+		if page, ok := journals[day.Date]; ok {
+			weekDay.Blocks = convertBlocks(page.Blocks)
+		}
+		summary.Days = append(summary.Days, weekDay)
+	}
+	
+	if generateReview {
+		title, err := a.generateWeeklyReview(year, week, start, journals)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate weekly review: %w", err)
+		}
+		summary.ReviewPage = title
+	}
+	
+	return summary, nil
+}
+
+// weeklyReviewTitle names the review page for an ISO week
+func weeklyReviewTitle(year, week int) string {
+	return fmt.Sprintf("Weekly Review %d-W%02d", year, week)
+}
+
+// generateWeeklyReview writes the review page for a week unless it exists
+func (a *App) generateWeeklyReview(year, week int, start time.Time, journals map[string]*parser.Page) (string, error) {
+	title := weeklyReviewTitle(year, week)
+	if _, exists := a.findPage(title); exists {
+		return title, nil
+	}
+	
+	page := &parser.Page{Title: title}
+	for i := 0; i < 7; i++ {
+		date := start.AddDate(0, 0, i)
+		journal, ok := journals[parser.FormatDateISO(date)]
+		if !ok || len(journal.Blocks) == 0 {
+			continue
+		}
+		
+		// One block per day linking the journal, with its top-level
+		// blocks copied underneath
+		dayBlock := &parser.Block{}
+		dayBlock.SetContent("[[" + journal.Title + "]]")
+		for _, block := range journal.Blocks {
+			if block.Content == "" {
+				continue
+			}
+			child := &parser.Block{}
+			child.SetContent(block.Content)
+			dayBlock.AddChild(child)
+		}
+		page.Blocks = append(page.Blocks, dayBlock)
+	}
+	
+	if len(page.Blocks) == 0 {
+		empty := &parser.Block{}
+		empty.SetContent("No journal entries this week")
+		page.Blocks = append(page.Blocks, empty)
+	}
+	a.todoKeywords().Apply(page.Blocks)
+	
+	if err := a.savePage(page); err != nil {
+		return "", err
+	}
+	return title, nil
+}
+
+// GetOnThisDay lists journals written on the same month and day in earlier
+// years, most recent first. An empty date means today.
+func (a *App) GetOnThisDay(date string) ([]OnThisDayEntry, error) {
+	day := a.today()
+	if date != "" {
+		parsed, err := a.journalFormat.ParseTitle(date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date: %w", err)
+		}
+		day = parsed
+	}
+	
+	a.syncPages()
+	
+	entries := []OnThisDayEntry{}
+	for key, page := range parser.JournalPagesByDate(a.pages, a.journalFormat) {
+		journalDate, _ := time.Parse("2006-01-02", key)
+		if journalDate.Month() != day.Month() || journalDate.Day() != day.Day() || journalDate.Year() >= day.Year() {
+			continue
+		}
+		entries = append(entries, OnThisDayEntry{
+			Date:     key,
+			YearsAgo: day.Year() - journalDate.Year(),
+			Page:     *a.buildPageData(page.Title, page),
+		})
+	}
+	
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].YearsAgo < entries[j].YearsAgo
+	})
+	return entries, nil
+}
\ No newline at end of file
@@ -0,0 +1,161 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGetCalendarMonth verifies the month grid marks journals and tasks
+func TestGetCalendarMonth(t *testing.T) {
+	libDir := setupJournalLibrary(t)
+	task := "# Tasks\n\n- TODO Ship release\n  SCHEDULED: <2025-01-17 Fri>\n"
+	if err := os.WriteFile(filepath.Join(libDir, "pages", "tasks.md"), []byte(task), 0644); err != nil {
+		t.Fatalf("Failed to write page: %v", err)
+	}
+
+	app := NewApp()
+	app.now = fixedClock
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	month, err := app.GetCalendarMonth(2025, 1)
+	if err != nil {
+		t.Fatalf("GetCalendarMonth failed: %v", err)
+	}
+
+	// January 2025 starts on a Wednesday and spans five Monday-first weeks
+	if len(month.Weeks) != 5 {
+		t.Fatalf("Expected 5 weeks, got %d", len(month.Weeks))
+	}
+	if first := month.Weeks[0][0]; first.Date != "2024-12-30" || first.InMonth {
+		t.Errorf("Grid should start with padding day 2024-12-30, got %+v", first)
+	}
+
+	days := make(map[string]CalendarDay)
+	for _, week := range month.Weeks {
+		for _, day := range week {
+			days[day.Date] = day
+		}
+	}
+
+	if day := days["2025-01-15"]; day.JournalTitle != "Jan 15th, 2025" || !day.IsToday {
+		t.Errorf("Jan 15th should be today with a journal, got %+v", day)
+	}
+	if day := days["2025-01-16"]; day.JournalTitle != "" {
+		t.Errorf("Jan 16th has no journal, got %q", day.JournalTitle)
+	}
+	if day := days["2025-01-17"]; len(day.Tasks) != 1 || day.Tasks[0].Kind != "scheduled" {
+		t.Errorf("Expected scheduled task on Jan 17th, got %+v", day.Tasks)
+	}
+
+	if _, err := app.GetCalendarMonth(2025, 13); err == nil {
+		t.Error("Expected error for invalid month")
+	}
+}
+
+// TestGetWeekGeneratesReview verifies week summaries and the review page
+func TestGetWeekGeneratesReview(t *testing.T) {
+	libDir := setupJournalLibrary(t)
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	summary, err := app.GetWeek(2025, 3, true)
+	if err != nil {
+		t.Fatalf("GetWeek failed: %v", err)
+	}
+
+	if summary.Start != "2025-01-13" || summary.End != "2025-01-19" || len(summary.Days) != 7 {
+		t.Fatalf("Unexpected week bounds: %s..%s (%d days)", summary.Start, summary.End, len(summary.Days))
+	}
+	if len(summary.Days[0].Blocks) != 1 || !strings.Contains(summary.Days[0].Blocks[0].Content, "Started") {
+		t.Errorf("Monday should include its journal block, got %+v", summary.Days[0].Blocks)
+	}
+	if summary.ReviewPage != "Weekly Review 2025-W03" {
+		t.Errorf("ReviewPage = %q", summary.ReviewPage)
+	}
+
+	reviewFile := filepath.Join(libDir, "pages", "Weekly Review 2025-W03.md")
+	content, err := os.ReadFile(reviewFile)
+	if err != nil {
+		t.Fatalf("Review page not written: %v", err)
+	}
+	for _, want := range []string{"- [[Jan 13th, 2025]]", "  - Started [[Project]]", "- [[Jan 15th, 2025]]"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Review page missing %q:\n%s", want, content)
+		}
+	}
+
+	// An existing review is never overwritten
+	if err := os.WriteFile(reviewFile, []byte("# Weekly Review 2025-W03\n\n- My notes\n"), 0644); err != nil {
+		t.Fatalf("Failed to edit review: %v", err)
+	}
+	if _, err := app.GetWeek(2025, 3, true); err != nil {
+		t.Fatalf("GetWeek failed: %v", err)
+	}
+	content, _ = os.ReadFile(reviewFile)
+	if !strings.Contains(string(content), "My notes") {
+		t.Errorf("Existing review was overwritten:\n%s", content)
+	}
+}
+
+// TestGetOnThisDay verifies earlier years' journals are listed newest first
+func TestGetOnThisDay(t *testing.T) {
+	libDir := setupJournalLibrary(t)
+	for _, name := range []string{"2023_01_15.md", "2024_01_15.md", "2024_01_16.md"} {
+		if err := os.WriteFile(filepath.Join(libDir, "journals", name), []byte("- Entry"), 0644); err != nil {
+			t.Fatalf("Failed to write journal: %v", err)
+		}
+	}
+
+	app := NewApp()
+	app.now = fixedClock
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	entries, err := app.GetOnThisDay("")
+	if err != nil {
+		t.Fatalf("GetOnThisDay failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Date != "2024-01-15" || entries[0].YearsAgo != 1 || entries[1].Date != "2023-01-15" {
+		t.Errorf("Unexpected entries: %+v", entries)
+	}
+
+	entries, err = app.GetOnThisDay("2025-01-16")
+	if err != nil {
+		t.Fatalf("GetOnThisDay failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Page.Title != "Jan 16th, 2024" {
+		t.Errorf("Unexpected entries for Jan 16th: %+v", entries)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,210 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+func TestLoadVaultConfig(t *testing.T) {
+	libDir := t.TempDir()
+	files := map[string]string{
+		"seq2b.yaml":           "indent-width: 4\ntodo-keywords: [TODO, BLOCKED, DONE]\npages-directory: notes\njournals-directory: daily\n",
+		"notes/Tasks.md":       "- BLOCKED on review\n    - nested under it\n",
+		"daily/2025_01_13.md":  "- Worked on [[Tasks]]\n",
+		"pages/Not Scanned.md": "- pages/ isn't this vault's pages directory\n",
+		"logseq/config.edn":    "{:journal/file-name-format \"yyyy_MM_dd\"}",
+	}
+	for name, content := range files {
+		path := filepath.Join(libDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("LoadDirectory failed: %v", err)
+	}
+	if app.pagesDir != filepath.Join(libDir, "notes") || app.journalsDir != filepath.Join(libDir, "daily") {
+		t.Errorf("Directories = %q, %q", app.pagesDir, app.journalsDir)
+	}
+	if app.journalFormat.FileNameFormat != "yyyy_MM_dd" {
+		t.Errorf("Journal format from config.edn not used: %+v", app.journalFormat)
+	}
+	if _, found := app.findPage("Not Scanned"); found {
+		t.Error("Pages outside the configured directories should not load")
+	}
+	if _, found := app.findPage("Jan 13th, 2025"); !found {
+		t.Error("Journal in the configured directory should load")
+	}
+
+	page, found := app.findPage("Tasks")
+	if !found {
+		t.Fatal("Page in the configured pages directory should load")
+	}
+	if page.Blocks[0].TodoInfo.TodoState != "BLOCKED" {
+		t.Errorf("Configured TODO keyword not recognised: %q", page.Blocks[0].TodoInfo.TodoState)
+	}
+	if len(page.Blocks[0].Children) != 1 {
+		t.Fatalf("Four-space indentation not nested: %d children", len(page.Blocks[0].Children))
+	}
+
+	// Saves keep the vault's indentation
+	if _, err := app.UpdateBlockAtPath("Tasks", BlockPath{0, 0}, "still nested"); err != nil {
+		t.Fatalf("UpdateBlockAtPath failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(libDir, "notes", "Tasks.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "\n    - still nested\n") {
+		t.Errorf("Saved page should indent by four spaces:\n%s", content)
+	}
+
+	// Reading the page back gives the same tree
+	reparsed, err := parser.ParsePageFile(filepath.Join(libDir, "notes", "Tasks.md"), app.parseOptions(false))
+	if err != nil || len(reparsed.Blocks) != 1 || len(reparsed.Blocks[0].Children) != 1 {
+		t.Errorf("Saved page doesn't parse back to the same tree: %v", err)
+	}
+}
+
+func TestLoadInvalidVaultConfig(t *testing.T) {
+	libDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(libDir, "seq2b.yaml"), []byte("indent-width: 0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	app := NewApp()
+	err := app.LoadDirectory(libDir)
+	if err == nil || !strings.Contains(err.Error(), "indent-width must be between 1 and 8") {
+		t.Errorf("LoadDirectory should report the invalid setting, got %v", err)
+	}
+}
+
+func TestSaveKeepsContinuationLinesWithWideIndent(t *testing.T) {
+	libDir := t.TempDir()
+	files := map[string]string{
+		"seq2b.yaml":     "indent-width: 4\n",
+		"pages/Plans.md": "- TODO Write the plan\n    SCHEDULED: <2025-01-17 Fri>\n    status:: open\n    - First step\n- Second line\n    of a long block\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(libDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("LoadDirectory failed: %v", err)
+	}
+	if _, err := app.UpdateBlockAtPath("Plans", BlockPath{0, 0}, "First step, edited"); err != nil {
+		t.Fatalf("UpdateBlockAtPath failed: %v", err)
+	}
+
+	// Load the saved file from scratch
+	reloaded := NewApp()
+	if err := reloaded.LoadDirectory(libDir); err != nil {
+		t.Fatalf("LoadDirectory after save failed: %v", err)
+	}
+	page, found := reloaded.findPage("Plans")
+	if !found {
+		t.Fatal("Saved page should load")
+	}
+	if len(page.Blocks) != 2 {
+		t.Fatalf("Continuation lines became blocks: %d top-level blocks", len(page.Blocks))
+	}
+	task := page.Blocks[0]
+	for _, want := range []string{"SCHEDULED: <2025-01-17 Fri>", "status:: open"} {
+		if !strings.Contains(task.Content, want) {
+			t.Errorf("Block lost %q after saving: %q", want, task.Content)
+		}
+	}
+	if task.TodoInfo.TodoState != "TODO" || task.Properties["status"] != "open" {
+		t.Errorf("Block state or property lost: %q, %v", task.TodoInfo.TodoState, task.Properties)
+	}
+	if len(task.Children) != 1 || task.Children[0].Content != "First step, edited" {
+		t.Errorf("Child block not kept: %+v", task.Children)
+	}
+	if !strings.Contains(page.Blocks[1].Content, "of a long block") {
+		t.Errorf("Multi-line content lost: %q", page.Blocks[1].Content)
+	}
+}
+
+func TestEditBlockWithVaultTodoKeywords(t *testing.T) {
+	libDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(libDir, "seq2b.yaml"), []byte("todo-keywords: [TODO, BLOCKED, DONE]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(libDir, "pages"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(libDir, "pages", "Tasks.md"), []byte("- TODO Write the report\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("LoadDirectory failed: %v", err)
+	}
+	delta, err := app.UpdateBlockAtPath("Tasks", BlockPath{0}, "BLOCKED Write the report")
+	if err != nil {
+		t.Fatalf("UpdateBlockAtPath failed: %v", err)
+	}
+	if state := delta["block"].(BlockData).TodoState; state != "BLOCKED" {
+		t.Errorf("Edited block should be BLOCKED, got %q", state)
+	}
+	added, err := app.AddBlockAtPath("Tasks", BlockPath{1}, "BLOCKED Send it out")
+	if err != nil {
+		t.Fatalf("AddBlockAtPath failed: %v", err)
+	}
+	if state := added["block"].(BlockData).TodoState; state != "BLOCKED" {
+		t.Errorf("New block should be BLOCKED, got %q", state)
+	}
+
+	// The saved page reads back with the same states
+	reloaded := NewApp()
+	if err := reloaded.LoadDirectory(libDir); err != nil {
+		t.Fatalf("LoadDirectory after save failed: %v", err)
+	}
+	page, found := reloaded.findPage("Tasks")
+	if !found || len(page.Blocks) != 2 {
+		t.Fatalf("Saved page should have two blocks: %v", found)
+	}
+	for _, block := range page.Blocks {
+		if block.TodoInfo.TodoState != "BLOCKED" {
+			t.Errorf("Saved block %q should be BLOCKED, got %q", block.Content, block.TodoInfo.TodoState)
+		}
+	}
+}
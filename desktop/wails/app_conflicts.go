@@ -0,0 +1,143 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// PageConflictEvent is emitted to the frontend with a PageConflict when a
+// save is refused
+const PageConflictEvent = "page:conflict"
+
+// PageConflict is a save refused because the page's file was changed
+// outside the app since it was read. Both versions are kept until
+// ResolveConflict is called.
+type PageConflict struct {
+	Page string `json:"page"`
+	Path string `json:"path"`
+	Local string `json:"local"` // Text the app tried to write
+	Disk string `json:"disk"` // Text now on disk
+	
+	encoding parser.FileEncoding // Encoding of the page, used to write the resolution
+}
+
+func (c *PageConflict) Error() string {
+	return fmt.Sprintf("page '%s' was changed outside the app since it was loaded", c.Page)
+}
+
+// checkConflict returns a PageConflict if filePath no longer holds the
+// version the app read or last wrote. content is the file as it would be
+// written. A file whose modification time changed but whose content didn't
+// is not a conflict.
+func (a *App) checkConflict(filePath string, page *parser.Page, content []byte) error {
+	state, known := a.recordedFile(filePath)
+	if !known {
+		// A new file, or one written before the app saw it
+		return nil
+	}
+	info, err := os.Stat(filePath)
+	if err != nil || state.matches(info) {
+		return nil
+	}
+	disk, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil
+	}
+	if parser.ContentHash(disk) == state.hash || bytes.Equal(disk, content) {
+		return nil
+	}
+	
+	// The frontend is sent text, whatever the file's character set
+	local, _ := parser.DecodeText(content)
+	diskText, _ := parser.DecodeText(disk)
+	conflict := &PageConflict{
+		Page: page.Title,
+		Path: filePath,
+		Local: local,
+		Disk: diskText,
+		encoding: page.Encoding,
+	}
+	if a.conflicts == nil {
+		a.conflicts = make(map[string]*PageConflict)
+	}
+	a.conflicts[a.pageKey(page.Title)] = conflict
+	a.emit(PageConflictEvent, conflict)
+	return conflict
+}
+
+// GetConflicts returns the saves refused since the library was loaded that
+// haven't been resolved, by page title
+func (a *App) GetConflicts() []PageConflict {
+	conflicts := make([]PageConflict, 0, len(a.conflicts))
+	for _, conflict := range a.conflicts {
+		conflicts = append(conflicts, *conflict)
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Page < conflicts[j].Page
+	})
+	return conflicts
+}
+
+// ResolveConflict settles a refused save by writing content to the page's
+// file, in the page's encoding, and reloading the page. content is usually
+// the conflict's Local or Disk version, or a merge of the two. If the file
+// has changed again since the conflict was reported, nothing is written and
+// an updated conflict is returned.
+func (a *App) ResolveConflict(pageName string, content string) error {
+	key := a.pageKey(pageName)
+	conflict, exists := a.conflicts[key]
+	if !exists {
+		return fmt.Errorf("no conflict for page '%s'", pageName)
+	}
+	
+	disk, err := os.ReadFile(conflict.Path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read page: %w", err)
+	}
+	if err == nil {
+		if diskText, _ := parser.DecodeText(disk); diskText != conflict.Disk {
+			conflict.Disk = diskText
+			a.emit(PageConflictEvent, conflict)
+			return conflict
+		}
+	}
+	
+	encoded := conflict.encoding.EncodeText(content)
+	if err != nil || !bytes.Equal(disk, encoded) {
+		if err := writeFileAtomic(conflict.Path, encoded, 0644); err != nil {
+			return fmt.Errorf("failed to save page: %w", err)
+		}
+	}
+	delete(a.conflicts, key)
+	
+	// Read the page back from the file, whichever version was chosen
+	a.forgetFile(conflict.Path)
+	a.applyFileChanges([]string{conflict.Path})
+	return nil
+}
\ No newline at end of file
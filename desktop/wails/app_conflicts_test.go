@@ -0,0 +1,231 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// setupConflictPage loads a library holding one page and returns the app
+// and the page's file
+func setupConflictPage(t *testing.T) (*App, string) {
+	t.Helper()
+	libDir := t.TempDir()
+	pageFile := filepath.Join(libDir, "notes.md")
+	if err := os.WriteFile(pageFile, []byte("# Notes\n\n- First\n- Second\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+	return app, pageFile
+}
+
+// editOutside rewrites a file as another editor would, with a later
+// modification time
+func editOutside(t *testing.T, filePath string, content string) {
+	t.Helper()
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(filePath, later, later); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSaveRefusesOutsideEdit(t *testing.T) {
+	app, pageFile := setupConflictPage(t)
+	var events []*PageConflict
+	app.onEvent = func(event string, data ...interface{}) {
+		if event == PageConflictEvent {
+			events = append(events, data[0].(*PageConflict))
+		}
+	}
+
+	outside := "# Notes\n\n- First\n- Edited elsewhere\n"
+	editOutside(t, pageFile, outside)
+
+	err := app.UpdateBlock("Notes", "block-1", "Edited here")
+	var conflict *PageConflict
+	if !errors.As(err, &conflict) {
+		t.Fatalf("UpdateBlock error = %v, want PageConflict", err)
+	}
+	if conflict.Disk != outside || !strings.Contains(conflict.Local, "- Edited here") {
+		t.Errorf("Conflict should carry both versions, got local %q, disk %q", conflict.Local, conflict.Disk)
+	}
+	if len(events) != 1 || events[0] != conflict {
+		t.Errorf("Expected one conflict event, got %v", events)
+	}
+	if content, _ := os.ReadFile(pageFile); string(content) != outside {
+		t.Errorf("Outside edit was overwritten:\n%s", content)
+	}
+	if got := app.GetConflicts(); len(got) != 1 || got[0].Page != "Notes" {
+		t.Errorf("GetConflicts = %v", got)
+	}
+
+	// Keeping the app's version writes it and reloads the page
+	if err := app.ResolveConflict("notes", conflict.Local); err != nil {
+		t.Fatalf("ResolveConflict failed: %v", err)
+	}
+	if content, _ := os.ReadFile(pageFile); string(content) != conflict.Local {
+		t.Errorf("Local version not written:\n%s", content)
+	}
+	if got := app.GetConflicts(); len(got) != 0 {
+		t.Errorf("Conflict not cleared: %v", got)
+	}
+	if err := app.UpdateBlock("Notes", "block-2", "Saved again"); err != nil {
+		t.Errorf("Saving after resolving failed: %v", err)
+	}
+}
+
+func TestResolveConflictKeepsDisk(t *testing.T) {
+	app, pageFile := setupConflictPage(t)
+	outside := "# Notes\n\n- Only outside\n"
+	editOutside(t, pageFile, outside)
+
+	var conflict *PageConflict
+	if err := app.UpdateBlock("Notes", "block-1", "Edited here"); !errors.As(err, &conflict) {
+		t.Fatalf("UpdateBlock error = %v, want PageConflict", err)
+	}
+	if err := app.ResolveConflict("Notes", conflict.Disk); err != nil {
+		t.Fatalf("ResolveConflict failed: %v", err)
+	}
+
+	if content, _ := os.ReadFile(pageFile); string(content) != outside {
+		t.Errorf("Disk version should be kept:\n%s", content)
+	}
+	data, err := app.GetPage("Notes")
+	if err != nil {
+		t.Fatalf("GetPage failed: %v", err)
+	}
+	if len(data.Blocks) != 1 || data.Blocks[0].Content != "Only outside" {
+		t.Errorf("Page should show the disk version, got %+v", data.Blocks)
+	}
+}
+
+func TestResolveConflictChangedAgain(t *testing.T) {
+	app, pageFile := setupConflictPage(t)
+	editOutside(t, pageFile, "# Notes\n\n- Outside once\n")
+
+	var conflict *PageConflict
+	if err := app.UpdateBlock("Notes", "block-1", "Edited here"); !errors.As(err, &conflict) {
+		t.Fatalf("UpdateBlock error = %v, want PageConflict", err)
+	}
+	again := "# Notes\n\n- Outside twice\n"
+	editOutside(t, pageFile, again)
+
+	err := app.ResolveConflict("Notes", conflict.Local)
+	var updated *PageConflict
+	if !errors.As(err, &updated) || updated.Disk != again {
+		t.Fatalf("ResolveConflict error = %v, want a conflict with the new disk version", err)
+	}
+	if content, _ := os.ReadFile(pageFile); string(content) != again {
+		t.Errorf("File should not be written:\n%s", content)
+	}
+}
+
+// TestConflictOnUTF16Page verifies conflicts carry text, and the resolution
+// is written in the page's encoding
+func TestConflictOnUTF16Page(t *testing.T) {
+	wide := parser.FileEncoding{Charset: parser.CharsetUTF16LE, BOM: true, CRLF: true}
+	app, pagesDir := loadWindowsVault(t, map[string][]byte{
+		"Wide.md": wide.EncodeText("# Wide\n\n- One\n"),
+	})
+	pageFile := filepath.Join(pagesDir, "Wide.md")
+	editOutside(t, pageFile, string(wide.EncodeText("# Wide\n\n- Outside\n")))
+
+	var conflict *PageConflict
+	if _, err := app.UpdateBlockAtPath("Wide", BlockPath{0}, "Edited here"); !errors.As(err, &conflict) {
+		t.Fatalf("UpdateBlockAtPath error = %v, want PageConflict", err)
+	}
+	if conflict.Local != "# Wide\n\n- Edited here\n" || conflict.Disk != "# Wide\n\n- Outside\n" {
+		t.Errorf("Conflict should carry decoded text, got local %q, disk %q", conflict.Local, conflict.Disk)
+	}
+
+	if err := app.ResolveConflict("Wide", conflict.Local); err != nil {
+		t.Fatalf("ResolveConflict failed: %v", err)
+	}
+	if got, want := readPageFile(t, pageFile), string(wide.EncodeText(conflict.Local)); got != want {
+		t.Errorf("Resolution not written as UTF-16 with CRLF: %q, want %q", got, want)
+	}
+	if page, _ := app.findPage("Wide"); page.Blocks[0].Content != "Edited here" {
+		t.Errorf("Page not reloaded from the resolution: %q", page.Blocks[0].Content)
+	}
+}
+
+// TestSaveAllowsTouchedFile verifies a new modification time alone isn't
+// a conflict
+func TestSaveAllowsTouchedFile(t *testing.T) {
+	app, pageFile := setupConflictPage(t)
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(pageFile, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.UpdateBlock("Notes", "block-1", "Edited here"); err != nil {
+		t.Fatalf("UpdateBlock failed: %v", err)
+	}
+	if content, _ := os.ReadFile(pageFile); !strings.Contains(string(content), "- Edited here") {
+		t.Errorf("Edit not saved:\n%s", content)
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "page.md")
+	if err := os.WriteFile(filePath, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeFileAtomic(filePath, []byte("new"), 0644); err != nil {
+		t.Fatalf("writeFileAtomic failed: %v", err)
+	}
+	if content, _ := os.ReadFile(filePath); string(content) != "new" {
+		t.Errorf("content = %q", content)
+	}
+	if info, _ := os.Stat(filePath); info.Mode().Perm() != 0600 {
+		t.Errorf("Permissions should be kept, got %v", info.Mode().Perm())
+	}
+
+	// A failed rename leaves no temporary file
+	blocked := filepath.Join(dir, "folder.md")
+	if err := os.MkdirAll(filepath.Join(blocked, "child"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(blocked, []byte("x"), 0644); err == nil {
+		t.Error("Expected an error replacing a directory")
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Errorf("Temporary files left behind: %v", entries)
+	}
+}
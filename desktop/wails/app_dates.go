@@ -0,0 +1,108 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// Matches an existing SCHEDULED line in block content
+var scheduledLinePattern = regexp.MustCompile(`^SCHEDULED: <[^>]*>$`)
+
+// DateCommandResult is the outcome of the /date command
+type DateCommandResult struct {
+	Date string `json:"date"` // ISO date, e.g. 2025-01-17
+	Title string `json:"title"` // Journal page title in the vault's format
+	Reference string `json:"reference"` // Text to insert, e.g. [[Jan 17th, 2025]]
+}
+
+// dateParser returns a natural-language date parser using the app clock
+func (a *App) dateParser() *parser.NaturalDateParser {
+	return parser.NewNaturalDateParser(a.now)
+}
+
+// ResolveDateCommand resolves the argument of the /date command
+// ("today", "next friday", ...) to a journal page reference
+func (a *App) ResolveDateCommand(phrase string) (*DateCommandResult, error) {
+	date, err := a.dateParser().Parse(phrase)
+	if err != nil {
+		return nil, err
+	}
+	
+	title := a.journalFormat.FormatTitle(date)
+	return &DateCommandResult{
+		Date: parser.FormatDateISO(date),
+		Title: title,
+		Reference: "[[" + title + "]]",
+	}, nil
+}
+
+// ScheduleBlock sets a block's SCHEDULED date from a natural-language
+// phrase, replacing any existing SCHEDULED line
+func (a *App) ScheduleBlock(pageName string, path BlockPath, phrase string) (map[string]interface{}, error) {
+	page, exists := a.findPage(pageName)
+	if !exists {
+		return nil, fmt.Errorf("page '%s' not found", pageName)
+	}
+	
+	block, err := FindBlockByPath(page.Blocks, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find block: %w", err)
+	}
+	
+	date, err := a.dateParser().Parse(phrase)
+	if err != nil {
+		return nil, err
+	}
+	
+	return a.UpdateBlockAtPath(pageName, path, setScheduledLine(block.Content, parser.FormatScheduled(date)))
+}
+
+// setScheduledLine puts scheduled in place of the first SCHEDULED line of
+// block content, dropping any others, or appends it if there is none. The
+// other lines are left as they are.
+func setScheduledLine(content string, scheduled string) string {
+	lines := strings.Split(content, "\n")
+	kept := make([]string, 0, len(lines)+1)
+	replaced := false
+	for _, line := range lines {
+		if !scheduledLinePattern.MatchString(line) {
+			kept = append(kept, line)
+		} else if !replaced {
+			kept = append(kept, scheduled)
+			replaced = true
+		}
+	}
+	if !replaced {
+		if kept[len(kept)-1] == "" {
+			// Content ending in a line break gets the line in its place
+			kept = kept[:len(kept)-1]
+		}
+		kept = append(kept, scheduled)
+	}
+	return strings.Join(kept, "\n")
+}
\ No newline at end of file
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fixedClock returns a clock stuck at Wednesday, Jan 15th 2025
+func fixedClock() time.Time {
+	return time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
+}
+
+// TestResolveDateCommand verifies the /date command resolves phrases
+func TestResolveDateCommand(t *testing.T) {
+	app := NewApp()
+	app.now = fixedClock
+
+	tests := []struct {
+		phrase    string
+		reference string
+	}{
+		{"today", "[[Jan 15th, 2025]]"},
+		{"tomorrow", "[[Jan 16th, 2025]]"},
+		{"next friday", "[[Jan 17th, 2025]]"},
+		{"in 3 days", "[[Jan 18th, 2025]]"},
+	}
+
+	for _, tt := range tests {
+		result, err := app.ResolveDateCommand(tt.phrase)
+		if err != nil {
+			t.Errorf("ResolveDateCommand(%q) failed: %v", tt.phrase, err)
+			continue
+		}
+		if result.Reference != tt.reference {
+			t.Errorf("ResolveDateCommand(%q) = %q, want %q", tt.phrase, result.Reference, tt.reference)
+		}
+	}
+
+	if _, err := app.ResolveDateCommand("whenever"); err == nil {
+		t.Error("Expected error for unparseable phrase")
+	}
+}
+
+// TestScheduleBlock verifies SCHEDULED dates are written and replaced
+func TestScheduleBlock(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "seq2b-schedule-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	pageFile := filepath.Join(tempDir, "tasks.md")
+	if err := os.WriteFile(pageFile, []byte("# Tasks\n\n- TODO Write report\n"), 0644); err != nil {
+		t.Fatalf("Failed to write page: %v", err)
+	}
+
+	app := NewApp()
+	app.now = fixedClock
+	if err := app.LoadDirectory(tempDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	if _, err := app.ScheduleBlock("Tasks", BlockPath{0}, "next friday"); err != nil {
+		t.Fatalf("ScheduleBlock failed: %v", err)
+	}
+
+	content, _ := os.ReadFile(pageFile)
+	if !strings.Contains(string(content), "  SCHEDULED: <2025-01-17 Fri>") {
+		t.Errorf("SCHEDULED line not written: %q", content)
+	}
+
+	// Rescheduling replaces the old date, and survives a reload
+	if _, err := app.ScheduleBlock("Tasks", BlockPath{0}, "in two weeks"); err != nil {
+		t.Fatalf("ScheduleBlock failed: %v", err)
+	}
+
+	pageData, err := app.GetPage("Tasks")
+	if err != nil {
+		t.Fatalf("Failed to get page: %v", err)
+	}
+	want := "TODO Write report\nSCHEDULED: <2025-01-29 Wed>"
+	if pageData.Blocks[0].Content != want {
+		t.Errorf("Block content = %q, want %q", pageData.Blocks[0].Content, want)
+	}
+}
+
+// TestSetScheduledLine verifies only the SCHEDULED line is replaced and
+// blank lines elsewhere in the block are kept
+func TestSetScheduledLine(t *testing.T) {
+	const scheduled = "SCHEDULED: <2025-01-17 Fri>"
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"appended", "TODO Write report", "TODO Write report\n" + scheduled},
+		{"replaced in place", "TODO Write report\nSCHEDULED: <2025-01-01 Wed>\nid:: abc", "TODO Write report\n" + scheduled + "\nid:: abc"},
+		{"blank lines kept", "TODO Write report\n\nNotes\n\nSCHEDULED: <2025-01-01 Wed>", "TODO Write report\n\nNotes\n\n" + scheduled},
+		{"blank line after kept", "Plan\nSCHEDULED: <2025-01-01 Wed>\n\nMore", "Plan\n" + scheduled + "\n\nMore"},
+		{"duplicates dropped", "Plan\nSCHEDULED: <2025-01-01 Wed>\nSCHEDULED: <2025-01-02 Thu>", "Plan\n" + scheduled},
+		{"trailing line break", "Plan\n", "Plan\n" + scheduled},
+		{"empty", "", scheduled},
+	}
+
+	for _, tt := range tests {
+		if got := setScheduledLine(tt.content, scheduled); got != tt.want {
+			t.Errorf("%s: setScheduledLine(%q) = %q, want %q", tt.name, tt.content, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// windowsPage is a page as Notepad and other Windows editors save it
+const windowsPage = "\xEF\xBB\xBF# Plans\r\ntags:: work\r\n\r\n- First\r\n- Second\r\n  status:: open\r\n"
+
+// loadWindowsVault loads a library holding the given files under pages/
+func loadWindowsVault(t *testing.T, files map[string][]byte) (*App, string) {
+	t.Helper()
+	libDir := t.TempDir()
+	pagesDir := filepath.Join(libDir, "pages")
+	if err := os.MkdirAll(pagesDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(pagesDir, name), content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+	return app, pagesDir
+}
+
+func TestWindowsPageLoads(t *testing.T) {
+	app, _ := loadWindowsVault(t, map[string][]byte{"Plans.md": []byte(windowsPage)})
+
+	page, err := app.GetPage("Plans")
+	if err != nil {
+		t.Fatalf("GetPage failed: %v", err)
+	}
+	if page.Title != "Plans" {
+		t.Errorf("Title = %q, want %q", page.Title, "Plans")
+	}
+	if parsed, _ := app.findPage("Plans"); parsed.Properties["tags"] != "work" {
+		t.Errorf("tags = %q, want %q", parsed.Properties["tags"], "work")
+	}
+	if len(page.Blocks) != 2 || page.Blocks[0].Content != "First" {
+		t.Fatalf("Blocks = %+v, want First and Second", page.Blocks)
+	}
+	if content := page.Blocks[1].Content; strings.Contains(content, "\r") {
+		t.Errorf("Block content kept a carriage return: %q", content)
+	}
+}
+
+func TestSaveKeepsWindowsLineEndings(t *testing.T) {
+	app, pagesDir := loadWindowsVault(t, map[string][]byte{"Plans.md": []byte(windowsPage)})
+	pagePath := filepath.Join(pagesDir, "Plans.md")
+
+	if _, err := app.UpdateBlockAtPath("Plans", BlockPath{0}, "First, edited"); err != nil {
+		t.Fatalf("UpdateBlockAtPath failed: %v", err)
+	}
+	want := "\xEF\xBB\xBF# Plans\r\ntags:: work\r\n\r\n- First, edited\r\n- Second\r\n  status:: open\r\n"
+	if got := readPageFile(t, pagePath); got != want {
+		t.Errorf("Saved page = %q, want %q", got, want)
+	}
+
+	// The app's own write isn't mistaken for an outside edit
+	if _, err := app.UpdateBlockAtPath("Plans", BlockPath{1}, "Second\nstatus:: done"); err != nil {
+		t.Fatalf("Second save failed: %v", err)
+	}
+	if got := readPageFile(t, pagePath); !strings.HasSuffix(got, "- Second\r\n  status:: done\r\n") {
+		t.Errorf("Second save = %q", got)
+	}
+	if conflicts := app.GetConflicts(); len(conflicts) != 0 {
+		t.Errorf("Unexpected conflicts: %v", conflicts)
+	}
+}
+
+func TestSaveKeepsUTF16(t *testing.T) {
+	utf16 := func(text string) []byte {
+		out := []byte{0xFF, 0xFE}
+		for _, b := range []byte(text) {
+			out = append(out, b, 0)
+		}
+		return out
+	}
+	app, pagesDir := loadWindowsVault(t, map[string][]byte{
+		"Wide.md": utf16("# Wide\r\n\r\n- One\r\n"),
+		"Unix.md": []byte("# Unix\n\n- Plain\n"),
+	})
+
+	if _, err := app.UpdateBlockAtPath("Wide", BlockPath{0}, "Two"); err != nil {
+		t.Fatalf("UpdateBlockAtPath failed: %v", err)
+	}
+	if got, want := readPageFile(t, filepath.Join(pagesDir, "Wide.md")), string(utf16("# Wide\r\n\r\n- Two\r\n")); got != want {
+		t.Errorf("Saved page = %q, want %q", got, want)
+	}
+
+	// Other pages in the vault keep their own style
+	if _, err := app.UpdateBlockAtPath("Unix", BlockPath{0}, "Edited"); err != nil {
+		t.Fatalf("UpdateBlockAtPath failed: %v", err)
+	}
+	if got := readPageFile(t, filepath.Join(pagesDir, "Unix.md")); got != "# Unix\n\n- Edited\n" {
+		t.Errorf("Saved page = %q", got)
+	}
+}
+
+func TestRenameKeepsWindowsLineEndings(t *testing.T) {
+	app, pagesDir := loadWindowsVault(t, map[string][]byte{
+		"Plans.md": []byte(windowsPage),
+		"Index.md": []byte("# Index\r\n\r\n- See [[Plans]]\r\n"),
+	})
+
+	if _, err := app.RenamePage("Plans", "Roadmap", false); err != nil {
+		t.Fatalf("RenamePage failed: %v", err)
+	}
+	renamed := readPageFile(t, filepath.Join(pagesDir, "Roadmap.md"))
+	if !strings.HasPrefix(renamed, "\xEF\xBB\xBF# Roadmap\r\n") || strings.Count(renamed, "\r\n") != strings.Count(renamed, "\n") {
+		t.Errorf("Renamed page = %q, want a BOM and CRLF line endings", renamed)
+	}
+	if got := readPageFile(t, filepath.Join(pagesDir, "Index.md")); got != "# Index\r\n\r\n- See [[Roadmap]]\r\n" {
+		t.Errorf("Referring page = %q", got)
+	}
+}
@@ -0,0 +1,522 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	
+	"github.com/rehanog/seq2b/pkg/parser"
+	"github.com/rehanog/seq2b/pkg/watcher"
+)
+
+// PagesChangedEvent is emitted to the frontend with the paths of files
+// changed outside the app, so the current page can be reloaded
+const PagesChangedEvent = "pages:changed"
+
+// fileState is what was on disk when a page file was last read or written
+type fileState struct {
+	key string // Key of the page the file holds
+	modTime time.Time
+	size int64
+	hash string // parser.ContentHash of the content, empty if not known
+}
+
+// matches reports whether info describes the file as it was recorded
+func (s fileState) matches(info os.FileInfo) bool {
+	return s.modTime.Equal(info.ModTime()) && s.size == info.Size()
+}
+
+// pageDirs returns the directories pages are loaded from
+func (a *App) pageDirs() []string {
+	dir := a.pagesDir
+	if dir == "" {
+		dir = a.currentDir
+	}
+	dirs := []string{dir}
+	if a.journalsDir != "" && isDir(a.journalsDir) {
+		dirs = append(dirs, a.journalsDir)
+	}
+	return dirs
+}
+
+// recordFiles remembers the files a directory parse loaded pages from,
+// including files skipped as duplicates of another page
+func (a *App) recordFiles(result *parser.MultiPageResult) {
+	files := make(map[string]string, len(result.Files))
+	hashes := make(map[string]string, len(result.Files))
+	for filePath, key := range result.Files {
+		files[filePath] = key
+		hashes[filePath] = result.Pages[key].Hash
+	}
+	for _, err := range result.Errors {
+		var duplicate *parser.DuplicatePageError
+		if errors.As(err, &duplicate) {
+			files[duplicate.Path] = a.pageKey(duplicate.Title)
+		}
+	}
+	
+	states := make(map[string]fileState, len(files))
+	for filePath, key := range files {
+		if info, err := os.Stat(filePath); err == nil {
+			states[filePath] = fileState{key: key, modTime: info.ModTime(), size: info.Size(), hash: hashes[filePath]}
+		}
+	}
+	
+	a.fileMu.Lock()
+	a.files = states
+	a.pending = make(map[string]bool)
+	a.fileMu.Unlock()
+}
+
+// recordFile remembers the state of a file the app has just read or written
+func (a *App) recordFile(filePath string, key string, hash string) {
+	info, err := os.Stat(filePath)
+	
+	a.fileMu.Lock()
+	defer a.fileMu.Unlock()
+	if a.files == nil {
+		a.files = make(map[string]fileState)
+	}
+	if err != nil {
+		delete(a.files, filePath)
+		return
+	}
+	a.files[filePath] = fileState{key: key, modTime: info.ModTime(), size: info.Size(), hash: hash}
+}
+
+// recordedFile returns the recorded state of a file
+func (a *App) recordedFile(filePath string) (fileState, bool) {
+	a.fileMu.Lock()
+	defer a.fileMu.Unlock()
+	state, ok := a.files[filePath]
+	return state, ok
+}
+
+// isRecorded reports whether a file's state is recorded
+func (a *App) isRecorded(filePath string) bool {
+	_, ok := a.recordedFile(filePath)
+	return ok
+}
+
+// forgetFile drops the recorded state of a file
+func (a *App) forgetFile(filePath string) {
+	a.fileMu.Lock()
+	defer a.fileMu.Unlock()
+	delete(a.files, filePath)
+}
+
+// fileForKey returns another recorded file holding the page with key, used
+// when the same page exists in both the pages and journals directories
+func (a *App) fileForKey(key string, except string) (string, bool) {
+	a.fileMu.Lock()
+	defer a.fileMu.Unlock()
+	
+	var found []string
+	for filePath, state := range a.files {
+		if state.key == key && filePath != except {
+			found = append(found, filePath)
+		}
+	}
+	if len(found) == 0 {
+		return "", false
+	}
+	sort.Strings(found)
+	return found[0], true
+}
+
+// fileWritten records a page file the app has just written so the change
+// isn't mistaken for an outside edit. Pages that aren't loaded yet, like a
+// newly generated weekly review, are read in.
+func (a *App) fileWritten(filePath string, page *parser.Page, hash string) {
+	key := a.pageKey(page.Title)
+	if loaded, ok := a.pages[key]; ok && loaded == page {
+		a.recordFile(filePath, key, hash)
+		return
+	}
+	a.applyFileChanges([]string{filePath})
+}
+
+// syncPages applies changes made to page files outside the app since they
+// were loaded. With a watcher running only the files it reported are
+// looked at; otherwise the page directories are scanned for files whose
+// size or modification time changed.
+func (a *App) syncPages() {
+	if a.backlinks == nil {
+		return
+	}
+	
+	var paths []string
+	if a.watcher != nil {
+		paths = a.takePending()
+	} else {
+		paths = a.changedFiles()
+	}
+	a.applyFileChanges(paths)
+}
+
+// changedFiles compares the page directories with the recorded files and
+// returns the files created, changed or deleted since
+func (a *App) changedFiles() []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, dir := range a.pageDirs() {
+		files, err := parser.FindPageFiles(dir, a.ignore)
+		if err != nil {
+			continue
+		}
+		for _, filePath := range files {
+			seen[filePath] = true
+			state, known := a.recordedFile(filePath)
+			info, err := os.Stat(filePath)
+			if !known || err != nil || !state.matches(info) {
+				paths = append(paths, filePath)
+			}
+		}
+	}
+	
+	a.fileMu.Lock()
+	for filePath := range a.files {
+		if !seen[filePath] {
+			paths = append(paths, filePath)
+		}
+	}
+	a.fileMu.Unlock()
+	
+	sort.Strings(paths)
+	return paths
+}
+
+// applyFileChanges brings pages and backlinks up to date with files that
+// were created, changed or deleted, reparsing only those files. Files whose
+// size and modification time are unchanged are skipped. It returns the
+// titles of the pages that changed.
+func (a *App) applyFileChanges(paths []string) []string {
+	if a.backlinks == nil {
+		return nil
+	}
+	
+	var changed []string
+	for _, filePath := range paths {
+		state, known := a.recordedFile(filePath)
+		info, err := os.Stat(filePath)
+		if err != nil {
+			// Deleted (or renamed away)
+			if known {
+				changed = append(changed, a.removeFilePage(filePath, state.key)...)
+			}
+			continue
+		}
+		if known && state.matches(info) {
+			continue
+		}
+		
+		opts := a.parseOptions(false)
+		opts.Journal = a.journalsDir != "" && isWithin(filePath, a.journalsDir)
+		page, err := parser.ParsePageFile(filePath, opts)
+		if err != nil {
+			fmt.Printf("Warning: %v\n", err)
+			continue
+		}
+		
+		key := a.pageKey(page.Title)
+		if known && state.key != key {
+			// The file's title changed, so it no longer holds the old page
+			changed = append(changed, a.removeFilePage(filePath, state.key)...)
+		}
+		if loaded, ok := a.pages[key]; ok && loaded.Path != filePath && a.isRecorded(loaded.Path) {
+			// Another file already holds this page
+			fmt.Printf("Warning: %v\n", &parser.DuplicatePageError{Title: page.Title, Path: filePath, ExistingPath: loaded.Path})
+			a.recordFile(filePath, key, page.Hash)
+			continue
+		}
+		a.pages[key] = page
+		a.backlinks.AddPage(page)
+		a.recordFile(filePath, key, page.Hash)
+		changed = append(changed, page.Title)
+	}
+	
+	if len(changed) > 0 {
+		a.unlinked = nil
+		a.namespaces = nil
+		a.linkGraph = nil
+	}
+	return changed
+}
+
+// removeFilePage forgets the page loaded from a file that was deleted or
+// retitled. If another file holds a page of the same name it takes over.
+func (a *App) removeFilePage(filePath string, key string) []string {
+	a.forgetFile(filePath)
+	
+	page, ok := a.pages[key]
+	if !ok {
+		return nil
+	}
+	if other, ok := a.fileForKey(key, filePath); ok {
+		a.forgetFile(other)
+		return append([]string{page.Title}, a.applyFileChanges([]string{other})...)
+	}
+	
+	delete(a.pages, key)
+	a.backlinks.RemovePage(page.Title)
+	return []string{page.Title}
+}
+
+// startWatching watches the page directories and reports outside changes
+// to the frontend. Changes are applied on the next call that reads pages.
+func (a *App) startWatching() {
+	a.stopWatching()
+	
+	w, err := watcher.New(a.pageDirs(), a.watchDelay, a.ignore.Ignored, a.queueChanges)
+	if err != nil {
+		fmt.Printf("Warning: failed to watch pages: %v\n", err)
+		return
+	}
+	a.watcher = w
+}
+
+// stopWatching stops any running watcher
+func (a *App) stopWatching() {
+	if a.watcher != nil {
+		a.watcher.Close()
+		a.watcher = nil
+	}
+}
+
+// queueChanges is called by the watcher with changed files. Files the app
+// wrote itself still match their recorded state and are left out. A
+// removed or renamed directory stands for the files loaded from it.
+func (a *App) queueChanges(paths []string) {
+	var changed []string
+	
+	a.fileMu.Lock()
+	var files []string
+	for _, path := range paths {
+		if strings.HasSuffix(path, ".md") {
+			files = append(files, path)
+			continue
+		}
+		for filePath := range a.files {
+			if isWithin(filePath, path) {
+				files = append(files, filePath)
+			}
+		}
+	}
+	sort.Strings(files)
+	
+	for _, filePath := range files {
+		state, known := a.files[filePath]
+		info, err := os.Stat(filePath)
+		if known && err == nil && state.matches(info) {
+			continue
+		}
+		if !known && err != nil {
+			continue
+		}
+		if a.pending == nil {
+			a.pending = make(map[string]bool)
+		}
+		a.pending[filePath] = true
+		changed = append(changed, filePath)
+	}
+	a.fileMu.Unlock()
+	
+	if len(changed) > 0 {
+		a.emit(PagesChangedEvent, changed)
+	}
+}
+
+// isWithin reports whether path is inside dir or one of its subdirectories
+func isWithin(path string, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// takePending returns and clears the files reported by the watcher
+func (a *App) takePending() []string {
+	a.fileMu.Lock()
+	defer a.fileMu.Unlock()
+	
+	paths := make([]string, 0, len(a.pending))
+	for filePath := range a.pending {
+		paths = append(paths, filePath)
+	}
+	a.pending = make(map[string]bool)
+	sort.Strings(paths)
+	return paths
+}
+
+// writeFileAtomic replaces a file's content so that a crash leaves either
+// the old or the new version: the data is written to a hidden temporary
+// file in the same directory, synced, then renamed over the original.
+// An existing file keeps its permissions.
+func writeFileAtomic(filePath string, data []byte, perm os.FileMode) error {
+	if info, err := os.Stat(filePath); err == nil {
+		perm = info.Mode().Perm()
+	}
+	
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), "." + filepath.Base(filePath) + ".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // Left behind only if the rename isn't reached
+	
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		return err
+	}
+	
+	// Persist the rename itself. Not every platform can sync a directory,
+	// and the file is already complete, so errors are ignored.
+	if dir, err := os.Open(filepath.Dir(filePath)); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+	return nil
+}
+
+// fileChange is one file written or removed by an operation that spans
+// several files, such as a rename
+type fileChange struct {
+	path string
+	before []byte // Content expected on disk beforehand, nil for a new file
+	after []byte // Content to write, nil to remove the file
+}
+
+// commitChanges makes every change or none: each file must still hold its
+// before content, and if one has changed or can't be written the files
+// already changed are put back.
+func commitChanges(changes []fileChange) error {
+	for i, change := range changes {
+		if err := applyChange(change); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				undo := fileChange{path: changes[j].path, after: changes[j].before}
+				if err := writeChange(undo); err != nil {
+					fmt.Printf("Warning: failed to restore %s: %v\n", undo.path, err)
+				}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// applyChange checks a file still holds the content a change expects,
+// then makes it
+func applyChange(change fileChange) error {
+	if err := checkChange(change); err != nil {
+		return err
+	}
+	return writeChange(change)
+}
+
+// checkChange returns an error if a file no longer holds the content a
+// change expects
+func checkChange(change fileChange) error {
+	current, err := os.ReadFile(change.path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	exists := err == nil
+	if exists != (change.before != nil) || !bytes.Equal(current, change.before) {
+		return fmt.Errorf("%s was changed outside the app", change.path)
+	}
+	return nil
+}
+
+// writeChange writes or removes a file
+func writeChange(change fileChange) error {
+	if change.after == nil {
+		if err := os.Remove(change.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(change.path), 0755); err != nil {
+		return err
+	}
+	return writeFileAtomic(change.path, change.after, 0644)
+}
+
+// invertChanges returns the changes that undo a committed list
+func invertChanges(changes []fileChange) []fileChange {
+	inverse := make([]fileChange, len(changes))
+	for i, change := range changes {
+		inverse[len(changes)-1-i] = fileChange{path: change.path, before: change.after, after: change.before}
+	}
+	return inverse
+}
+
+// reloadChanges reads committed changes back into the loaded pages
+func (a *App) reloadChanges(changes []fileChange) {
+	var written []string
+	for _, change := range changes {
+		if change.after == nil {
+			if state, ok := a.recordedFile(change.path); ok {
+				a.removeFilePage(change.path, state.key)
+			}
+			continue
+		}
+		a.forgetFile(change.path)
+		written = append(written, change.path)
+	}
+	a.applyFileChanges(written)
+	a.unlinked = nil
+	a.namespaces = nil
+	a.linkGraph = nil
+}
+
+// emit sends an event to the frontend. Tests replace it with onEvent.
+func (a *App) emit(event string, data ...interface{}) {
+	if a.onEvent != nil {
+		a.onEvent(event, data...)
+		return
+	}
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, event, data...)
+	}
+}
\ No newline at end of file
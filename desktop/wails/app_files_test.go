@@ -0,0 +1,297 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// referencingPages lists the keys of the pages linking to a page
+func referencingPages(app *App, pageName string) []string {
+	pages := []string{}
+	for source := range app.backlinks.GetBacklinks(pageName) {
+		pages = append(pages, source)
+	}
+	sort.Strings(pages)
+	return pages
+}
+
+// blockIDs returns the IDs of a page's top-level blocks
+func blockIDs(t *testing.T, app *App, pageName string) []string {
+	t.Helper()
+	data, err := app.GetPage(pageName)
+	if err != nil {
+		t.Fatalf("GetPage(%q) failed: %v", pageName, err)
+	}
+	ids := []string{}
+	for _, block := range data.Blocks {
+		ids = append(ids, block.ID)
+	}
+	return ids
+}
+
+func TestSyncPagesAppliesOutsideChanges(t *testing.T) {
+	libDir := setupJournalLibrary(t)
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	// A new page, an edited journal and a deleted journal
+	writes := map[string]string{
+		"pages/notes.md":         "# Notes\n\n- About [[Project]]",
+		"journals/2025_01_14.md": "- Took a day off",
+	}
+	for name, content := range writes {
+		if err := os.WriteFile(filepath.Join(libDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	if err := os.Remove(filepath.Join(libDir, "journals/2025_01_15.md")); err != nil {
+		t.Fatalf("Failed to remove journal: %v", err)
+	}
+
+	data, err := app.GetPage("Notes")
+	if err != nil {
+		t.Fatalf("GetPage failed: %v", err)
+	}
+	if len(data.Blocks) != 1 || data.Blocks[0].Content != "About [[Project]]" {
+		t.Errorf("Expected the new page's block, got %+v", data.Blocks)
+	}
+
+	want := []string{"2025-01-13", "notes"}
+	if got := referencingPages(app, "Project"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected Project to be linked from %v, got %v", want, got)
+	}
+	if _, exists := app.findPage("Jan 15th, 2025"); exists {
+		t.Error("Expected the deleted journal to be unloaded")
+	}
+}
+
+func TestSyncPagesKeepsPagesTheAppWrote(t *testing.T) {
+	libDir := setupJournalLibrary(t)
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	before := blockIDs(t, app, "Project")
+	if err := app.UpdateBlock("Project", before[0], "Planning notes for [[Launch]]"); err != nil {
+		t.Fatalf("UpdateBlock failed: %v", err)
+	}
+
+	// The saved file isn't reparsed, so block IDs stay stable
+	after := blockIDs(t, app, "Project")
+	if len(after) != 1 || after[0] != before[0] {
+		t.Errorf("Expected block IDs %v to be kept, got %v", before, after)
+	}
+	if changed := app.changedFiles(); len(changed) != 0 {
+		t.Errorf("Expected no outside changes after saving, got %v", changed)
+	}
+}
+
+func TestSyncPagesRetitledFile(t *testing.T) {
+	libDir := setupJournalLibrary(t)
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	path := filepath.Join(libDir, "pages/project.md")
+	if err := os.WriteFile(path, []byte("title:: Venture\n\n- Renamed outside the app"), 0644); err != nil {
+		t.Fatalf("Failed to write page: %v", err)
+	}
+
+	pages := app.GetPageList()
+	for _, title := range pages {
+		if title == "Project" {
+			t.Errorf("Expected the old title to be gone, got %v", pages)
+		}
+	}
+	if _, exists := app.findPage("Venture"); !exists {
+		t.Errorf("Expected the retitled page to be loaded, got %v", pages)
+	}
+}
+
+func TestWatcherReportsOutsideChanges(t *testing.T) {
+	libDir := setupJournalLibrary(t)
+	events := make(chan []string, 10)
+
+	app := NewApp()
+	app.watchFiles = true
+	app.watchDelay = 20 * time.Millisecond
+	app.onEvent = func(event string, data ...interface{}) {
+		if event == PagesChangedEvent {
+			events <- data[0].([]string)
+		}
+	}
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+	t.Cleanup(app.stopWatching)
+
+	// Saving from the app isn't reported
+	ids := blockIDs(t, app, "Project")
+	if err := app.UpdateBlock("Project", ids[0], "Edited in the app"); err != nil {
+		t.Fatalf("UpdateBlock failed: %v", err)
+	}
+	select {
+	case paths := <-events:
+		t.Errorf("Expected no event for the app's own save, got %v", paths)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	path := filepath.Join(libDir, "pages/project.md")
+	if err := os.WriteFile(path, []byte("# Project\n\n- Edited in another editor"), 0644); err != nil {
+		t.Fatalf("Failed to write page: %v", err)
+	}
+	select {
+	case paths := <-events:
+		if len(paths) != 1 || paths[0] != path {
+			t.Errorf("Expected event for %s, got %v", path, paths)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected an event for the outside edit")
+	}
+
+	data, err := app.GetPage("Project")
+	if err != nil {
+		t.Fatalf("GetPage failed: %v", err)
+	}
+	if len(data.Blocks) != 1 || data.Blocks[0].Content != "Edited in another editor" {
+		t.Errorf("Expected the outside edit, got %+v", data.Blocks)
+	}
+}
+
+func TestLoadDirectoryScansSubdirectories(t *testing.T) {
+	libDir := setupJournalLibrary(t)
+	files := map[string]string{
+		"pages/projects/alpha.md":     "# Alpha\n\n- Nested page about [[Project]]",
+		"pages/drafts/secret.md":      "# Secret\n\n- Not a page",
+		"journals/2024/2024_12_31.md": "- Year end",
+		".seq2bignore":                "pages/drafts/\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(libDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	if _, exists := app.findPage("Secret"); exists {
+		t.Error("Expected the ignored drafts folder to be skipped")
+	}
+	journal, exists := app.findPage("Dec 31st, 2024")
+	if !exists || !journal.IsJournal {
+		t.Errorf("Expected the nested journal to be loaded as a journal, got %v", journal)
+	}
+
+	// Edits are saved to the nested file rather than a new top-level one
+	ids := blockIDs(t, app, "Alpha")
+	if err := app.UpdateBlock("Alpha", ids[0], "Edited nested page"); err != nil {
+		t.Fatalf("UpdateBlock failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(libDir, "pages", "projects", "alpha.md"))
+	if err != nil {
+		t.Fatalf("Failed to read nested page: %v", err)
+	}
+	if !strings.Contains(string(content), "Edited nested page") {
+		t.Errorf("Expected the nested file to be updated, got %q", content)
+	}
+	if _, err := os.Stat(filepath.Join(libDir, "pages", "alpha.md")); err == nil {
+		t.Error("Expected no top-level copy of the nested page")
+	}
+
+	// A file added to a new subfolder is picked up
+	added := filepath.Join(libDir, "pages", "archive", "old.md")
+	if err := os.MkdirAll(filepath.Dir(added), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(added, []byte("- Archived [[Project]]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.GetPage("old"); err != nil {
+		t.Fatalf("GetPage failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(libDir, "pages", "old.md")); err == nil {
+		t.Error("Expected the archived page to be found, not created")
+	}
+}
+
+func TestDuplicatePageTakesOverWhenFileDeleted(t *testing.T) {
+	libDir := setupJournalLibrary(t)
+	files := map[string]string{
+		"pages/a.md": "title:: Shared\n\n- From a",
+		"pages/b.md": "title:: Shared\n\n- From b",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(libDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+	data, err := app.GetPage("Shared")
+	if err != nil {
+		t.Fatalf("GetPage failed: %v", err)
+	}
+	if len(data.Blocks) != 1 || data.Blocks[0].Content != "From a" {
+		t.Errorf("Expected the first file to hold the page, got %+v", data.Blocks)
+	}
+
+	// Editing the duplicate doesn't replace the loaded page
+	if err := os.WriteFile(filepath.Join(libDir, "pages/b.md"), []byte("title:: Shared\n\n- From b, edited"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := app.GetPage("Shared"); data.Blocks[0].Content != "From a" {
+		t.Errorf("Expected the page to stay loaded from a.md, got %+v", data.Blocks)
+	}
+
+	if err := os.Remove(filepath.Join(libDir, "pages/a.md")); err != nil {
+		t.Fatal(err)
+	}
+	data, err = app.GetPage("Shared")
+	if err != nil {
+		t.Fatalf("GetPage failed: %v", err)
+	}
+	if len(data.Blocks) != 1 || data.Blocks[0].Content != "From b, edited" {
+		t.Errorf("Expected the duplicate to take over, got %+v", data.Blocks)
+	}
+}
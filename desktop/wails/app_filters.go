@@ -0,0 +1,167 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// BacklinkFilterData is a backlink filter on a page and how many references
+// it hides
+type BacklinkFilterData struct {
+	Name string `json:"name"`
+	Include bool `json:"include"` // Show only references mentioning Name
+	Hidden int `json:"hidden"` // References this filter hides on its own
+}
+
+// filterBacklinks applies a page's filters:: property to its backlinks. It
+// returns the references left, each filter with the number of references it
+// hides, and the total number hidden.
+func (a *App) filterBacklinks(page *parser.Page, backlinks map[string][]parser.BlockReference) (map[string][]parser.BlockReference, []BacklinkFilterData, int) {
+	filters, err := page.BacklinkFilters()
+	if err != nil {
+		fmt.Printf("Warning: ignoring filters on %s: %v\n", page.Title, err)
+	}
+	result := []BacklinkFilterData{}
+	if len(filters) == 0 {
+		return backlinks, result, 0
+	}
+	
+	filterKeys := make([]string, len(filters))
+	for i, filter := range filters {
+		filterKeys[i] = a.backlinks.Key(filter.Name)
+		result = append(result, BacklinkFilterData{Name: filter.Name, Include: filter.Include})
+	}
+	
+	kept := make(map[string][]parser.BlockReference)
+	hidden := 0
+	for sourceKey, refs := range backlinks {
+		for _, ref := range refs {
+			names := a.referenceNames(sourceKey, ref)
+			visible := true
+			for i, filter := range filters {
+				if names[filterKeys[i]] != filter.Include {
+					result[i].Hidden++
+					visible = false
+				}
+			}
+			if visible {
+				kept[sourceKey] = append(kept[sourceKey], ref)
+			} else {
+				hidden++
+			}
+		}
+	}
+	return kept, result, hidden
+}
+
+// referenceNames returns the keys a reference can be filtered by: its source
+// page and the namespaces above it, and the pages and tags the referencing
+// block mentions
+func (a *App) referenceNames(sourceKey string, ref parser.BlockReference) map[string]bool {
+	names := map[string]bool{sourceKey: true}
+	
+	source, ok := a.pages[sourceKey]
+	if !ok {
+		return names
+	}
+	for _, namespace := range parser.NamespaceAncestors(source.Title) {
+		names[a.backlinks.Key(namespace)] = true
+	}
+	if block := findBlockByID(source.Blocks, ref.BlockID); block != nil {
+		for _, tag := range block.Tags {
+			names[a.backlinks.Key(tag)] = true
+		}
+		for _, link := range parser.ExtractPageLinks(block.Content) {
+			names[a.backlinks.Key(link)] = true
+		}
+	}
+	return names
+}
+
+// SetBacklinkFilter adds or changes a filter on a page's linked references
+// and saves it in the page's filters:: property. With include set only
+// references mentioning name are shown; otherwise they are hidden.
+func (a *App) SetBacklinkFilter(pageName string, name string, include bool) error {
+	return a.updateBacklinkFilters(pageName, func(filters []parser.BacklinkFilter) []parser.BacklinkFilter {
+		filters = a.withoutFilter(filters, name)
+		return append(filters, parser.BacklinkFilter{Name: name, Include: include})
+	})
+}
+
+// RemoveBacklinkFilter removes one filter from a page's linked references
+func (a *App) RemoveBacklinkFilter(pageName string, name string) error {
+	return a.updateBacklinkFilters(pageName, func(filters []parser.BacklinkFilter) []parser.BacklinkFilter {
+		return a.withoutFilter(filters, name)
+	})
+}
+
+// ClearBacklinkFilters removes every filter from a page's linked references
+func (a *App) ClearBacklinkFilters(pageName string) error {
+	return a.updateBacklinkFilters(pageName, func([]parser.BacklinkFilter) []parser.BacklinkFilter {
+		return nil
+	})
+}
+
+// updateBacklinkFilters rewrites a page's filters:: property and saves the
+// page
+func (a *App) updateBacklinkFilters(pageName string, update func([]parser.BacklinkFilter) []parser.BacklinkFilter) error {
+	page, exists := a.findPage(pageName)
+	if !exists {
+		return fmt.Errorf("page '%s' not found", pageName)
+	}
+	
+	filters, err := page.BacklinkFilters()
+	if err != nil {
+		return fmt.Errorf("page '%s' has invalid filters: %w", page.Title, err)
+	}
+	
+	value := parser.FormatFilters(update(filters))
+	if page.Properties == nil {
+		page.Properties = make(map[string]string)
+	}
+	if value == "" {
+		delete(page.Properties, parser.FiltersProperty)
+	} else {
+		page.Properties[parser.FiltersProperty] = value
+	}
+	
+	if err := a.savePage(page); err != nil {
+		return fmt.Errorf("failed to save page: %w", err)
+	}
+	return nil
+}
+
+// withoutFilter drops any filter on the same page as name
+func (a *App) withoutFilter(filters []parser.BacklinkFilter, name string) []parser.BacklinkFilter {
+	key := a.backlinks.Key(name)
+	kept := filters[:0]
+	for _, filter := range filters {
+		if a.backlinks.Key(filter.Name) != key {
+			kept = append(kept, filter)
+		}
+	}
+	return kept
+}
\ No newline at end of file
@@ -0,0 +1,114 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestBacklinkFilters verifies filters are saved to the page and applied to
+// its linked references
+func TestBacklinkFilters(t *testing.T) {
+	libDir := setupJournalLibrary(t)
+	files := map[string]string{
+		"pages/work___tasks.md": "# work/tasks\n\n- Plan [[Project]] #urgent\n- Review [[Project]]",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(libDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	// Hide one journal page
+	if err := app.SetBacklinkFilter("project", "jan 13th, 2025", false); err != nil {
+		t.Fatalf("SetBacklinkFilter failed: %v", err)
+	}
+	content, _ := os.ReadFile(filepath.Join(libDir, "pages", "project.md"))
+	if !strings.Contains(string(content), `filters:: {"jan 13th, 2025" false}`) {
+		t.Errorf("Expected filters property in page file, got:\n%s", content)
+	}
+
+	pageData, err := app.GetPage("Project")
+	if err != nil {
+		t.Fatalf("GetPage failed: %v", err)
+	}
+	if pageData.HiddenBacklinks != 1 || len(pageData.Backlinks) != 3 {
+		t.Errorf("Expected 1 hidden and 3 shown groups, got hidden=%d groups=%d", pageData.HiddenBacklinks, len(pageData.Backlinks))
+	}
+	if len(pageData.BacklinkFilters) != 1 || pageData.BacklinkFilters[0].Hidden != 1 {
+		t.Errorf("Unexpected filter counts %+v", pageData.BacklinkFilters)
+	}
+
+	// Show only references under the work namespace, then only #urgent ones
+	if err := app.SetBacklinkFilter("Project", "work", true); err != nil {
+		t.Fatalf("SetBacklinkFilter failed: %v", err)
+	}
+	pageData, _ = app.GetPage("Project")
+	if len(pageData.Backlinks) != 1 || pageData.Backlinks[0].Count != 2 || pageData.HiddenBacklinks != 3 {
+		t.Errorf("Expected only work/tasks references, got %+v hidden=%d", pageData.Backlinks, pageData.HiddenBacklinks)
+	}
+
+	if err := app.SetBacklinkFilter("Project", "work", false); err != nil {
+		t.Fatalf("SetBacklinkFilter failed: %v", err)
+	}
+	if err := app.SetBacklinkFilter("Project", "urgent", true); err != nil {
+		t.Fatalf("SetBacklinkFilter failed: %v", err)
+	}
+	pageData, _ = app.GetPage("Project")
+	counts := make(map[string]int)
+	for _, filter := range pageData.BacklinkFilters {
+		counts[filter.Name] = filter.Hidden
+	}
+	// Filters are counted separately, so a reference may count twice
+	if counts["work"] != 2 || counts["urgent"] != 4 || counts["jan 13th, 2025"] != 1 || pageData.HiddenBacklinks != 5 {
+		t.Errorf("Unexpected counts %v, hidden=%d", counts, pageData.HiddenBacklinks)
+	}
+
+	if err := app.RemoveBacklinkFilter("Project", "URGENT"); err != nil {
+		t.Fatalf("RemoveBacklinkFilter failed: %v", err)
+	}
+	pageData, _ = app.GetPage("Project")
+	if len(pageData.BacklinkFilters) != 2 {
+		t.Errorf("Expected 2 filters after removal, got %+v", pageData.BacklinkFilters)
+	}
+
+	if err := app.ClearBacklinkFilters("Project"); err != nil {
+		t.Fatalf("ClearBacklinkFilters failed: %v", err)
+	}
+	content, _ = os.ReadFile(filepath.Join(libDir, "pages", "project.md"))
+	if strings.Contains(string(content), "filters::") {
+		t.Errorf("Expected filters property to be removed, got:\n%s", content)
+	}
+	pageData, _ = app.GetPage("Project")
+	if pageData.HiddenBacklinks != 0 || len(pageData.Backlinks) != 4 {
+		t.Errorf("Expected all references after clearing, got hidden=%d groups=%d", pageData.HiddenBacklinks, len(pageData.Backlinks))
+	}
+}
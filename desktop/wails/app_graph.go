@@ -0,0 +1,152 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	
+	"github.com/rehanog/seq2b/pkg/graph"
+)
+
+// GraphScoreData is a page with a ranking score for the frontend
+type GraphScoreData struct {
+	Page string `json:"page"`
+	Score float64 `json:"score"`
+}
+
+// GraphAnalysisData summarises the structure of the link graph
+type GraphAnalysisData struct {
+	PageCount int `json:"pageCount"`
+	Components [][]string `json:"components"` // Largest first
+	PageRank []GraphScoreData `json:"pageRank"` // Most central pages first
+	Hubs []GraphScoreData `json:"hubs"` // Pages linking to many authorities
+	Authorities []GraphScoreData `json:"authorities"` // Pages linked from many hubs
+}
+
+// GraphHopData is a page some number of links away from another
+type GraphHopData struct {
+	Page string `json:"page"`
+	Distance int `json:"distance"`
+}
+
+// LinkSuggestionData is a page worth linking from the current one
+type LinkSuggestionData struct {
+	Page string `json:"page"`
+	Score int `json:"score"` // Number of pages citing both
+	CitedWith []string `json:"citedWith"`
+}
+
+// currentGraph returns the link graph, building it if links have changed
+// since it was last used
+func (a *App) currentGraph() (*graph.Graph, error) {
+	a.syncPages()
+	if a.backlinks == nil {
+		return nil, fmt.Errorf("no directory loaded")
+	}
+	if a.linkGraph == nil {
+		a.linkGraph = graph.New(a.backlinks)
+	}
+	return a.linkGraph, nil
+}
+
+// GetGraphAnalysis returns the connected components of the vault and the
+// top pages by PageRank and by hub and authority score
+func (a *App) GetGraphAnalysis(limit int) (*GraphAnalysisData, error) {
+	g, err := a.currentGraph()
+	if err != nil {
+		return nil, err
+	}
+	
+	hubs, authorities := g.HITS(50)
+	return &GraphAnalysisData{
+		PageCount: g.Len(),
+		Components: g.Components(),
+		PageRank: convertScores(g.PageRank(0.85, 100, 1e-6), limit),
+		Hubs: convertScores(hubs, limit),
+		Authorities: convertScores(authorities, limit),
+	}, nil
+}
+
+// GetShortestPath returns the pages on a shortest chain of links between two
+// pages, both included
+func (a *App) GetShortestPath(from string, to string) ([]string, error) {
+	g, err := a.currentGraph()
+	if err != nil {
+		return nil, err
+	}
+	return g.ShortestPath(from, to)
+}
+
+// GetPagesNear returns the pages within distance links of a page, nearest
+// first
+func (a *App) GetPagesNear(pageName string, distance int) ([]GraphHopData, error) {
+	g, err := a.currentGraph()
+	if err != nil {
+		return nil, err
+	}
+	
+	hops, err := g.Neighbourhood(pageName, distance)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]GraphHopData, len(hops))
+	for i, hop := range hops {
+		result[i] = GraphHopData{Page: hop.Page, Distance: hop.Distance}
+	}
+	return result, nil
+}
+
+// GetLinkSuggestions returns up to limit pages often cited together with a
+// page that it doesn't link to yet
+func (a *App) GetLinkSuggestions(pageName string, limit int) ([]LinkSuggestionData, error) {
+	g, err := a.currentGraph()
+	if err != nil {
+		return nil, err
+	}
+	
+	suggestions, err := g.SuggestLinks(pageName, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]LinkSuggestionData, len(suggestions))
+	for i, suggestion := range suggestions {
+		result[i] = LinkSuggestionData{
+			Page: suggestion.Page,
+			Score: suggestion.Score,
+			CitedWith: suggestion.CitedWith,
+		}
+	}
+	return result, nil
+}
+
+// convertScores converts at most limit scores; zero means all of them
+func convertScores(scores []graph.Score, limit int) []GraphScoreData {
+	if limit > 0 && limit < len(scores) {
+		scores = scores[:limit]
+	}
+	result := make([]GraphScoreData, len(scores))
+	for i, score := range scores {
+		result[i] = GraphScoreData{Page: score.Page, Score: score.Score}
+	}
+	return result
+}
\ No newline at end of file
@@ -0,0 +1,85 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestGraphAnalysis verifies the graph methods on a small vault
+func TestGraphAnalysis(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"index.md":  "# Index\n\n- [[Go]] and [[Rust]]\n- Also [[Zig]]",
+		"go.md":     "# Go\n\n- Compiled, see [[Rust]]",
+		"rust.md":   "# Rust\n\n- Systems language",
+		"zig.md":    "# Zig\n\n- Small language",
+		"island.md": "# Island\n\n- Alone",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	app := NewApp()
+	if err := app.LoadDirectory(tempDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	analysis, err := app.GetGraphAnalysis(2)
+	if err != nil {
+		t.Fatalf("GetGraphAnalysis failed: %v", err)
+	}
+	want := [][]string{{"Go", "Index", "Rust", "Zig"}, {"Island"}}
+	if !reflect.DeepEqual(analysis.Components, want) {
+		t.Errorf("Expected components %v, got %v", want, analysis.Components)
+	}
+	if len(analysis.PageRank) != 2 || analysis.PageRank[0].Page != "Rust" {
+		t.Errorf("Expected Rust to lead 2 PageRank results, got %+v", analysis.PageRank)
+	}
+	if analysis.Hubs[0].Page != "Index" {
+		t.Errorf("Expected Index as top hub, got %+v", analysis.Hubs)
+	}
+
+	path, err := app.GetShortestPath("zig", "go")
+	if err != nil || !reflect.DeepEqual(path, []string{"Zig", "Index", "Go"}) {
+		t.Errorf("GetShortestPath = %v, %v", path, err)
+	}
+
+	hops, err := app.GetPagesNear("Zig", 1)
+	if err != nil || !reflect.DeepEqual(hops, []GraphHopData{{Page: "Index", Distance: 1}}) {
+		t.Errorf("GetPagesNear = %+v, %v", hops, err)
+	}
+
+	suggestions, err := app.GetLinkSuggestions("Zig", 5)
+	if err != nil || len(suggestions) != 2 || suggestions[0].Page != "Go" {
+		t.Errorf("GetLinkSuggestions = %+v, %v", suggestions, err)
+	}
+	if _, err := app.GetShortestPath("Zig", "Island"); err == nil {
+		t.Error("Expected an error for a page in another component")
+	}
+}
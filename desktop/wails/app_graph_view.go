@@ -0,0 +1,224 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// GraphViewOptions selects and filters the pages shown in the graph view
+type GraphViewOptions struct {
+	Center string `json:"center"` // Page to centre on; empty for the whole vault
+	Hops int `json:"hops"` // Links to follow from Center, at least 1
+	HideJournals bool `json:"hideJournals"`
+	HideTags bool `json:"hideTags"` // Hide #tag pages that have no file of their own
+	HideOrphans bool `json:"hideOrphans"`
+	ExcludePattern string `json:"excludePattern"` // Regular expression matched against titles, ignoring case
+}
+
+// GraphNodeData is a page in the graph view
+type GraphNodeData struct {
+	ID string `json:"id"` // Page key, referenced by edges
+	Title string `json:"title"`
+	Exists bool `json:"exists"` // Has a file, rather than only being linked to
+	IsJournal bool `json:"isJournal"`
+	IsTag bool `json:"isTag"` // Referenced as a #tag
+	Namespace string `json:"namespace,omitempty"` // Enclosing namespace, if any
+	IsOrphan bool `json:"isOrphan"`
+	Degree int `json:"degree"` // Pages linked to or from it across the vault
+}
+
+// GraphEdgeData is a link between two pages in the graph view
+type GraphEdgeData struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Weight int `json:"weight"` // Number of links and tags from Source to Target
+}
+
+// GraphViewData is the set of nodes and edges to draw
+type GraphViewData struct {
+	Nodes []GraphNodeData `json:"nodes"`
+	Edges []GraphEdgeData `json:"edges"`
+}
+
+// graphEdge is a directed pair of page keys
+type graphEdge struct {
+	source, target string
+}
+
+// GetGraphView returns the nodes and edges of the whole vault, or of the
+// pages within a number of hops of a page, after applying the filters
+func (a *App) GetGraphView(options GraphViewOptions) (*GraphViewData, error) {
+	a.syncPages()
+	if a.backlinks == nil {
+		return nil, fmt.Errorf("no directory loaded")
+	}
+	
+	var exclude *regexp.Regexp
+	if options.ExcludePattern != "" {
+		var err error
+		if exclude, err = regexp.Compile("(?i)" + options.ExcludePattern); err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+	}
+	
+	// Links and tags both make edges
+	weights := make(map[graphEdge]int)
+	for source, targets := range a.backlinks.ForwardLinks {
+		for target, refs := range targets {
+			weights[graphEdge{source, target}] += len(refs)
+		}
+	}
+	tags := make(map[string]string) // Tag key -> spelling
+	for pageKey, page := range a.pages {
+		for _, block := range page.AllBlocks {
+			for _, tag := range block.Tags {
+				tagKey := a.backlinks.Key(tag)
+				if spelling, seen := tags[tagKey]; !seen || tag < spelling {
+					tags[tagKey] = tag
+				}
+				if tagKey != pageKey {
+					weights[graphEdge{pageKey, tagKey}]++
+				}
+			}
+		}
+	}
+	
+	neighbours := make(map[string]map[string]bool)
+	link := func(from, to string) {
+		if neighbours[from] == nil {
+			neighbours[from] = make(map[string]bool)
+		}
+		neighbours[from][to] = true
+	}
+	for edge := range weights {
+		link(edge.source, edge.target)
+		link(edge.target, edge.source)
+	}
+	
+	nodes := make(map[string]GraphNodeData)
+	addNode := func(key string) {
+		if _, done := nodes[key]; done {
+			return
+		}
+		_, exists := a.pages[key]
+		_, isTag := tags[key]
+		title := a.backlinks.Title(key)
+		if _, known := a.backlinks.Titles[key]; !known && isTag {
+			title = tags[key]
+		}
+		_, dateErr := a.journalFormat.ParseTitle(title)
+		nodes[key] = GraphNodeData{
+			ID: key,
+			Title: title,
+			Exists: exists,
+			IsJournal: dateErr == nil,
+			IsTag: isTag,
+			Namespace: parser.NamespaceParent(title),
+			IsOrphan: a.backlinks.IsOrphanPage(key) && len(neighbours[key]) == 0,
+			Degree: len(neighbours[key]),
+		}
+	}
+	for key := range a.pages {
+		addNode(key)
+	}
+	for edge := range weights {
+		addNode(edge.source)
+		addNode(edge.target)
+	}
+	
+	// Apply the filters
+	visible := make(map[string]bool, len(nodes))
+	for key, node := range nodes {
+		hidden := (options.HideJournals && node.IsJournal) ||
+			(options.HideTags && node.IsTag && !node.Exists) ||
+			(options.HideOrphans && node.IsOrphan) ||
+			(exclude != nil && exclude.MatchString(node.Title))
+		if !hidden {
+			visible[key] = true
+		}
+	}
+	
+	// Keep only the neighbourhood of the centre page, walking through
+	// visible pages
+	if options.Center != "" {
+		center := a.backlinks.Key(options.Center)
+		if _, ok := nodes[center]; !ok {
+			return nil, fmt.Errorf("page '%s' not found", options.Center)
+		}
+		hops := options.Hops
+		if hops < 1 {
+			hops = 1
+		}
+		
+		reached := map[string]bool{center: true}
+		frontier := []string{center}
+		for depth := 0; depth < hops && len(frontier) > 0; depth++ {
+			var next []string
+			for _, key := range frontier {
+				for neighbour := range neighbours[key] {
+					if visible[neighbour] && !reached[neighbour] {
+						reached[neighbour] = true
+						next = append(next, neighbour)
+					}
+				}
+			}
+			frontier = next
+		}
+		visible = reached
+	}
+	
+	result := &GraphViewData{Nodes: []GraphNodeData{}, Edges: []GraphEdgeData{}}
+	for key := range visible {
+		result.Nodes = append(result.Nodes, nodes[key])
+	}
+	for edge, weight := range weights {
+		if visible[edge.source] && visible[edge.target] {
+			result.Edges = append(result.Edges, GraphEdgeData{
+				Source: edge.source,
+				Target: edge.target,
+				Weight: weight,
+			})
+		}
+	}
+	
+	sort.Slice(result.Nodes, func(i, j int) bool {
+		left, right := strings.ToLower(result.Nodes[i].Title), strings.ToLower(result.Nodes[j].Title)
+		if left != right {
+			return left < right
+		}
+		return result.Nodes[i].ID < result.Nodes[j].ID
+	})
+	sort.Slice(result.Edges, func(i, j int) bool {
+		if result.Edges[i].Source != result.Edges[j].Source {
+			return result.Edges[i].Source < result.Edges[j].Source
+		}
+		return result.Edges[i].Target < result.Edges[j].Target
+	})
+	return result, nil
+}
\ No newline at end of file
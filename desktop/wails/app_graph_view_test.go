@@ -0,0 +1,148 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// setupGraphLibrary creates pages, journals, tags and an orphan
+func setupGraphLibrary(t *testing.T) *App {
+	t.Helper()
+	libDir := setupJournalLibrary(t)
+	files := map[string]string{
+		"pages/go.md":         "# Go\n\n- Compiled, see [[Rust]] and [[Rust]] #language",
+		"pages/rust.md":       "# Rust\n\n- Systems #language",
+		"pages/lang___zig.md": "# lang/zig\n\n- Links [[Go]]",
+		"pages/orphan.md":     "# Orphan\n\n- Nobody links here",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(libDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+	return app
+}
+
+// nodeTitles lists the titles of the nodes in a graph view
+func nodeTitles(view *GraphViewData) []string {
+	titles := []string{}
+	for _, node := range view.Nodes {
+		titles = append(titles, node.Title)
+	}
+	return titles
+}
+
+func TestGetGraphViewWholeVault(t *testing.T) {
+	app := setupGraphLibrary(t)
+
+	view, err := app.GetGraphView(GraphViewOptions{})
+	if err != nil {
+		t.Fatalf("GetGraphView failed: %v", err)
+	}
+
+	want := []string{"Go", "Jan 13th, 2025", "Jan 14th, 2025", "Jan 15th, 2025", "lang/zig", "language", "Orphan", "Project", "Rust"}
+	if got := nodeTitles(view); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expected nodes %v, got %v", want, got)
+	}
+
+	nodes := make(map[string]GraphNodeData)
+	for _, node := range view.Nodes {
+		nodes[node.Title] = node
+	}
+	if node := nodes["language"]; !node.IsTag || node.Exists || node.Degree != 2 {
+		t.Errorf("Expected a tag node linked from two pages, got %+v", node)
+	}
+	if !nodes["Jan 13th, 2025"].IsJournal || nodes["Go"].IsJournal {
+		t.Error("Expected only date pages to be journals")
+	}
+	if nodes["lang/zig"].Namespace != "lang" {
+		t.Errorf("Expected namespace lang, got %q", nodes["lang/zig"].Namespace)
+	}
+	if !nodes["Orphan"].IsOrphan || nodes["Go"].IsOrphan {
+		t.Error("Expected only Orphan to be an orphan")
+	}
+
+	for _, edge := range view.Edges {
+		if edge.Source == nodes["Go"].ID && edge.Target == nodes["Rust"].ID && edge.Weight != 2 {
+			t.Errorf("Expected two links from Go to Rust, got weight %d", edge.Weight)
+		}
+	}
+	if len(view.Edges) != 7 {
+		t.Errorf("Expected 7 edges, got %+v", view.Edges)
+	}
+}
+
+func TestGetGraphViewFilters(t *testing.T) {
+	app := setupGraphLibrary(t)
+
+	view, err := app.GetGraphView(GraphViewOptions{
+		HideJournals:   true,
+		HideTags:       true,
+		HideOrphans:    true,
+		ExcludePattern: "^LANG/",
+	})
+	if err != nil {
+		t.Fatalf("GetGraphView failed: %v", err)
+	}
+	if got := nodeTitles(view); !reflect.DeepEqual(got, []string{"Go", "Project", "Rust"}) {
+		t.Errorf("Unexpected filtered nodes %v", got)
+	}
+	if len(view.Edges) != 1 {
+		t.Errorf("Expected only the Go -> Rust edge, got %+v", view.Edges)
+	}
+
+	if _, err := app.GetGraphView(GraphViewOptions{ExcludePattern: "("}); err == nil {
+		t.Error("Expected an error for an invalid pattern")
+	}
+}
+
+func TestGetGraphViewNeighbourhood(t *testing.T) {
+	app := setupGraphLibrary(t)
+
+	view, err := app.GetGraphView(GraphViewOptions{Center: "rust", Hops: 1})
+	if err != nil {
+		t.Fatalf("GetGraphView failed: %v", err)
+	}
+	if got := nodeTitles(view); !reflect.DeepEqual(got, []string{"Go", "language", "Rust"}) {
+		t.Errorf("Unexpected 1-hop nodes %v", got)
+	}
+
+	// Hidden pages are not walked through
+	view, _ = app.GetGraphView(GraphViewOptions{Center: "Rust", Hops: 2, HideTags: true})
+	if got := nodeTitles(view); !reflect.DeepEqual(got, []string{"Go", "lang/zig", "Rust"}) {
+		t.Errorf("Unexpected 2-hop nodes %v", got)
+	}
+
+	if _, err := app.GetGraphView(GraphViewOptions{Center: "Nowhere"}); err == nil {
+		t.Error("Expected an error for an unknown centre page")
+	}
+}
@@ -0,0 +1,312 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+	
+	"github.com/rehanog/seq2b/pkg/history"
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// historyDir holds the versions of pages saved by the app, relative to the
+// library root. Like the trash it's under .seq2b/, which is never scanned
+// for pages.
+const historyDir = ".seq2b/history"
+
+// PageVersion is a saved version of a page
+type PageVersion struct {
+	Hash string `json:"hash"`
+	SavedAt time.Time `json:"savedAt"`
+	Current bool `json:"current"` // The page's file holds this version
+}
+
+// recordVersion adds content to a page's history. The page has been saved
+// whether or not this works, so a failure is only reported.
+func (a *App) recordVersion(title string, content []byte) {
+	if a.history == nil {
+		return
+	}
+	savedAt := time.Now()
+	if a.now != nil {
+		savedAt = a.now()
+	}
+	if _, _, err := a.history.Record(a.pageKey(title), content, savedAt); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+}
+
+// recordOriginal adds a page's file to its history, as of when the file was
+// last modified, before the page's first save replaces it
+func (a *App) recordOriginal(filePath string, title string) {
+	if a.history == nil {
+		return
+	}
+	if versions, err := a.history.Versions(a.pageKey(title)); err != nil || len(versions) > 0 {
+		return
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return
+	}
+	if _, _, err := a.history.Record(a.pageKey(title), content, info.ModTime()); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+}
+
+// GetPageHistory lists the saved versions of a page, newest first
+func (a *App) GetPageHistory(pageName string) ([]PageVersion, error) {
+	a.syncPages()
+	
+	page, found := a.findPage(pageName)
+	if !found {
+		return nil, fmt.Errorf("page '%s' not found", pageName)
+	}
+	versions, err := a.pageVersions(page)
+	if err != nil {
+		return nil, err
+	}
+	
+	current := ""
+	if content, err := os.ReadFile(page.Path); err == nil {
+		current = parser.ContentHash(content)
+	}
+	list := make([]PageVersion, 0, len(versions))
+	for i := len(versions) - 1; i >= 0; i-- {
+		list = append(list, PageVersion{
+			Hash: versions[i].Hash,
+			SavedAt: versions[i].SavedAt,
+			Current: versions[i].Hash == current,
+		})
+	}
+	return list, nil
+}
+
+// DiffPageVersions compares two versions of a page block by block. An
+// empty hash stands for the page's file as it is now.
+func (a *App) DiffPageVersions(pageName string, fromHash string, toHash string) (*parser.PageDiff, error) {
+	a.syncPages()
+	
+	page, found := a.findPage(pageName)
+	if !found {
+		return nil, fmt.Errorf("page '%s' not found", pageName)
+	}
+	from, err := a.parseVersion(page, fromHash)
+	if err != nil {
+		return nil, err
+	}
+	to, err := a.parseVersion(page, toHash)
+	if err != nil {
+		return nil, err
+	}
+	return parser.DiffPages(from, to), nil
+}
+
+// RestorePageVersion writes a saved version of a page back to its file.
+// The restore is recorded as a new version, so it can be undone the same
+// way.
+func (a *App) RestorePageVersion(pageName string, hash string) error {
+	a.syncPages()
+	
+	page, found := a.findPage(pageName)
+	if !found {
+		return fmt.Errorf("page '%s' not found", pageName)
+	}
+	if page.Path == "" {
+		return fmt.Errorf("page '%s' has no file", page.Title)
+	}
+	content, err := a.versionContent(page, hash)
+	if err != nil {
+		return err
+	}
+	
+	if err := a.checkConflict(page.Path, page, content); err != nil {
+		return err
+	}
+	a.recordOriginal(page.Path, page.Title)
+	if err := writeFileAtomic(page.Path, content, 0644); err != nil {
+		return fmt.Errorf("failed to restore page: %w", err)
+	}
+	a.recordVersion(page.Title, content)
+	
+	// Read the page back, as the version may have another title
+	a.forgetFile(page.Path)
+	a.applyFileChanges([]string{page.Path})
+	return nil
+}
+
+// RestoreBlockVersion brings back one block as it was in a saved version,
+// addressed by its path in that version. A block that's still on the page
+// gets its old content back; a block that was removed is put back where it
+// was, with any of its children that aren't elsewhere on the page.
+func (a *App) RestoreBlockVersion(pageName string, hash string, path BlockPath) error {
+	a.syncPages()
+	
+	page, found := a.findPage(pageName)
+	if !found {
+		return fmt.Errorf("page '%s' not found", pageName)
+	}
+	if hash == "" {
+		return fmt.Errorf("no version given")
+	}
+	version, err := a.parseVersion(page, hash)
+	if err != nil {
+		return err
+	}
+	block, err := FindBlockByPath(version.Blocks, path)
+	if err != nil {
+		return fmt.Errorf("block not found in version: %w", err)
+	}
+	
+	matches := parser.MatchBlocks(version, page)
+	if current, found := matches[block]; found {
+		if current.Content == block.Content {
+			return nil
+		}
+		current.SetContent(block.Content)
+	} else {
+		restored := restoreBlock(page, version, block, matches)
+		a.todoKeywords().Apply([]*parser.Block{restored})
+	}
+	
+	if err := a.savePage(page); err != nil {
+		return fmt.Errorf("failed to save page: %w", err)
+	}
+	a.reindexPage(page)
+	return nil
+}
+
+// restoreBlock puts a removed block back on a page: under the block its
+// parent became, after the block its nearest earlier sibling became. It
+// returns the restored copy.
+func restoreBlock(page *parser.Page, version *parser.Page, block *parser.Block, matches map[*parser.Block]*parser.Block) *parser.Block {
+	oldSiblings := version.Blocks
+	var parent *parser.Block
+	if block.Parent != nil {
+		oldSiblings = block.Parent.Children
+		parent = matches[block.Parent] // nil, so top level, if it's gone too
+	}
+	siblings := page.Blocks
+	if parent != nil {
+		siblings = parent.Children
+	}
+	
+	index := 0
+	for i := indexOfBlock(oldSiblings, block) - 1; i >= 0; i-- {
+		if current, found := matches[oldSiblings[i]]; found && current.Parent == parent {
+			index = indexOfBlock(siblings, current) + 1
+			break
+		}
+	}
+	
+	restored := copyRemovedBlock(block, parent, matches)
+	siblings = append(siblings[:index], append([]*parser.Block{restored}, siblings[index:]...)...)
+	if parent != nil {
+		parent.Children = siblings
+	} else {
+		page.Blocks = siblings
+	}
+	return restored
+}
+
+// copyRemovedBlock copies a block from a saved version into the current
+// page, leaving out children that are still on the page
+func copyRemovedBlock(block *parser.Block, parent *parser.Block, matches map[*parser.Block]*parser.Block) *parser.Block {
+	restored := &parser.Block{
+		ID: generateBlockID(),
+		Parent: parent,
+		Children: []*parser.Block{},
+	}
+	if parent != nil {
+		restored.Depth = parent.Depth + 1
+	}
+	restored.SetContent(block.Content)
+	for _, child := range block.Children {
+		if _, found := matches[child]; !found {
+			restored.Children = append(restored.Children, copyRemovedBlock(child, restored, matches))
+		}
+	}
+	return restored
+}
+
+// indexOfBlock returns a block's index among its siblings, or -1
+func indexOfBlock(blocks []*parser.Block, block *parser.Block) int {
+	for i, b := range blocks {
+		if b == block {
+			return i
+		}
+	}
+	return -1
+}
+
+// pageVersions returns a page's saved versions, oldest first
+func (a *App) pageVersions(page *parser.Page) ([]history.Version, error) {
+	if a.history == nil {
+		return nil, nil
+	}
+	versions, err := a.history.Versions(a.pageKey(page.Title))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page history: %w", err)
+	}
+	return versions, nil
+}
+
+// versionContent returns a saved version of a page, or its file as it is
+// now for an empty hash. Only the page's own versions can be read.
+func (a *App) versionContent(page *parser.Page, hash string) ([]byte, error) {
+	if hash == "" {
+		content, err := os.ReadFile(page.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read page: %w", err)
+		}
+		return content, nil
+	}
+	versions, err := a.pageVersions(page)
+	if err != nil {
+		return nil, err
+	}
+	for _, version := range versions {
+		if version.Hash == hash {
+			return a.history.Content(hash)
+		}
+	}
+	return nil, fmt.Errorf("page '%s' has no version '%s'", page.Title, hash)
+}
+
+// parseVersion parses a saved version of a page, or its file as it is now
+// for an empty hash
+func (a *App) parseVersion(page *parser.Page, hash string) (*parser.Page, error) {
+	content, err := a.versionContent(page, hash)
+	if err != nil {
+		return nil, err
+	}
+	opts := a.parseOptions(false)
+	opts.Journal = page.IsJournal
+	return parser.ParsePageContent(page.Path, content, opts)
+}
\ No newline at end of file
@@ -0,0 +1,247 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+const historyPage = "- TODO write report\n- Buy milk\n  - oat\n- Call Sam\n"
+
+// loadHistoryLibrary loads a library holding the page "Tasks", with a clock
+// that moves on a minute each time it's read
+func loadHistoryLibrary(t *testing.T) (*App, string) {
+	t.Helper()
+	libDir := t.TempDir()
+	pagePath := filepath.Join(libDir, "pages", "Tasks.md")
+	if err := os.MkdirAll(filepath.Dir(pagePath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pagePath, []byte(historyPage), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(pagePath, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	app := NewApp()
+	app.now = func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+	return app, pagePath
+}
+
+func readPageFile(t *testing.T, pagePath string) string {
+	t.Helper()
+	content, err := os.ReadFile(pagePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestSaveRecordsHistory(t *testing.T) {
+	app, pagePath := loadHistoryLibrary(t)
+
+	if history, err := app.GetPageHistory("Tasks"); err != nil || len(history) != 0 {
+		t.Fatalf("Unsaved page should have no history: %+v, %v", history, err)
+	}
+
+	if _, err := app.UpdateBlockAtPath("Tasks", BlockPath{0}, "DONE write report"); err != nil {
+		t.Fatalf("UpdateBlockAtPath failed: %v", err)
+	}
+	history, err := app.GetPageHistory("Tasks")
+	if err != nil {
+		t.Fatalf("GetPageHistory failed: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("Expected the original and the saved version, got %+v", history)
+	}
+	if !history[0].Current || history[1].Current {
+		t.Errorf("Newest version should be current: %+v", history)
+	}
+	if history[0].Hash != parser.ContentHash([]byte(readPageFile(t, pagePath))) {
+		t.Error("Newest version should be the saved file")
+	}
+	if history[1].Hash != parser.ContentHash([]byte(historyPage)) {
+		t.Error("Oldest version should be the original file")
+	}
+	if !history[1].SavedAt.Equal(time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("Original version should be dated by the file, got %v", history[1].SavedAt)
+	}
+
+	// Saving the same content again doesn't add a version
+	page, _ := app.findPage("Tasks")
+	if err := app.savePage(page); err != nil {
+		t.Fatalf("savePage failed: %v", err)
+	}
+	if history, _ := app.GetPageHistory("Tasks"); len(history) != 2 {
+		t.Errorf("Unchanged save added a version: %+v", history)
+	}
+
+	// History is kept in the library but never loaded as pages
+	if _, err := os.Stat(filepath.Join(filepath.Dir(filepath.Dir(pagePath)), ".seq2b", "history")); err != nil {
+		t.Errorf("History directory missing: %v", err)
+	}
+	if len(app.GetPageList()) != 1 {
+		t.Errorf("Unexpected pages %v", app.GetPageList())
+	}
+}
+
+func TestDiffPageVersions(t *testing.T) {
+	app, _ := loadHistoryLibrary(t)
+
+	if _, err := app.UpdateBlockAtPath("Tasks", BlockPath{0}, "DONE write report"); err != nil {
+		t.Fatalf("UpdateBlockAtPath failed: %v", err)
+	}
+	page, _ := app.findPage("Tasks")
+	page.Blocks = []*parser.Block{page.Blocks[0], page.Blocks[2]} // Remove "Buy milk"
+	if err := app.savePage(page); err != nil {
+		t.Fatalf("savePage failed: %v", err)
+	}
+
+	history, _ := app.GetPageHistory("Tasks")
+	if len(history) != 3 {
+		t.Fatalf("Expected 3 versions, got %+v", history)
+	}
+	diff, err := app.DiffPageVersions("Tasks", history[2].Hash, "")
+	if err != nil {
+		t.Fatalf("DiffPageVersions failed: %v", err)
+	}
+	var got []string
+	for _, change := range diff.Blocks {
+		got = append(got, string(change.Type)+": "+change.OldContent+" -> "+change.NewContent)
+	}
+	want := []string{
+		"edited: TODO write report -> DONE write report",
+		"removed: Buy milk -> ",
+		"removed: oat -> ",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Diff = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Change %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	// The middle version differs from the newest by the removal only
+	diff, err = app.DiffPageVersions("Tasks", history[1].Hash, history[0].Hash)
+	if err != nil || len(diff.Blocks) != 2 || diff.Blocks[0].Type != parser.BlockRemoved {
+		t.Errorf("Unexpected diff %+v, %v", diff, err)
+	}
+
+	if _, err := app.DiffPageVersions("Tasks", parser.ContentHash([]byte("other")), ""); err == nil {
+		t.Error("Diff with a version the page doesn't have should fail")
+	}
+}
+
+func TestRestorePageVersion(t *testing.T) {
+	app, pagePath := loadHistoryLibrary(t)
+
+	if _, err := app.UpdateBlockAtPath("Tasks", BlockPath{0}, "DONE write report"); err != nil {
+		t.Fatalf("UpdateBlockAtPath failed: %v", err)
+	}
+	history, _ := app.GetPageHistory("Tasks")
+	original := history[len(history)-1]
+
+	if err := app.RestorePageVersion("Tasks", original.Hash); err != nil {
+		t.Fatalf("RestorePageVersion failed: %v", err)
+	}
+	if got := readPageFile(t, pagePath); got != historyPage {
+		t.Errorf("Restored file = %q, want %q", got, historyPage)
+	}
+	page, _ := app.findPage("Tasks")
+	if page.Blocks[0].Content != "TODO write report" {
+		t.Errorf("Loaded page not restored: %q", page.Blocks[0].Content)
+	}
+
+	// The restore is a version of its own
+	history, _ = app.GetPageHistory("Tasks")
+	if len(history) != 3 || history[0].Hash != original.Hash || !history[0].Current {
+		t.Errorf("Restore should be recorded as the newest version: %+v", history)
+	}
+
+	if err := app.RestorePageVersion("Tasks", "../../pages/Tasks.md"); err == nil {
+		t.Error("Restoring an invalid version should fail")
+	}
+}
+
+func TestRestoreBlockVersion(t *testing.T) {
+	app, pagePath := loadHistoryLibrary(t)
+
+	if _, err := app.UpdateBlockAtPath("Tasks", BlockPath{0}, "DONE write report"); err != nil {
+		t.Fatalf("UpdateBlockAtPath failed: %v", err)
+	}
+	if _, err := app.UpdateBlockAtPath("Tasks", BlockPath{2}, "Call Alex"); err != nil {
+		t.Fatalf("UpdateBlockAtPath failed: %v", err)
+	}
+	page, _ := app.findPage("Tasks")
+	page.Blocks = []*parser.Block{page.Blocks[0], page.Blocks[2]} // Remove "Buy milk"
+	if err := app.savePage(page); err != nil {
+		t.Fatalf("savePage failed: %v", err)
+	}
+	history, _ := app.GetPageHistory("Tasks")
+	original := history[len(history)-1].Hash
+
+	// An edited block gets its old content back, leaving other edits
+	if err := app.RestoreBlockVersion("Tasks", original, BlockPath{0}); err != nil {
+		t.Fatalf("RestoreBlockVersion failed: %v", err)
+	}
+	want := "- TODO write report\n- Call Alex\n"
+	if got := readPageFile(t, pagePath); got != want {
+		t.Errorf("After restoring an edited block file = %q, want %q", got, want)
+	}
+
+	// A removed block comes back in its place, with its children
+	if err := app.RestoreBlockVersion("Tasks", original, BlockPath{1}); err != nil {
+		t.Fatalf("RestoreBlockVersion failed: %v", err)
+	}
+	want = "- TODO write report\n- Buy milk\n  - oat\n- Call Alex\n"
+	if got := readPageFile(t, pagePath); got != want {
+		t.Errorf("After restoring a removed block file = %q, want %q", got, want)
+	}
+	page, _ = app.findPage("Tasks")
+	if len(page.Blocks) != 3 || page.Blocks[1].Children[0].Parent != page.Blocks[1] {
+		t.Error("Restored block not linked into the page")
+	}
+
+	if history, _ := app.GetPageHistory("Tasks"); len(history) != 6 {
+		t.Errorf("Each restore should add a version, got %d", len(history))
+	}
+	if err := app.RestoreBlockVersion("Tasks", original, BlockPath{7}); err == nil {
+		t.Error("Restoring a block the version doesn't have should fail")
+	}
+}
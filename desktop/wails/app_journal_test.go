@@ -0,0 +1,113 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeLogseqConfig writes a logseq/config.edn with the given journal formats
+func writeLogseqConfig(t *testing.T, libraryDir, titleFormat, fileFormat string) {
+	t.Helper()
+	configDir := filepath.Join(libraryDir, "logseq")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create logseq dir: %v", err)
+	}
+	config := `{:journal/page-title-format "` + titleFormat + `"
+ :journal/file-name-format "` + fileFormat + `"}`
+	if err := os.WriteFile(filepath.Join(configDir, "config.edn"), []byte(config), 0644); err != nil {
+		t.Fatalf("Failed to write config.edn: %v", err)
+	}
+}
+
+// TestImportedJournalNotDuplicated verifies that Logseq journals named with a
+// custom filename format are reused instead of recreated under ISO names
+func TestImportedJournalNotDuplicated(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "seq2b-journal-format-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	pagesDir := filepath.Join(tempDir, "pages")
+	if err := os.MkdirAll(pagesDir, 0755); err != nil {
+		t.Fatalf("Failed to create pages dir: %v", err)
+	}
+	writeLogseqConfig(t, tempDir, "EEEE, dd.MM.yyyy", "yyyy_MM_dd")
+
+	// Logseq journal: underscore filename, no header
+	journalFile := filepath.Join(pagesDir, "2025_01_15.md")
+	if err := os.WriteFile(journalFile, []byte("- Imported entry\n"), 0644); err != nil {
+		t.Fatalf("Failed to write journal: %v", err)
+	}
+
+	app := NewApp()
+	if err := app.LoadDirectory(tempDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	// Any spelling of the date should land on the imported journal
+	for _, name := range []string{"Wednesday, 15.01.2025", "Jan 15th, 2025", "2025-01-15"} {
+		pageData, err := app.GetPage(name)
+		if err != nil {
+			t.Fatalf("GetPage(%q) failed: %v", name, err)
+		}
+		if pageData.Title != "Wednesday, 15.01.2025" {
+			t.Errorf("GetPage(%q) title = %q, want configured title", name, pageData.Title)
+		}
+		if len(pageData.Blocks) == 0 || pageData.Blocks[0].Content != "Imported entry" {
+			t.Errorf("GetPage(%q) did not return the imported content", name)
+		}
+	}
+
+	// Editing saves back to the same file
+	if _, err := app.UpdateBlockAtPath("Wednesday, 15.01.2025", BlockPath{0}, "Edited entry"); err != nil {
+		t.Fatalf("Failed to update block: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(pagesDir, "*.md"))
+	if len(files) != 1 {
+		t.Fatalf("Expected a single journal file, got %v", files)
+	}
+	content, _ := os.ReadFile(journalFile)
+	if !strings.Contains(string(content), "Edited entry") {
+		t.Errorf("Edit not written to imported journal file: %q", content)
+	}
+}
+
+// TestNewJournalUsesConfiguredFormats verifies new date pages follow config.edn
+func TestNewJournalUsesConfiguredFormats(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "seq2b-journal-new-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	pagesDir := filepath.Join(tempDir, "pages")
+	if err := os.MkdirAll(pagesDir, 0755); err != nil {
+		t.Fatalf("Failed to create pages dir: %v", err)
+	}
+	writeLogseqConfig(t, tempDir, "EEEE, dd.MM.yyyy", "yyyy_MM_dd")
+
+	app := NewApp()
+	if err := app.LoadDirectory(tempDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	pageData, err := app.GetPage("Feb 3rd, 2025")
+	if err != nil {
+		t.Fatalf("Failed to create date page: %v", err)
+	}
+	if pageData.Title != "Monday, 03.02.2025" {
+		t.Errorf("Expected configured title, got %q", pageData.Title)
+	}
+
+	journalsDir := filepath.Join(tempDir, "journals")
+	if _, err := os.Stat(filepath.Join(journalsDir, "2025_02_03.md")); err != nil {
+		t.Errorf("Expected journal file 2025_02_03.md: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(journalsDir, "2025-02-03.md")); err == nil {
+		t.Error("Journal should not be written under the ISO filename")
+	}
+}
\ No newline at end of file
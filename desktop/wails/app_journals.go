@@ -0,0 +1,100 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+	
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// JournalFeed is one page of the journals feed, newest first
+type JournalFeed struct {
+	Journals []PageData `json:"journals"`
+	Offset int `json:"offset"`
+	Total int `json:"total"`
+	HasMore bool `json:"hasMore"`
+}
+
+// journalEntry pairs a journal page with its date for sorting
+type journalEntry struct {
+	date time.Time
+	page *parser.Page
+}
+
+// GetJournals returns up to limit journal pages starting at offset,
+// ordered newest first
+func (a *App) GetJournals(offset int, limit int) (*JournalFeed, error) {
+	if offset < 0 || limit <= 0 {
+		return nil, fmt.Errorf("invalid journal range: offset %d, limit %d", offset, limit)
+	}
+	
+	// Pick up journals written outside the app
+	a.syncPages()
+	
+	journals := a.sortedJournals()
+	
+	feed := &JournalFeed{
+		Journals: []PageData{},
+		Offset: offset,
+		Total: len(journals),
+	}
+	
+	end := offset + limit
+	if end > len(journals) {
+		end = len(journals)
+	}
+	for i := offset; i < end; i++ {
+		page := journals[i].page
+		feed.Journals = append(feed.Journals, *a.buildPageData(page.Title, page))
+	}
+	feed.HasMore = end < len(journals)
+	
+	return feed, nil
+}
+
+// sortedJournals returns all journal pages, newest first
+func (a *App) sortedJournals() []journalEntry {
+	var journals []journalEntry
+	for _, page := range a.pages {
+		if !page.IsJournal {
+			continue
+		}
+		date, err := a.journalFormat.ParseTitle(page.Title)
+		if err != nil {
+			// Journals with a non-date title sort after all dated ones
+			date = time.Time{}
+		}
+		journals = append(journals, journalEntry{date: date, page: page})
+	}
+	
+	sort.Slice(journals, func(i, j int) bool {
+		if !journals[i].date.Equal(journals[j].date) {
+			return journals[i].date.After(journals[j].date)
+		}
+		return journals[i].page.Title < journals[j].page.Title
+	})
+	return journals
+}
\ No newline at end of file
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setupJournalLibrary creates a library with pages/ and journals/ directories
+func setupJournalLibrary(t *testing.T) string {
+	t.Helper()
+	tempDir, err := os.MkdirTemp("", "seq2b-journals-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(tempDir) })
+
+	files := map[string]string{
+		"pages/project.md":       "# Project\n\n- Planning notes",
+		"journals/2025_01_13.md": "- Started [[Project]]",
+		"journals/2025_01_14.md": "- Continued [[Project]]",
+		"journals/2025_01_15.md": "- Finished [[Project]]",
+	}
+	for name, content := range files {
+		path := filepath.Join(tempDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return tempDir
+}
+
+// TestLoadJournalsDirectory verifies pages/ and journals/ are both loaded
+func TestLoadJournalsDirectory(t *testing.T) {
+	app := NewApp()
+	if err := app.LoadDirectory(setupJournalLibrary(t)); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	project, err := app.GetPage("Project")
+	if err != nil {
+		t.Fatalf("Failed to get Project: %v", err)
+	}
+	if project.IsJournal {
+		t.Error("Project should not be marked as a journal")
+	}
+	if len(project.Backlinks) != 3 {
+		t.Errorf("Expected 3 journal backlinks to Project, got %d", len(project.Backlinks))
+	}
+
+	journal, err := app.GetPage("Jan 14th, 2025")
+	if err != nil {
+		t.Fatalf("Failed to get journal: %v", err)
+	}
+	if !journal.IsJournal {
+		t.Error("Journal page should be marked as a journal")
+	}
+}
+
+// TestNewJournalWrittenToJournalsDir verifies new date pages go to journals/
+func TestNewJournalWrittenToJournalsDir(t *testing.T) {
+	libraryDir := setupJournalLibrary(t)
+	app := NewApp()
+	if err := app.LoadDirectory(libraryDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	if _, err := app.GetPage("Feb 1st, 2025"); err != nil {
+		t.Fatalf("Failed to create journal: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(libraryDir, "journals", "2025-02-01.md")); err != nil {
+		t.Errorf("New journal not written to journals/: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(libraryDir, "pages", "2025-02-01.md")); err == nil {
+		t.Error("New journal should not be written to pages/")
+	}
+
+	// Regular pages still go to pages/
+	if _, err := app.GetPage("Brand New Page"); err != nil {
+		t.Fatalf("Failed to create page: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(libraryDir, "pages", "Brand New Page.md")); err != nil {
+		t.Errorf("New page not written to pages/: %v", err)
+	}
+}
+
+// TestGetJournalsFeed verifies the journals feed is paginated newest first
+func TestGetJournalsFeed(t *testing.T) {
+	app := NewApp()
+	if err := app.LoadDirectory(setupJournalLibrary(t)); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	feed, err := app.GetJournals(0, 2)
+	if err != nil {
+		t.Fatalf("GetJournals failed: %v", err)
+	}
+	if feed.Total != 3 || !feed.HasMore {
+		t.Errorf("Expected total 3 with more pages, got total %d hasMore %v", feed.Total, feed.HasMore)
+	}
+	if len(feed.Journals) != 2 {
+		t.Fatalf("Expected 2 journals, got %d", len(feed.Journals))
+	}
+	if feed.Journals[0].Title != "Jan 15th, 2025" || feed.Journals[1].Title != "Jan 14th, 2025" {
+		t.Errorf("Journals not newest first: %q, %q", feed.Journals[0].Title, feed.Journals[1].Title)
+	}
+
+	feed, err = app.GetJournals(2, 2)
+	if err != nil {
+		t.Fatalf("GetJournals failed: %v", err)
+	}
+	if len(feed.Journals) != 1 || feed.HasMore {
+		t.Errorf("Expected last journal only, got %d (hasMore %v)", len(feed.Journals), feed.HasMore)
+	}
+	if feed.Journals[0].Title != "Jan 13th, 2025" {
+		t.Errorf("Expected oldest journal last, got %q", feed.Journals[0].Title)
+	}
+
+	if _, err := app.GetJournals(-1, 10); err == nil {
+		t.Error("Expected error for negative offset")
+	}
+}
\ No newline at end of file
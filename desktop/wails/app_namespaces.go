@@ -0,0 +1,151 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// BreadcrumbData is one enclosing namespace of a page
+type BreadcrumbData struct {
+	Name string `json:"name"` // Full name to navigate to, e.g. Projects/seq2b
+	Title string `json:"title"` // Last part of the name, e.g. seq2b
+	Exists bool `json:"exists"` // False for namespaces without a page of their own
+}
+
+// NamespaceNodeData is a node of the namespace tree for the frontend
+type NamespaceNodeData struct {
+	Name string `json:"name"`
+	Title string `json:"title"`
+	Exists bool `json:"exists"`
+	Children []NamespaceNodeData `json:"children"`
+}
+
+// NamespaceBacklinkData groups the backlinks to one page of a namespace
+type NamespaceBacklinkData struct {
+	TargetPage string `json:"targetPage"`
+	Backlinks []BacklinkData `json:"backlinks"`
+}
+
+// namespaceTree returns the namespace hierarchy, building it if the pages
+// have been reloaded since it was last used
+func (a *App) namespaceTree() *parser.NamespaceTree {
+	if a.namespaces == nil {
+		a.namespaces = parser.BuildNamespaceTree(a.pages, a.pageKey)
+	}
+	return a.namespaces
+}
+
+// breadcrumbs returns the enclosing namespaces of a page
+func (a *App) breadcrumbs(title string) []BreadcrumbData {
+	crumbs := []BreadcrumbData{}
+	for _, node := range a.namespaceTree().Ancestors(title) {
+		crumbs = append(crumbs, BreadcrumbData{
+			Name: node.Name,
+			Title: node.Title,
+			Exists: node.Exists,
+		})
+	}
+	return crumbs
+}
+
+// convertNamespaceNodes converts tree nodes to frontend data recursively
+func convertNamespaceNodes(nodes []*parser.NamespaceNode) []NamespaceNodeData {
+	result := make([]NamespaceNodeData, 0, len(nodes))
+	for _, node := range nodes {
+		result = append(result, NamespaceNodeData{
+			Name: node.Name,
+			Title: node.Title,
+			Exists: node.Exists,
+			Children: convertNamespaceNodes(node.Children),
+		})
+	}
+	return result
+}
+
+// GetNamespaceTree returns the namespace hierarchy below root, or the
+// whole vault's hierarchy when root is empty
+func (a *App) GetNamespaceTree(root string) ([]NamespaceNodeData, error) {
+	a.syncPages()
+	
+	tree := a.namespaceTree()
+	if root == "" {
+		return convertNamespaceNodes(tree.Roots), nil
+	}
+	
+	node := tree.Node(root)
+	if node == nil {
+		return nil, fmt.Errorf("namespace '%s' not found", root)
+	}
+	return convertNamespaceNodes(node.Children), nil
+}
+
+// GetNamespacePages lists the pages in a namespace: direct children only,
+// or every page below it when recursive is set
+func (a *App) GetNamespacePages(namespace string, recursive bool) ([]string, error) {
+	a.syncPages()
+	
+	tree := a.namespaceTree()
+	node := tree.Node(namespace)
+	if node == nil {
+		return nil, fmt.Errorf("namespace '%s' not found", namespace)
+	}
+	
+	if recursive {
+		pages := tree.Subtree(namespace, true)
+		if pages == nil {
+			pages = []string{}
+		}
+		return pages, nil
+	}
+	
+	pages := []string{}
+	for _, child := range node.Children {
+		if child.Exists {
+			pages = append(pages, child.Name)
+		}
+	}
+	return pages, nil
+}
+
+// GetNamespaceBacklinks returns the backlinks to a namespace page and to
+// every page below it
+func (a *App) GetNamespaceBacklinks(namespace string) ([]NamespaceBacklinkData, error) {
+	a.syncPages()
+	
+	result := []NamespaceBacklinkData{}
+	for targetKey, backlinks := range a.backlinks.GetNamespaceBacklinks(namespace) {
+		result = append(result, NamespaceBacklinkData{
+			TargetPage: a.backlinks.Title(targetKey),
+			Backlinks: a.convertBacklinks(backlinks, false),
+		})
+	}
+	
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TargetPage < result[j].TargetPage
+	})
+	return result, nil
+}
\ No newline at end of file
@@ -0,0 +1,228 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// setupNamespaceLibrary writes namespaced pages to a flat directory
+func setupNamespaceLibrary(t *testing.T) string {
+	t.Helper()
+	tempDir, err := os.MkdirTemp("", "seq2b-namespace-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(tempDir) })
+
+	files := map[string]string{
+		"projects___seq2b.md":          "# Projects/seq2b\n\n- The app",
+		"projects___seq2b___parser.md": "# Projects/seq2b/Parser\n\n- Parses markdown",
+		"projects___archive.md":        "# Projects/Archive\n\n- Old work",
+		"notes.md":                     "# Notes\n\n- See [[Projects/seq2b/Parser]] and [[Projects/Archive]]",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return tempDir
+}
+
+// TestNamespaceBreadcrumbs verifies PageData carries the enclosing namespaces
+func TestNamespaceBreadcrumbs(t *testing.T) {
+	app := NewApp()
+	if err := app.LoadDirectory(setupNamespaceLibrary(t)); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	pageData, err := app.GetPage("Projects/seq2b/Parser")
+	if err != nil {
+		t.Fatalf("GetPage failed: %v", err)
+	}
+
+	crumbs := pageData.Breadcrumbs
+	if len(crumbs) != 2 {
+		t.Fatalf("Expected 2 breadcrumbs, got %+v", crumbs)
+	}
+	if crumbs[0].Name != "Projects" || crumbs[0].Exists {
+		t.Errorf("First breadcrumb should be the page-less Projects namespace, got %+v", crumbs[0])
+	}
+	if crumbs[1].Name != "Projects/seq2b" || crumbs[1].Title != "seq2b" || !crumbs[1].Exists {
+		t.Errorf("Unexpected second breadcrumb: %+v", crumbs[1])
+	}
+}
+
+// TestNamespaceQueries verifies the tree, page listing and backlink queries
+func TestNamespaceQueries(t *testing.T) {
+	app := NewApp()
+	if err := app.LoadDirectory(setupNamespaceLibrary(t)); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	tree, err := app.GetNamespaceTree("")
+	if err != nil {
+		t.Fatalf("GetNamespaceTree failed: %v", err)
+	}
+	if len(tree) != 2 || tree[1].Name != "Projects" || len(tree[1].Children) != 2 {
+		t.Errorf("Unexpected namespace tree: %+v", tree)
+	}
+
+	direct, err := app.GetNamespacePages("projects", false)
+	if err != nil {
+		t.Fatalf("GetNamespacePages failed: %v", err)
+	}
+	if strings.Join(direct, ",") != "Projects/Archive,Projects/seq2b" {
+		t.Errorf("Direct children = %v", direct)
+	}
+
+	all, _ := app.GetNamespacePages("Projects", true)
+	if len(all) != 3 {
+		t.Errorf("Expected 3 pages below Projects, got %v", all)
+	}
+
+	if _, err := app.GetNamespacePages("Nowhere", false); err == nil {
+		t.Error("Expected error for unknown namespace")
+	}
+
+	backlinks, err := app.GetNamespaceBacklinks("Projects")
+	if err != nil {
+		t.Fatalf("GetNamespaceBacklinks failed: %v", err)
+	}
+	if len(backlinks) != 2 || backlinks[0].TargetPage != "Projects/Archive" || backlinks[1].TargetPage != "Projects/seq2b/Parser" {
+		t.Errorf("Unexpected namespace backlinks: %+v", backlinks)
+	}
+}
+
+// TestNamespacedPageFiles verifies "/" is encoded and legacy files are reused
+func TestNamespacedPageFiles(t *testing.T) {
+	libDir := setupNamespaceLibrary(t)
+	legacy := filepath.Join(libDir, "projects-legacy.md")
+	if err := os.WriteFile(legacy, []byte("# Projects/Legacy\n\n- Written by an older version"), 0644); err != nil {
+		t.Fatalf("Failed to write page: %v", err)
+	}
+
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	if _, err := app.GetPage("Projects/New Idea"); err != nil {
+		t.Fatalf("GetPage failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(libDir, "Projects___New Idea.md")); err != nil {
+		t.Errorf("New namespaced page not written with encoded filename: %v", err)
+	}
+
+	if err := app.UpdateBlock("Projects/Legacy", "block-1", "Edited"); err != nil {
+		t.Fatalf("UpdateBlock failed: %v", err)
+	}
+	content, _ := os.ReadFile(legacy)
+	if !strings.Contains(string(content), "- Edited") {
+		t.Errorf("Legacy file should be updated in place:\n%s", content)
+	}
+	if _, err := os.Stat(filepath.Join(libDir, "projects___legacy.md")); err == nil {
+		t.Error("Saving a legacy page should not create a second file")
+	}
+}
+
+// TestImportedLogseqFileNames verifies pages imported from a legacy Logseq
+// vault are saved in place and new pages use the vault's format
+func TestImportedLogseqFileNames(t *testing.T) {
+	libDir := t.TempDir()
+	files := map[string]string{
+		"logseq/config.edn":          "{:meta/version 1}",
+		"pages/Projects%2FLegacy.md": "- Imported",
+		"pages/Reading List.md":      "- Books",
+		"journals/2025_01_13.md":     "- See [[Projects/Legacy]]",
+	}
+	for name, content := range files {
+		path := filepath.Join(libDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	for _, title := range []string{"Projects/Legacy", "Reading List"} {
+		if err := app.UpdateBlock(title, "block-1", "Edited"); err != nil {
+			t.Fatalf("UpdateBlock(%q) failed: %v", title, err)
+		}
+	}
+	entries, _ := os.ReadDir(filepath.Join(libDir, "pages"))
+	if len(entries) != 2 {
+		t.Errorf("Saving imported pages should not create new files, got %d files", len(entries))
+	}
+	content, _ := os.ReadFile(filepath.Join(libDir, "pages", "Projects%2FLegacy.md"))
+	if !strings.Contains(string(content), "- Edited") {
+		t.Errorf("Imported file should be updated in place:\n%s", content)
+	}
+
+	if _, err := app.GetPage("Projects/New Idea"); err != nil {
+		t.Fatalf("GetPage failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(libDir, "pages", "Projects%2FNew Idea.md")); err != nil {
+		t.Errorf("New page not written with the vault's legacy format: %v", err)
+	}
+}
+
+// TestCreatePageFileCollision verifies a new page isn't written over a file
+// that holds a different page
+func TestCreatePageFileCollision(t *testing.T) {
+	libDir := t.TempDir()
+	meeting := filepath.Join(libDir, "Meeting.md")
+	if err := os.WriteFile(meeting, []byte("title:: Standup\n\n- Daily"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	_, err := app.GetPage("Meeting")
+	var collision *parser.FileNameCollisionError
+	if !errors.As(err, &collision) {
+		t.Fatalf("GetPage(Meeting) error = %v, want FileNameCollisionError", err)
+	}
+	if collision.ExistingTitle != "Standup" {
+		t.Errorf("ExistingTitle = %q, want Standup", collision.ExistingTitle)
+	}
+	content, _ := os.ReadFile(meeting)
+	if string(content) != "title:: Standup\n\n- Daily" {
+		t.Errorf("Existing file should be untouched:\n%s", content)
+	}
+}
@@ -62,7 +62,7 @@ func TestEditPersistenceAcrossNavigation(t *testing.T) {
 	}
 
 	// Verify Page B was created
-	pageBFile := filepath.Join(tempDir, "page-b.md")
+	pageBFile := filepath.Join(tempDir, "Page B.md")
 	if _, err := os.Stat(pageBFile); os.IsNotExist(err) {
 		t.Error("Page B file was not created")
 	}
@@ -157,4 +157,99 @@ func TestBacklinksCaseInsensitive(t *testing.T) {
 	if len(backlinks) == 0 || len(backlinks["Source"]) == 0 {
 		t.Error("Backlinks lookup should work with uppercase")
 	}
-}
\ No newline at end of file
+}
+
+// TestDateSpellingsShareJournalPage verifies every spelling of a date
+// resolves to one journal page with combined backlinks
+func TestDateSpellingsShareJournalPage(t *testing.T) {
+	libDir := setupJournalLibrary(t)
+	meetings := `# Meetings
+
+- Kickoff on [[Jan 15th, 2025]]
+- Follow up [[2025-01-15]]
+- Notes from [[January 15, 2025]]`
+	if err := os.WriteFile(filepath.Join(libDir, "pages", "meetings.md"), []byte(meetings), 0644); err != nil {
+		t.Fatalf("Failed to write page: %v", err)
+	}
+
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	for _, spelling := range []string{"Jan 15th, 2025", "2025-01-15", "January 15, 2025"} {
+		pageData, err := app.GetPage(spelling)
+		if err != nil {
+			t.Fatalf("GetPage(%q) failed: %v", spelling, err)
+		}
+		if pageData.Title != "Jan 15th, 2025" {
+			t.Errorf("GetPage(%q) returned %q", spelling, pageData.Title)
+		}
+
+		var meetingRefs int
+		for _, backlink := range pageData.Backlinks {
+			if backlink.SourcePage == "Meetings" {
+				meetingRefs = backlink.Count
+			}
+		}
+		if meetingRefs != 3 {
+			t.Errorf("GetPage(%q) has %d backlinks from Meetings, want 3", spelling, meetingRefs)
+		}
+	}
+
+	// No stray pages were created for the alternative spellings
+	journals, _ := filepath.Glob(filepath.Join(libDir, "journals", "*.md"))
+	if len(journals) != 3 {
+		t.Errorf("Expected 3 journal files, got %v", journals)
+	}
+	if _, err := os.Stat(filepath.Join(libDir, "pages", "2025-01-15.md")); err == nil {
+		t.Error("Date page should not be created in pages/")
+	}
+}
+
+// TestPageKeysAreNormalised verifies that whitespace and Unicode variants of
+// a name reach the same page and share its backlinks
+func TestPageKeysAreNormalised(t *testing.T) {
+	tempDir := t.TempDir()
+
+	files := map[string]string{
+		"project.md": "# Project\n\n- The project page",
+		"notes.md":   "# Notes\n\n- Work on [[project]]\n- Review [[  PROJECT ]]",
+		"cafe.md":    "# Café\n\n- Coffee",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	app := NewApp()
+	if err := app.LoadDirectory(tempDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	pageData, err := app.GetPage(" PROJECT ")
+	if err != nil {
+		t.Fatalf("Failed to get page: %v", err)
+	}
+	if pageData.Title != "Project" {
+		t.Errorf("Expected display title 'Project', got '%s'", pageData.Title)
+	}
+	if len(pageData.Backlinks) != 1 || pageData.Backlinks[0].Count != 2 {
+		t.Errorf("Expected 2 backlinks from both spellings, got %+v", pageData.Backlinks)
+	}
+
+	// Decomposed "e" + combining accent is the same page as "é"
+	pageData, err = app.GetPage("CAFÉ")
+	if err != nil {
+		t.Fatalf("Failed to get page by decomposed name: %v", err)
+	}
+	if pageData.Title != "Café" {
+		t.Errorf("Expected display title 'Café', got '%s'", pageData.Title)
+	}
+
+	titles := strings.Join(app.GetPageList(), ",")
+	if !strings.Contains(titles, "Project") || !strings.Contains(titles, "Café") {
+		t.Errorf("Expected page list to use display titles, got %s", titles)
+	}
+}
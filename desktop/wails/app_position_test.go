@@ -74,7 +74,7 @@ func TestPositionalAddAndUpdate(t *testing.T) {
 		}
 		
 		// Verify block was added
-		page := app.pages["Test Page"]
+		page, _ := app.findPage("Test Page")
 		if len(page.Blocks) != 3 {
 			t.Errorf("Expected 3 blocks, got %d", len(page.Blocks))
 		}
@@ -96,7 +96,7 @@ func TestPositionalAddAndUpdate(t *testing.T) {
 		}
 		
 		// Verify block was updated
-		page := app.pages["Test Page"]
+		page, _ := app.findPage("Test Page")
 		secondChild := page.Blocks[0].Children[1]
 		if secondChild.Content != "Updated second child" {
 			t.Errorf("Expected 'Updated second child', got %s", secondChild.Content)
@@ -111,7 +111,7 @@ func TestPositionalAddAndUpdate(t *testing.T) {
 		}
 		
 		// Verify block was added
-		page := app.pages["Test Page"]
+		page, _ := app.findPage("Test Page")
 		if len(page.Blocks[0].Children) != 3 {
 			t.Errorf("Expected 3 children, got %d", len(page.Blocks[0].Children))
 		}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"os"
 	"testing"
 	"path/filepath"
 )
@@ -43,4 +44,36 @@ func TestReferencesDisplay(t *testing.T) {
 	if len(pageWithoutBacklinks.Backlinks) > 0 {
 		t.Error("Date page should not have backlinks")
 	}
-}
\ No newline at end of file
+}
+
+// TestEditsUpdateBacklinksIncrementally verifies that editing blocks keeps
+// backlinks exact without reparsing the directory
+func TestEditsUpdateBacklinksIncrementally(t *testing.T) {
+	tempDir := t.TempDir()
+	content := "# Notes\n\n- Read [[Book]]\n- Met [[Alice]]"
+	if err := os.WriteFile(filepath.Join(tempDir, "notes.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write page: %v", err)
+	}
+
+	app := NewApp()
+	if err := app.LoadDirectory(tempDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	if _, err := app.UpdateBlockAtPath("Notes", BlockPath{0}, "Read [[Paper]] and [[Paper]]"); err != nil {
+		t.Fatalf("UpdateBlockAtPath failed: %v", err)
+	}
+	if _, err := app.AddBlockAtPath("Notes", BlockPath{2}, "Called [[Alice]]"); err != nil {
+		t.Fatalf("AddBlockAtPath failed: %v", err)
+	}
+
+	if backlinks := app.GetBacklinks("Book"); len(backlinks) != 0 {
+		t.Errorf("Expected no backlinks to Book after edit, got %v", backlinks)
+	}
+	if blocks := app.GetBacklinks("Paper")["Notes"]; len(blocks) != 2 {
+		t.Errorf("Expected 2 references to Paper, got %v", blocks)
+	}
+	if blocks := app.GetBacklinks("Alice")["Notes"]; len(blocks) != 2 {
+		t.Errorf("Expected 2 references to Alice, got %v", blocks)
+	}
+}
@@ -0,0 +1,353 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// RenameResult describes a page rename: the pages it touches, for
+// confirmation beforehand, and what was done afterwards
+type RenameResult struct {
+	OldName string `json:"oldName"`
+	NewName string `json:"newName"` // Title of the page afterwards
+	Merged bool `json:"merged"` // The page is merged into an existing page
+	Pages []string `json:"pages"` // Other pages whose references are rewritten
+	References int `json:"references"` // References rewritten, including the page's own
+}
+
+// pageRename is a planned or committed rename and the file changes that
+// make it
+type pageRename struct {
+	result RenameResult
+	changes []fileChange
+}
+
+// PreviewRename returns what RenamePage would do without changing anything
+func (a *App) PreviewRename(oldName string, newName string) (*RenameResult, error) {
+	rename, err := a.planRename(oldName, newName, false)
+	if err != nil {
+		return nil, err
+	}
+	return &rename.result, nil
+}
+
+// RenamePage renames a page throughout the vault: its file is renamed and
+// every [[link]], #tag and page-ref property naming it is rewritten. A
+// rename that only changes case or spacing rewrites links to the new
+// spelling. Renaming to an existing page merges the page into it. With
+// keepAlias the old name stays as an alias, so links written elsewhere
+// still resolve. All files are changed or none are, and UndoRename puts
+// them back.
+func (a *App) RenamePage(oldName string, newName string, keepAlias bool) (*RenameResult, error) {
+	rename, err := a.planRename(oldName, newName, keepAlias)
+	if err != nil {
+		return nil, err
+	}
+	if err := commitChanges(rename.changes); err != nil {
+		return nil, fmt.Errorf("failed to rename page: %w", err)
+	}
+	a.reloadChanges(rename.changes)
+	a.lastRename = rename
+	return &rename.result, nil
+}
+
+// MergePages merges one page into another: the text below its header and
+// properties is appended to the target's file, references to it are
+// rewritten to point at the target, and its name and aliases become
+// aliases of the target. UndoRename reverts it.
+func (a *App) MergePages(sourceName string, targetName string) (*RenameResult, error) {
+	a.syncPages()
+	
+	source, found := a.findPage(sourceName)
+	if !found {
+		return nil, fmt.Errorf("page '%s' not found", sourceName)
+	}
+	target, found := a.findPage(targetName)
+	if !found {
+		return nil, fmt.Errorf("page '%s' not found", targetName)
+	}
+	if source == target {
+		return nil, fmt.Errorf("'%s' and '%s' are the same page", sourceName, targetName)
+	}
+	return a.RenamePage(source.Title, target.Title, true)
+}
+
+// UndoRename reverts the last rename, as long as none of the files it
+// changed have been edited since. Once one has, in the app or elsewhere,
+// the rename can't be undone: an error says so and it's forgotten.
+func (a *App) UndoRename() (*RenameResult, error) {
+	if a.lastRename == nil {
+		return nil, fmt.Errorf("no rename to undo")
+	}
+	inverse := invertChanges(a.lastRename.changes)
+	for _, change := range inverse {
+		if checkChange(change) != nil {
+			result := a.lastRename.result
+			a.lastRename = nil
+			return nil, fmt.Errorf("the rename of '%s' to '%s' can no longer be undone: %s has been edited since", result.OldName, result.NewName, filepath.Base(change.path))
+		}
+	}
+	if err := commitChanges(inverse); err != nil {
+		return nil, fmt.Errorf("failed to undo rename: %w", err)
+	}
+	a.reloadChanges(inverse)
+	
+	result := a.lastRename.result
+	a.lastRename = nil
+	return &result, nil
+}
+
+// planRename works out the file changes for a rename from the files as
+// they are on disk
+func (a *App) planRename(oldName string, newName string, keepAlias bool) (*pageRename, error) {
+	a.syncPages()
+	
+	page, found := a.findPage(oldName)
+	if !found {
+		return nil, fmt.Errorf("page '%s' not found", oldName)
+	}
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return nil, fmt.Errorf("new page name is empty")
+	}
+	if newName == page.Title {
+		return nil, fmt.Errorf("page is already named '%s'", newName)
+	}
+	if page.IsJournal {
+		return nil, fmt.Errorf("journal page '%s' can't be renamed", page.Title)
+	}
+	if _, err := a.journalFormat.ParseTitle(newName); err == nil {
+		return nil, fmt.Errorf("'%s' is a journal date", newName)
+	}
+	if page.Path == "" {
+		return nil, fmt.Errorf("page '%s' has no file", page.Title)
+	}
+	
+	oldKey := a.pageKey(page.Title)
+	isOld := func(name string) bool {
+		return a.pageKey(name) == oldKey
+	}
+	target, exists := a.findPage(newName)
+	merge := exists && target != page
+	if merge {
+		newName = target.Title
+	}
+	
+	rename := &pageRename{result: RenameResult{OldName: page.Title, NewName: newName, Merged: merge}}
+	
+	// The page itself, with its links to itself. Its file's text is edited
+	// in place; the parsed copy supplies the property values to write.
+	renamed, before, err := a.readPage(page.Path)
+	if err != nil {
+		return nil, err
+	}
+	parser.RenamePageReferences(renamed, isOld, newName)
+	text, encoding := parser.DecodeText(before)
+	text, count := parser.RenameReferences(text, isOld, newName)
+	rename.result.References += count
+	
+	if merge {
+		merged, targetBefore, err := a.readPage(target.Path)
+		if err != nil {
+			return nil, err
+		}
+		parser.RenamePageReferences(merged, isOld, newName)
+		properties := make(map[string]string, len(merged.Properties))
+		for key, value := range merged.Properties {
+			properties[key] = value
+		}
+		mergePageProperties(merged, renamed, a.pageKey)
+		if keepAlias {
+			parser.AddAlias(merged, page.Title, a.pageKey)
+		}
+		
+		// The page's text below its header and properties goes on the end
+		// of the target's file as written
+		targetText, targetEncoding := parser.DecodeText(targetBefore)
+		targetText, count := parser.RenameReferences(targetText, isOld, newName)
+		rename.result.References += count
+		if _, body := parser.SplitPageHead(text); body != "" {
+			targetText = strings.TrimRight(targetText, "\n") + "\n" + body
+			if !strings.HasSuffix(targetText, "\n") {
+				targetText += "\n"
+			}
+		}
+		for _, key := range merged.PropertyKeys() {
+			if merged.Properties[key] != properties[key] {
+				targetText = parser.SetPageProperty(targetText, key, merged.Properties[key])
+			}
+		}
+		rename.changes = append(rename.changes,
+			fileChange{path: page.Path, before: before},
+			fileChange{path: target.Path, before: targetBefore, after: targetEncoding.EncodeText(targetText)})
+	} else {
+		aliases := renamed.Properties["alias"]
+		parser.RemoveAlias(renamed, newName, a.pageKey)
+		if keepAlias && a.pageKey(newName) != oldKey {
+			parser.AddAlias(renamed, page.Title, a.pageKey)
+		}
+		text = parser.RenameHeader(text, isOld, newName)
+		if _, ok := renamed.Properties[parser.TitleProperty]; ok {
+			text = parser.SetPageProperty(text, parser.TitleProperty, newName)
+		}
+		if value := renamed.Properties["alias"]; value != aliases {
+			text = parser.SetPageProperty(text, "alias", value)
+		}
+		
+		newPath := filepath.Join(filepath.Dir(page.Path), a.fileNameFormat.Encode(newName))
+		content := encoding.EncodeText(text)
+		if newPath == page.Path {
+			rename.changes = append(rename.changes, fileChange{path: page.Path, before: before, after: content})
+		} else {
+			if err := a.checkRenameTarget(newPath, page.Path, newName); err != nil {
+				return nil, err
+			}
+			// The old file goes first, so a case-only rename on a
+			// case-insensitive file system doesn't remove the new one
+			rename.changes = append(rename.changes,
+				fileChange{path: page.Path, before: before},
+				fileChange{path: newPath, after: content})
+		}
+	}
+	
+	// Every other page referring to it
+	for _, source := range a.referringPages(page, isOld) {
+		if merge && source == target {
+			continue
+		}
+		sourceBefore, err := os.ReadFile(source.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read page: %w", err)
+		}
+		rewritten, count := renameReferencesIn(sourceBefore, isOld, newName)
+		if count == 0 {
+			continue
+		}
+		rename.result.References += count
+		rename.result.Pages = append(rename.result.Pages, source.Title)
+		rename.changes = append(rename.changes, fileChange{
+			path: source.Path,
+			before: sourceBefore,
+			after: rewritten,
+		})
+	}
+	sort.Strings(rename.result.Pages)
+	return rename, nil
+}
+
+// referringPages returns the loaded pages other than page that link to it,
+// found with the backlink index, or name it in a tag or page-ref property
+func (a *App) referringPages(page *parser.Page, isOld func(string) bool) []*parser.Page {
+	sources := make(map[*parser.Page]bool)
+	for sourceKey := range a.backlinks.GetBacklinks(page.Title) {
+		if source, ok := a.pages[sourceKey]; ok {
+			sources[source] = true
+		}
+	}
+	for _, source := range a.pages {
+		if !sources[source] && parser.TagsReference(source, isOld) {
+			sources[source] = true
+		}
+	}
+	
+	var pages []*parser.Page
+	for source := range sources {
+		if source != page && source.Path != "" {
+			pages = append(pages, source)
+		}
+	}
+	sort.Slice(pages, func(i, j int) bool {
+		return pages[i].Path < pages[j].Path
+	})
+	return pages
+}
+
+// renameReferencesIn rewrites the references to a page in a file's
+// content, leaving the rest of the file as it was written
+func renameReferencesIn(content []byte, isOld func(string) bool, newName string) ([]byte, int) {
+	text, encoding := parser.DecodeText(content)
+	text, count := parser.RenameReferences(text, isOld, newName)
+	return encoding.EncodeText(text), count
+}
+
+// readPage parses a page file afresh, returning the content it was read
+// from, so a change can be made without touching the loaded page
+func (a *App) readPage(filePath string) (*parser.Page, []byte, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read page: %w", err)
+	}
+	opts := a.parseOptions(false)
+	opts.Journal = a.journalsDir != "" && isWithin(filePath, a.journalsDir)
+	page, err := parser.ParsePageContent(filePath, content, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return page, content, nil
+}
+
+// checkRenameTarget returns an error if newPath is another page's file.
+// It may be the page's own file under a different case.
+func (a *App) checkRenameTarget(newPath string, oldPath string, newName string) error {
+	newInfo, err := os.Stat(newPath)
+	if err != nil {
+		return nil
+	}
+	if oldInfo, err := os.Stat(oldPath); err == nil && os.SameFile(oldInfo, newInfo) {
+		return nil
+	}
+	existing := filepath.Base(newPath)
+	if key, ok := a.fileKey(newPath); ok {
+		existing = key
+		if page, exists := a.pages[key]; exists {
+			existing = page.Title
+		}
+	}
+	return &parser.FileNameCollisionError{Title: newName, Path: newPath, ExistingTitle: existing}
+}
+
+// mergePageProperties adds the page properties of a page that another
+// doesn't have to it; aliases are combined
+func mergePageProperties(target *parser.Page, page *parser.Page, keyFunc func(string) string) {
+	for _, alias := range page.Aliases() {
+		if keyFunc(alias) != keyFunc(target.Title) {
+			parser.AddAlias(target, alias, keyFunc)
+		}
+	}
+	for _, key := range page.PropertyKeys() {
+		if _, exists := target.Properties[key]; !exists && key != parser.TitleProperty && key != "alias" {
+			if target.Properties == nil {
+				target.Properties = make(map[string]string)
+			}
+			target.Properties[key] = page.Properties[key]
+			target.PropertyOrder = append(target.PropertyOrder, key)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,359 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// setupRenameLibrary writes a library whose pages refer to "Project" in
+// every supported way, returning its directory and the files as written
+func setupRenameLibrary(t *testing.T) (string, map[string]string) {
+	t.Helper()
+	libDir := t.TempDir()
+	files := map[string]string{
+		"pages/Project.md":       "# Project\nalias:: proj\n\n- Links to itself: [[Project]]\n",
+		"pages/notes.md":         "# Notes\n\n- See [[Project]] and [the plan]([[project]])\n- Tagged #project and #[[Project]]\n- Via the alias [[proj]]\n",
+		"pages/meta.md":          "# Meta\ntags:: Project, Other\n\n- Unrelated\n",
+		"pages/Plans.md":         "# Plans\n\n- Existing plan, see [[Project]]\n",
+		"journals/2025_01_13.md": "- Worked on [[Project]]\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(libDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return libDir, files
+}
+
+// readLibrary returns the content of every page file, by path relative to
+// the library
+func readLibrary(t *testing.T, libDir string) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	for _, dir := range []string{"pages", "journals"} {
+		entries, err := os.ReadDir(filepath.Join(libDir, dir))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, entry := range entries {
+			content, err := os.ReadFile(filepath.Join(libDir, dir, entry.Name()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			files[dir+"/"+entry.Name()] = string(content)
+		}
+	}
+	return files
+}
+
+func TestRenamePageRewritesReferences(t *testing.T) {
+	libDir, original := setupRenameLibrary(t)
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	preview, err := app.PreviewRename("Project", "Venture Plan")
+	if err != nil {
+		t.Fatalf("PreviewRename failed: %v", err)
+	}
+	if !reflect.DeepEqual(readLibrary(t, libDir), original) {
+		t.Error("PreviewRename should not change any files")
+	}
+
+	result, err := app.RenamePage("Project", "Venture Plan", true)
+	if err != nil {
+		t.Fatalf("RenamePage failed: %v", err)
+	}
+	if !reflect.DeepEqual(result, preview) {
+		t.Errorf("RenamePage result %+v differs from preview %+v", result, preview)
+	}
+	wantPages := []string{"Jan 13th, 2025", "Meta", "Notes", "Plans"}
+	if result.NewName != "Venture Plan" || result.Merged || !reflect.DeepEqual(result.Pages, wantPages) || result.References != 8 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	files := readLibrary(t, libDir)
+	if _, exists := files["pages/Project.md"]; exists {
+		t.Error("Old file should be removed")
+	}
+	renamed := files["pages/Venture Plan.md"]
+	for _, want := range []string{"# Venture Plan", "alias:: proj, Project", "- Links to itself: [[Venture Plan]]"} {
+		if !strings.Contains(renamed, want) {
+			t.Errorf("Renamed page missing %q:\n%s", want, renamed)
+		}
+	}
+	notes := files["pages/notes.md"]
+	for _, want := range []string{"See [[Venture Plan]] and [the plan]([[Venture Plan]])", "Tagged #[[Venture Plan]] and #[[Venture Plan]]", "Via the alias [[proj]]"} {
+		if !strings.Contains(notes, want) {
+			t.Errorf("Notes missing %q:\n%s", want, notes)
+		}
+	}
+	if !strings.Contains(files["pages/meta.md"], "tags:: Venture Plan, Other") {
+		t.Errorf("Meta tags not rewritten:\n%s", files["pages/meta.md"])
+	}
+	if !strings.Contains(files["journals/2025_01_13.md"], "[[Venture Plan]]") {
+		t.Errorf("Journal not rewritten:\n%s", files["journals/2025_01_13.md"])
+	}
+
+	// The loaded pages follow, and the old name is an alias
+	data, err := app.GetPage("Project")
+	if err != nil || data.Title != "Venture Plan" {
+		t.Fatalf("GetPage(Project) = %+v, %v; want the renamed page", data, err)
+	}
+	if got := referencingPages(app, "Venture Plan"); !reflect.DeepEqual(got, []string{"2025-01-13", "notes", "plans"}) {
+		t.Errorf("Backlinks after rename = %v", got)
+	}
+
+	// Undo puts every file back as it was
+	undone, err := app.UndoRename()
+	if err != nil {
+		t.Fatalf("UndoRename failed: %v", err)
+	}
+	if undone.OldName != "Project" {
+		t.Errorf("UndoRename result = %+v", undone)
+	}
+	if got := readLibrary(t, libDir); !reflect.DeepEqual(got, original) {
+		t.Errorf("Files after undo = %v, want %v", got, original)
+	}
+	if data, err := app.GetPage("Project"); err != nil || data.Title != "Project" {
+		t.Errorf("GetPage(Project) after undo = %+v, %v", data, err)
+	}
+	if _, err := app.UndoRename(); err == nil {
+		t.Error("A rename should only be undone once")
+	}
+}
+
+// TestRenamePageKeepsFileText verifies a rename only rewrites references,
+// leaving text outside blocks, indentation and missing headers as written
+func TestRenamePageKeepsFileText(t *testing.T) {
+	libDir := t.TempDir()
+	files := map[string]string{
+		"pages/Project.md":       "Loose text before the header\n# Project\nstatus:: active\n\nIntro to [[Project]].\n- Block\n\t- tab child\n",
+		"pages/notes.md":         "# Notes\n\nIntro paragraph about things.\n- See [[Project]]\n\t- tab child\n\t\t- tab grandchild #Project\n\nClosing remark.\n",
+		"journals/2025_01_13.md": "- Worked on [[Project]]\n    - four-space child\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(libDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	if _, err := app.RenamePage("Project", "Venture", false); err != nil {
+		t.Fatalf("RenamePage failed: %v", err)
+	}
+
+	want := map[string]string{
+		"pages/Venture.md":       "Loose text before the header\n# Venture\nstatus:: active\n\nIntro to [[Venture]].\n- Block\n\t- tab child\n",
+		"pages/notes.md":         "# Notes\n\nIntro paragraph about things.\n- See [[Venture]]\n\t- tab child\n\t\t- tab grandchild #Venture\n\nClosing remark.\n",
+		"journals/2025_01_13.md": "- Worked on [[Venture]]\n    - four-space child\n",
+	}
+	if got := readLibrary(t, libDir); !reflect.DeepEqual(got, want) {
+		t.Errorf("Files after rename:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestRenamePageCaseOnly(t *testing.T) {
+	libDir, _ := setupRenameLibrary(t)
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	result, err := app.RenamePage("Project", "PROJECT", true)
+	if err != nil {
+		t.Fatalf("RenamePage failed: %v", err)
+	}
+	if result.Merged || result.NewName != "PROJECT" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	files := readLibrary(t, libDir)
+	renamed, exists := files["pages/PROJECT.md"]
+	if !exists {
+		t.Fatalf("Renamed file missing, got %v", files)
+	}
+	if strings.Contains(renamed, "alias:: proj, Project") {
+		t.Errorf("A case-only rename should not add an alias:\n%s", renamed)
+	}
+	if !strings.Contains(files["pages/notes.md"], "See [[PROJECT]] and [the plan]([[PROJECT]])") {
+		t.Errorf("Links should take the new spelling:\n%s", files["pages/notes.md"])
+	}
+	if data, err := app.GetPage("project"); err != nil || data.Title != "PROJECT" {
+		t.Errorf("GetPage after rename = %+v, %v", data, err)
+	}
+}
+
+func TestRenamePageMerge(t *testing.T) {
+	libDir, original := setupRenameLibrary(t)
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	result, err := app.RenamePage("Project", "plans", false)
+	if err != nil {
+		t.Fatalf("RenamePage failed: %v", err)
+	}
+	if !result.Merged || result.NewName != "Plans" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	files := readLibrary(t, libDir)
+	if _, exists := files["pages/Project.md"]; exists {
+		t.Error("Merged page's file should be removed")
+	}
+	plans := files["pages/Plans.md"]
+	for _, want := range []string{"alias:: proj", "- Existing plan, see [[Plans]]", "- Links to itself: [[Plans]]"} {
+		if !strings.Contains(plans, want) {
+			t.Errorf("Merged page missing %q:\n%s", want, plans)
+		}
+	}
+	if !strings.Contains(files["pages/notes.md"], "See [[Plans]]") {
+		t.Errorf("Links should point at the merged page:\n%s", files["pages/notes.md"])
+	}
+	data, err := app.GetPage("Plans")
+	if err != nil || len(data.Blocks) != 2 {
+		t.Errorf("GetPage(Plans) = %+v, %v; want both pages' blocks", data, err)
+	}
+	if data, err := app.GetPage("proj"); err != nil || data.Title != "Plans" {
+		t.Errorf("The merged page's alias should resolve to Plans, got %+v, %v", data, err)
+	}
+
+	if _, err := app.UndoRename(); err != nil {
+		t.Fatalf("UndoRename failed: %v", err)
+	}
+	if got := readLibrary(t, libDir); !reflect.DeepEqual(got, original) {
+		t.Errorf("Files after undo = %v, want %v", got, original)
+	}
+}
+
+func TestRenamePageRefused(t *testing.T) {
+	libDir, _ := setupRenameLibrary(t)
+	taken := filepath.Join(libDir, "pages", "Venture Plan.md")
+	if err := os.WriteFile(taken, []byte("title:: Something Else\n\n- Taken"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	var collision *parser.FileNameCollisionError
+	if _, err := app.RenamePage("Project", "Venture Plan", false); !errors.As(err, &collision) {
+		t.Errorf("Renaming onto another page's file: error = %v, want FileNameCollisionError", err)
+	}
+	for _, tt := range []struct{ oldName, newName string }{
+		{"Missing", "Anything"},
+		{"Project", "  "},
+		{"Project", "Project"},
+		{"Jan 13th, 2025", "Monday"},
+		{"Project", "2025-02-01"},
+	} {
+		if _, err := app.RenamePage(tt.oldName, tt.newName, false); err == nil {
+			t.Errorf("RenamePage(%q, %q) should fail", tt.oldName, tt.newName)
+		}
+	}
+	if _, exists := readLibrary(t, libDir)["pages/Project.md"]; !exists {
+		t.Error("A refused rename should not change files")
+	}
+}
+
+// TestUndoRenameAfterEdit verifies a rename isn't undone over later edits
+func TestUndoRenameAfterEdit(t *testing.T) {
+	libDir, _ := setupRenameLibrary(t)
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+	if _, err := app.RenamePage("Project", "Venture Plan", false); err != nil {
+		t.Fatalf("RenamePage failed: %v", err)
+	}
+	if err := app.UpdateBlock("Notes", "block-3", "Edited after the rename"); err != nil {
+		t.Fatalf("UpdateBlock failed: %v", err)
+	}
+
+	before := readLibrary(t, libDir)
+	_, err := app.UndoRename()
+	if err == nil || !strings.Contains(err.Error(), "can no longer be undone: notes.md has been edited since") {
+		t.Fatalf("UndoRename should say the undo has expired, got %v", err)
+	}
+	if got := readLibrary(t, libDir); !reflect.DeepEqual(got, before) {
+		t.Errorf("A refused undo should leave every file as it was")
+	}
+
+	// The expired rename is forgotten
+	if _, err := app.UndoRename(); err == nil || err.Error() != "no rename to undo" {
+		t.Errorf("Second UndoRename = %v, want no rename to undo", err)
+	}
+}
+
+func TestCommitChangesRollsBack(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.md")
+	second := filepath.Join(dir, "second.md")
+	if err := os.WriteFile(first, []byte("first"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(second, []byte("changed elsewhere"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := commitChanges([]fileChange{
+		{path: first, before: []byte("first"), after: nil},
+		{path: filepath.Join(dir, "new.md"), after: []byte("new")},
+		{path: second, before: []byte("second"), after: []byte("rewritten")},
+	})
+	if err == nil {
+		t.Fatal("commitChanges should fail when a file has changed")
+	}
+
+	for path, want := range map[string]string{first: "first", second: "changed elsewhere"} {
+		if content, err := os.ReadFile(path); err != nil || string(content) != want {
+			t.Errorf("%s = %q, %v; want %q", filepath.Base(path), content, err, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "new.md")); err == nil {
+		t.Error("New file should be removed on rollback")
+	}
+}
@@ -0,0 +1,253 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// trashDir holds deleted pages, relative to the library root. It's
+// seq2b's own, as Logseq's logseq/.recycle has a different layout, and is
+// never scanned for pages.
+const trashDir = ".seq2b/trash"
+
+// TrashEntry is a deleted page kept in the library's trash. The page's
+// file is stored as <ID>.md next to this metadata in <ID>.json.
+type TrashEntry struct {
+	ID string `json:"id"`
+	Title string `json:"title"`
+	Path string `json:"path"` // Where the file was, relative to the library root
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+// trashPath returns the trash directory of the loaded library
+func (a *App) trashPath() string {
+	return filepath.Join(a.currentDir, filepath.FromSlash(trashDir))
+}
+
+// DeletePage moves a page's file into the trash. Links to the page are
+// left in place and become links to a page without a file, as if the file
+// had been removed by hand; RestorePage brings it back.
+func (a *App) DeletePage(pageName string) (*TrashEntry, error) {
+	a.syncPages()
+	
+	page, found := a.findPage(pageName)
+	if !found {
+		return nil, fmt.Errorf("page '%s' not found", pageName)
+	}
+	if page.Path == "" {
+		return nil, fmt.Errorf("page '%s' has no file", page.Title)
+	}
+	relPath, err := filepath.Rel(a.currentDir, page.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete page: %w", err)
+	}
+	
+	dir := a.trashPath()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create trash: %w", err)
+	}
+	entry := TrashEntry{
+		ID: a.newTrashID(dir),
+		Title: page.Title,
+		Path: filepath.ToSlash(relPath),
+		DeletedAt: a.now(),
+	}
+	
+	// Metadata first, so a page file in the trash always has it
+	metadata, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	metadataPath := filepath.Join(dir, entry.ID + ".json")
+	if err := writeFileAtomic(metadataPath, metadata, 0644); err != nil {
+		return nil, fmt.Errorf("failed to delete page: %w", err)
+	}
+	if err := os.Rename(page.Path, filepath.Join(dir, entry.ID + ".md")); err != nil {
+		os.Remove(metadataPath)
+		return nil, fmt.Errorf("failed to delete page: %w", err)
+	}
+	
+	if state, ok := a.recordedFile(page.Path); ok {
+		a.removeFilePage(page.Path, state.key)
+	}
+	a.unlinked = nil
+	a.namespaces = nil
+	a.linkGraph = nil
+	return &entry, nil
+}
+
+// newTrashID names a trash entry after the time it was deleted
+func (a *App) newTrashID(dir string) string {
+	deleted := a.now().UTC()
+	for {
+		id := deleted.Format("20060102T150405.000000000Z")
+		if _, err := os.Stat(filepath.Join(dir, id + ".json")); os.IsNotExist(err) {
+			return id
+		}
+		deleted = deleted.Add(time.Nanosecond)
+	}
+}
+
+// GetTrash returns the deleted pages, most recently deleted first
+func (a *App) GetTrash() ([]TrashEntry, error) {
+	entries := []TrashEntry{}
+	files, err := os.ReadDir(a.trashPath())
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trash: %w", err)
+	}
+	
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		entry, err := a.readTrashEntry(strings.TrimSuffix(file.Name(), ".json"))
+		if err != nil {
+			fmt.Printf("Warning: %v\n", err)
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].DeletedAt.After(entries[j].DeletedAt)
+	})
+	return entries, nil
+}
+
+// readTrashEntry reads the metadata of a trash entry
+func (a *App) readTrashEntry(id string) (*TrashEntry, error) {
+	if id == "" || filepath.Base(id) != id || strings.HasPrefix(id, ".") {
+		return nil, fmt.Errorf("invalid trash entry '%s'", id)
+	}
+	metadata, err := os.ReadFile(filepath.Join(a.trashPath(), id + ".json"))
+	if err != nil {
+		return nil, fmt.Errorf("trash entry '%s' not found", id)
+	}
+	var entry TrashEntry
+	if err := json.Unmarshal(metadata, &entry); err != nil {
+		return nil, fmt.Errorf("invalid trash entry '%s': %w", id, err)
+	}
+	entry.ID = id
+	return &entry, nil
+}
+
+// RestorePage moves a deleted page back to where it was. It fails if a
+// file has been created there, or a page with the same name added, since.
+func (a *App) RestorePage(id string) (*TrashEntry, error) {
+	a.syncPages()
+	
+	entry, err := a.readTrashEntry(id)
+	if err != nil {
+		return nil, err
+	}
+	filePath := filepath.Join(a.currentDir, filepath.FromSlash(entry.Path))
+	if !isWithin(filePath, a.currentDir) {
+		return nil, fmt.Errorf("invalid trash entry '%s'", id)
+	}
+	if _, err := os.Stat(filePath); err == nil {
+		return nil, fmt.Errorf("cannot restore '%s': %s already exists", entry.Title, entry.Path)
+	}
+	if existing, exists := a.pages[a.pageKey(entry.Title)]; exists {
+		return nil, fmt.Errorf("cannot restore '%s': page '%s' already exists", entry.Title, existing.Title)
+	}
+	
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to restore page: %w", err)
+	}
+	if err := os.Rename(filepath.Join(a.trashPath(), id + ".md"), filePath); err != nil {
+		return nil, fmt.Errorf("failed to restore page: %w", err)
+	}
+	os.Remove(filepath.Join(a.trashPath(), id + ".json"))
+	
+	a.applyFileChanges([]string{filePath})
+	return entry, nil
+}
+
+// purgeExpiredTrash permanently deletes the pages kept in the trash for
+// longer than the vault's trash-retention-days. Without it pages are kept
+// until they're purged by hand.
+func (a *App) purgeExpiredTrash() {
+	if a.trashRetention <= 0 {
+		return
+	}
+	purged, err := a.purgeTrashBefore(a.now().Add(-a.trashRetention))
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+	if purged > 0 {
+		fmt.Printf("Purged %d pages from the trash\n", purged)
+	}
+}
+
+// PurgeTrashEntry permanently deletes a page from the trash
+func (a *App) PurgeTrashEntry(id string) error {
+	if _, err := a.readTrashEntry(id); err != nil {
+		return err
+	}
+	return a.removeTrashEntry(id)
+}
+
+// PurgeTrash permanently deletes the pages deleted more than olderThanDays
+// days ago, or every page in the trash for 0, and returns how many were
+// removed
+func (a *App) PurgeTrash(olderThanDays int) (int, error) {
+	return a.purgeTrashBefore(a.now().Add(-time.Duration(olderThanDays) * 24 * time.Hour))
+}
+
+// purgeTrashBefore permanently deletes the pages deleted before cutoff
+func (a *App) purgeTrashBefore(cutoff time.Time) (int, error) {
+	entries, err := a.GetTrash()
+	if err != nil {
+		return 0, err
+	}
+	purged := 0
+	for _, entry := range entries {
+		if entry.DeletedAt.After(cutoff) {
+			continue
+		}
+		if err := a.removeTrashEntry(entry.ID); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// removeTrashEntry deletes a trash entry's page file and metadata
+func (a *App) removeTrashEntry(id string) error {
+	for _, ext := range []string{".md", ".json"} {
+		if err := os.Remove(filepath.Join(a.trashPath(), id + ext)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to purge trash entry '%s': %w", id, err)
+		}
+	}
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,276 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// loadTrashLibrary loads the rename test library with a fixed clock that
+// the returned function advances
+func loadTrashLibrary(t *testing.T) (*App, string, map[string]string, func(time.Duration)) {
+	t.Helper()
+	libDir, original := setupRenameLibrary(t)
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	app := NewApp()
+	app.now = func() time.Time { return now }
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+	return app, libDir, original, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestDeleteAndRestorePage(t *testing.T) {
+	app, libDir, original, _ := loadTrashLibrary(t)
+
+	entry, err := app.DeletePage("project")
+	if err != nil {
+		t.Fatalf("DeletePage failed: %v", err)
+	}
+	if entry.Title != "Project" || entry.Path != "pages/Project.md" {
+		t.Errorf("Unexpected trash entry %+v", entry)
+	}
+	if _, err := os.Stat(filepath.Join(libDir, "pages", "Project.md")); err == nil {
+		t.Error("Deleted page's file should be moved")
+	}
+	for _, ext := range []string{".md", ".json"} {
+		if _, err := os.Stat(filepath.Join(libDir, ".seq2b", "trash", entry.ID+ext)); err != nil {
+			t.Errorf("Trash entry missing %s: %v", ext, err)
+		}
+	}
+	for _, title := range app.GetPageList() {
+		if title == "Project" {
+			t.Error("Deleted page should not be listed")
+		}
+	}
+
+	// Links to the page stay, as they would after reloading the library
+	reloaded := NewApp()
+	reloaded.now = app.now
+	if err := reloaded.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to reload: %v", err)
+	}
+	if got, want := referencingPages(app, "Project"), referencingPages(reloaded, "Project"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Backlinks after delete = %v, after reload = %v", got, want)
+	}
+	if _, found := reloaded.findPage("Project"); found {
+		t.Error("The trash should not be scanned for pages")
+	}
+
+	trash, err := app.GetTrash()
+	if err != nil || len(trash) != 1 || trash[0] != *entry {
+		t.Fatalf("GetTrash = %v, %v", trash, err)
+	}
+
+	if _, err := app.RestorePage(entry.ID); err != nil {
+		t.Fatalf("RestorePage failed: %v", err)
+	}
+	if got := readLibrary(t, libDir); !reflect.DeepEqual(got, original) {
+		t.Errorf("Files after restore = %v, want %v", got, original)
+	}
+	if data, err := app.GetPage("proj"); err != nil || data.Title != "Project" {
+		t.Errorf("Restored page should be loaded with its aliases, got %+v, %v", data, err)
+	}
+	if trash, _ := app.GetTrash(); len(trash) != 0 {
+		t.Errorf("Trash after restore = %v", trash)
+	}
+}
+
+// TestRestorePageRefused verifies a restore never replaces a newer page
+func TestRestorePageRefused(t *testing.T) {
+	app, libDir, _, _ := loadTrashLibrary(t)
+	entry, err := app.DeletePage("Project")
+	if err != nil {
+		t.Fatalf("DeletePage failed: %v", err)
+	}
+
+	// Following a dangling link creates a new page in its place
+	if _, err := app.GetPage("Project"); err != nil {
+		t.Fatalf("GetPage failed: %v", err)
+	}
+	if _, err := app.RestorePage(entry.ID); err == nil {
+		t.Error("RestorePage should refuse to replace the new page")
+	}
+	if trash, _ := app.GetTrash(); len(trash) != 1 {
+		t.Errorf("A refused restore should keep the trash entry, got %v", trash)
+	}
+
+	for _, id := range []string{"", "../pages/notes", "missing"} {
+		if _, err := app.RestorePage(id); err == nil {
+			t.Errorf("RestorePage(%q) should fail", id)
+		}
+		if err := app.PurgeTrashEntry(id); err == nil {
+			t.Errorf("PurgeTrashEntry(%q) should fail", id)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(libDir, "pages", "notes.md")); err != nil {
+		t.Errorf("Invalid IDs must not touch other files: %v", err)
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	app, libDir, _, advance := loadTrashLibrary(t)
+	first, err := app.DeletePage("Meta")
+	if err != nil {
+		t.Fatalf("DeletePage failed: %v", err)
+	}
+	advance(20 * 24 * time.Hour)
+	if _, err := app.DeletePage("Notes"); err != nil {
+		t.Fatalf("DeletePage failed: %v", err)
+	}
+	advance(24 * time.Hour)
+
+	if purged, err := app.PurgeTrash(10); err != nil || purged != 1 {
+		t.Errorf("PurgeTrash(10) = %d, %v; want the older page", purged, err)
+	}
+	trash, _ := app.GetTrash()
+	if len(trash) != 1 || trash[0].Title != "Notes" {
+		t.Errorf("Trash after purge = %v", trash)
+	}
+	if _, err := os.Stat(filepath.Join(libDir, ".seq2b", "trash", first.ID+".md")); err == nil {
+		t.Error("Purged page file should be removed")
+	}
+
+	// Pages are only purged on load once a retention period is configured
+	advance(60 * 24 * time.Hour)
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to reload: %v", err)
+	}
+	if trash, _ := app.GetTrash(); len(trash) != 1 {
+		t.Errorf("Pages should be kept without trash-retention-days, got %v", trash)
+	}
+	if err := os.WriteFile(filepath.Join(libDir, "seq2b.yaml"), []byte("trash-retention-days: 30\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to reload: %v", err)
+	}
+	if trash, _ := app.GetTrash(); len(trash) != 0 {
+		t.Errorf("Expired pages should be purged on load, got %v", trash)
+	}
+}
+
+func TestPurgeTrashEntry(t *testing.T) {
+	app, _, _, _ := loadTrashLibrary(t)
+	entry, err := app.DeletePage("Meta")
+	if err != nil {
+		t.Fatalf("DeletePage failed: %v", err)
+	}
+	if _, err := app.DeletePage("Notes"); err != nil {
+		t.Fatalf("DeletePage failed: %v", err)
+	}
+
+	if err := app.PurgeTrashEntry(entry.ID); err != nil {
+		t.Fatalf("PurgeTrashEntry failed: %v", err)
+	}
+	if trash, _ := app.GetTrash(); len(trash) != 1 || trash[0].Title != "Notes" {
+		t.Errorf("Trash after purging one entry = %v", trash)
+	}
+	if purged, err := app.PurgeTrash(0); err != nil || purged != 1 {
+		t.Errorf("PurgeTrash(0) = %d, %v; want everything", purged, err)
+	}
+}
+
+func TestMergePages(t *testing.T) {
+	app, libDir, original, _ := loadTrashLibrary(t)
+
+	result, err := app.MergePages("proj", "Plans")
+	if err != nil {
+		t.Fatalf("MergePages failed: %v", err)
+	}
+	if !result.Merged || result.OldName != "Project" || result.NewName != "Plans" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	plans, _ := os.ReadFile(filepath.Join(libDir, "pages", "Plans.md"))
+	if !strings.Contains(string(plans), "alias:: proj, Project") {
+		t.Errorf("Merged page should keep the source's name and aliases:\n%s", plans)
+	}
+	if data, err := app.GetPage("Project"); err != nil || data.Title != "Plans" || len(data.Blocks) != 2 {
+		t.Errorf("GetPage(Project) = %+v, %v; want the merged page", data, err)
+	}
+	if got := referencingPages(app, "Plans"); !reflect.DeepEqual(got, []string{"2025-01-13", "notes"}) {
+		t.Errorf("Backlinks after merge = %v", got)
+	}
+
+	if _, err := app.MergePages("Plans", "proj"); err == nil {
+		t.Error("Merging a page into itself should fail")
+	}
+	if _, err := app.MergePages("Plans", "Missing"); err == nil {
+		t.Error("Merging into a missing page should fail")
+	}
+
+	if _, err := app.UndoRename(); err != nil {
+		t.Fatalf("UndoRename failed: %v", err)
+	}
+	if got := readLibrary(t, libDir); !reflect.DeepEqual(got, original) {
+		t.Errorf("Files after undo = %v, want %v", got, original)
+	}
+}
+
+// TestMergePagesKeepsFileText verifies a merge appends the page's text to
+// the target's file as written and only rewrites references elsewhere
+func TestMergePagesKeepsFileText(t *testing.T) {
+	libDir := t.TempDir()
+	files := map[string]string{
+		"pages/Draft.md":         "# Draft\r\nstatus:: rough\r\n\r\nWhy this page exists.\r\n- Idea\r\n\t- tab child with [[Draft]]\r\n",
+		"pages/Plans.md":         "# Plans\ntags:: work\n\nOverview of [[Draft]] and the rest.\n- Existing plan\n    - four-space child\n",
+		"pages/notes.md":         "Loose note.\n- About [[Draft]]\n\t- tab block\n",
+		"journals/2025_01_13.md": "- Wrote [[Draft]]\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(libDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	if _, err := app.MergePages("Draft", "Plans"); err != nil {
+		t.Fatalf("MergePages failed: %v", err)
+	}
+
+	want := map[string]string{
+		"pages/Plans.md": "# Plans\ntags:: work\nstatus:: rough\nalias:: Draft\n\nOverview of [[Plans]] and the rest.\n- Existing plan\n    - four-space child\n" +
+			"Why this page exists.\n- Idea\n\t- tab child with [[Plans]]\n",
+		"pages/notes.md":         "Loose note.\n- About [[Plans]]\n\t- tab block\n",
+		"journals/2025_01_13.md": "- Wrote [[Plans]]\n",
+	}
+	if got := readLibrary(t, libDir); !reflect.DeepEqual(got, want) {
+		t.Errorf("Files after merge:\n%q\nwant:\n%q", got, want)
+	}
+	data, err := app.GetPage("Draft")
+	if err != nil || data.Title != "Plans" {
+		t.Errorf("GetPage(Draft) = %+v, %v; want the merged page", data, err)
+	}
+}
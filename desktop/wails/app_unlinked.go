@@ -0,0 +1,134 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// UnlinkedReferenceData is a plain-text mention of a page for the frontend
+type UnlinkedReferenceData struct {
+	SourcePage string `json:"sourcePage"`
+	BlockID string `json:"blockId"`
+	Content string `json:"content"` // Full content of the mentioning block
+	Text string `json:"text"` // Mentioned text as written
+	Start int `json:"start"` // Byte offsets of the mention in Content
+	End int `json:"end"`
+}
+
+// PageReferences lists the linked and unlinked references to a page
+type PageReferences struct {
+	Page string `json:"page"`
+	Linked []BacklinkData `json:"linked"`
+	Unlinked []UnlinkedReferenceData `json:"unlinked"`
+}
+
+// unlinkedIndex returns the unlinked reference index, building it if the
+// pages have been reloaded since it was last used
+func (a *App) unlinkedIndex() *parser.UnlinkedIndex {
+	if a.unlinked == nil {
+		a.unlinked = parser.BuildUnlinkedIndex(a.pages, a.backlinks.Key)
+	}
+	return a.unlinked
+}
+
+// GetReferences returns the linked backlinks and unlinked mentions of a page
+func (a *App) GetReferences(pageName string) (*PageReferences, error) {
+	a.syncPages()
+	
+	page, found := a.findPage(pageName)
+	if !found {
+		return nil, fmt.Errorf("page '%s' not found", pageName)
+	}
+	
+	refs := &PageReferences{
+		Page: page.Title,
+		Linked: a.convertBacklinks(a.backlinks.GetBacklinks(page.Title), false),
+		Unlinked: []UnlinkedReferenceData{},
+	}
+	
+	for _, ref := range a.unlinkedIndex().GetUnlinkedReferences(page.Title) {
+		source, found := a.findPage(ref.PageName)
+		if !found {
+			continue
+		}
+		block := findBlockByID(source.Blocks, ref.BlockID)
+		if block == nil {
+			continue
+		}
+		refs.Unlinked = append(refs.Unlinked, UnlinkedReferenceData{
+			SourcePage: ref.PageName,
+			BlockID: ref.BlockID,
+			Content: block.Content,
+			Text: ref.Text,
+			Start: ref.Start,
+			End: ref.End,
+		})
+	}
+	
+	return refs, nil
+}
+
+// LinkUnlinkedReference turns the mention of targetPage starting at byte
+// offset start of a block into a [[link]] and saves the source page. It
+// returns the target's references as they stand after the change.
+func (a *App) LinkUnlinkedReference(sourcePage string, blockID string, start int, targetPage string) (*PageReferences, error) {
+	a.syncPages()
+	
+	source, found := a.findPage(sourcePage)
+	if !found {
+		return nil, fmt.Errorf("page '%s' not found", sourcePage)
+	}
+	block := findBlockByID(source.Blocks, blockID)
+	if block == nil {
+		return nil, fmt.Errorf("block '%s' not found in page '%s'", blockID, sourcePage)
+	}
+	
+	// Re-find the mention so a stale offset can't corrupt the block
+	targetKey := a.pageKey(targetPage)
+	for _, mention := range a.unlinkedIndex().FindMentions(block.Content) {
+		if mention.Start != start || a.pageKey(mention.Target) != targetKey {
+			continue
+		}
+		
+		newContent := block.Content[:mention.Start] + a.mentionLink(mention) + block.Content[mention.End:]
+		if err := a.UpdateBlock(source.Title, blockID, newContent); err != nil {
+			return nil, err
+		}
+		return a.GetReferences(targetPage)
+	}
+	
+	return nil, fmt.Errorf("no mention of '%s' at offset %d in block '%s'", targetPage, start, blockID)
+}
+
+// mentionLink returns the link replacing a mention, keeping the text as it
+// was written: [[text]] when the text names the page itself, by any
+// spelling or alias, otherwise [text]([[Page]])
+func (a *App) mentionLink(mention parser.Mention) string {
+	if a.backlinks != nil && a.backlinks.Key(mention.Text) == a.backlinks.Key(mention.Target) {
+		return "[[" + mention.Text + "]]"
+	}
+	return "[" + mention.Text + "]([[" + mention.Target + "]])"
+}
\ No newline at end of file
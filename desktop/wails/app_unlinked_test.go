@@ -0,0 +1,192 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// setupMentionLibrary writes pages that mention each other without links
+func setupMentionLibrary(t *testing.T) string {
+	t.Helper()
+	tempDir, err := os.MkdirTemp("", "seq2b-unlinked-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(tempDir) })
+
+	files := map[string]string{
+		"parser.md": "# Parser\nalias:: Markdown Parser\ntags:: core\n\n- Turns markdown into blocks",
+		"notes.md":  "# Notes\n\n- Rewrote the parser today\n- Linked [[Parser]] here\n- The markdown parser is fast",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return tempDir
+}
+
+// TestGetReferences verifies linked and unlinked references are returned together
+func TestGetReferences(t *testing.T) {
+	app := NewApp()
+	if err := app.LoadDirectory(setupMentionLibrary(t)); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	refs, err := app.GetReferences("parser")
+	if err != nil {
+		t.Fatalf("GetReferences failed: %v", err)
+	}
+
+	if len(refs.Linked) != 1 || refs.Linked[0].SourcePage != "Notes" || refs.Linked[0].Count != 1 {
+		t.Errorf("Unexpected linked references: %+v", refs.Linked)
+	}
+	if len(refs.Unlinked) != 2 {
+		t.Fatalf("Expected 2 unlinked references, got %+v", refs.Unlinked)
+	}
+
+	first := refs.Unlinked[0]
+	if first.SourcePage != "Notes" || first.Text != "parser" || first.Content[first.Start:first.End] != "parser" {
+		t.Errorf("Unexpected first mention: %+v", first)
+	}
+	// The alias is the longer match in the third block
+	if second := refs.Unlinked[1]; second.Text != "markdown parser" {
+		t.Errorf("Expected alias mention, got %+v", second)
+	}
+
+	if _, err := app.GetReferences("Missing Page"); err == nil {
+		t.Error("Expected error for missing page")
+	}
+}
+
+// TestLinkUnlinkedReference verifies a mention becomes a link on disk
+func TestLinkUnlinkedReference(t *testing.T) {
+	libDir := setupMentionLibrary(t)
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	refs, err := app.GetReferences("Parser")
+	if err != nil {
+		t.Fatalf("GetReferences failed: %v", err)
+	}
+	mention := refs.Unlinked[0]
+
+	refs, err = app.LinkUnlinkedReference(mention.SourcePage, mention.BlockID, mention.Start, "Parser")
+	if err != nil {
+		t.Fatalf("LinkUnlinkedReference failed: %v", err)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(libDir, "notes.md"))
+	if !strings.Contains(string(content), "- Rewrote the [[parser]] today") {
+		t.Errorf("Mention not linked on disk:\n%s", content)
+	}
+	if len(refs.Unlinked) != 1 {
+		t.Errorf("Expected 1 remaining unlinked reference, got %+v", refs.Unlinked)
+	}
+	if len(refs.Linked) != 1 || refs.Linked[0].Count != 2 {
+		t.Errorf("Expected 2 linked references from Notes, got %+v", refs.Linked)
+	}
+
+	// The same offset no longer holds an unlinked mention
+	if _, err := app.LinkUnlinkedReference(mention.SourcePage, mention.BlockID, mention.Start, "Parser"); err == nil {
+		t.Error("Expected error when linking a stale mention")
+	}
+
+	// A mention of an alias links the alias as written
+	alias := refs.Unlinked[0]
+	if _, err := app.LinkUnlinkedReference(alias.SourcePage, alias.BlockID, alias.Start, "Parser"); err != nil {
+		t.Fatalf("LinkUnlinkedReference failed: %v", err)
+	}
+	content, _ = os.ReadFile(filepath.Join(libDir, "notes.md"))
+	if !strings.Contains(string(content), "- The [[markdown parser]] is fast") {
+		t.Errorf("Alias mention not linked as written:\n%s", content)
+	}
+}
+
+// TestMentionLink verifies text that doesn't name the page itself is kept
+// as the label of a link
+func TestMentionLink(t *testing.T) {
+	app := NewApp()
+	if err := app.LoadDirectory(setupMentionLibrary(t)); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+	tests := map[string]string{
+		"PARSER":          "[[PARSER]]",
+		"Markdown Parser": "[[Markdown Parser]]",
+		"parsers":         "[parsers]([[Parser]])",
+	}
+	for text, want := range tests {
+		if got := app.mentionLink(parser.Mention{Target: "Parser", Text: text}); got != want {
+			t.Errorf("mentionLink(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+// TestSavePageKeepsPageProperties verifies saving doesn't drop alias:: etc.
+func TestSavePageKeepsPageProperties(t *testing.T) {
+	libDir := setupMentionLibrary(t)
+	app := NewApp()
+	if err := app.LoadDirectory(libDir); err != nil {
+		t.Fatalf("Failed to load directory: %v", err)
+	}
+
+	if err := app.UpdateBlock("Parser", "block-1", "Turns markdown into a block tree"); err != nil {
+		t.Fatalf("UpdateBlock failed: %v", err)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(libDir, "parser.md"))
+	if !strings.HasPrefix(string(content), "# Parser\nalias:: Markdown Parser\ntags:: core\n") {
+		t.Errorf("Page properties not preserved in order:\n%s", content)
+	}
+}
+
+// TestSavePageWritesHeaderOnlyIfPresent verifies saving doesn't add a
+// "# Title" line to pages written without one
+func TestSavePageWritesHeaderOnlyIfPresent(t *testing.T) {
+	app, pagesDir := loadWindowsVault(t, map[string][]byte{
+		"Plain.md":      []byte("- One\n"),
+		"Tagged.md":     []byte("tags:: core\n\n- One\n"),
+		"Subheading.md": []byte("## Subheading\n\n- One\n"),
+	})
+
+	for name, want := range map[string]string{
+		"Plain":      "- Edited\n",
+		"Tagged":     "tags:: core\n\n- Edited\n",
+		"Subheading": "## Subheading\n\n- Edited\n",
+	} {
+		if _, err := app.UpdateBlockAtPath(name, BlockPath{0}, "Edited"); err != nil {
+			t.Fatalf("UpdateBlockAtPath(%s) failed: %v", name, err)
+		}
+		if got := readPageFile(t, filepath.Join(pagesDir, name+".md")); got != want {
+			t.Errorf("Saved %s = %q, want %q", name, got, want)
+		}
+	}
+}
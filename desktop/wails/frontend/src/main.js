@@ -1,5 +1,6 @@
 import './style.css';
-import { GetPage, GetPageList, UpdateBlock, AddBlock, UpdateBlockAtPath, AddBlockAtPath, IsTestMode, CaptureDOM, LogUserAction, CaptureNavigationHistory, GetAsset, LogResourceError } from '../wailsjs/go/main/App';
+import { EventsOn } from '../wailsjs/runtime/runtime';
+import { GetPage, GetPageList, DeletePage, GetTrash, RestorePage, GetPageHistory, DiffPageVersions, RestorePageVersion, PreviewRename, RenamePage, UndoRename, ResolveConflict, UpdateBlock, AddBlock, UpdateBlockAtPath, AddBlockAtPath, IsTestMode, CaptureDOM, LogUserAction, CaptureNavigationHistory, GetAsset, LogResourceError } from '../wailsjs/go/main/App';
 
 // Application state
 let currentPage = getTodayPageName();
@@ -156,6 +157,169 @@ function setupEventListeners() {
     if (homeButton) {
         homeButton.addEventListener('click', goToToday);
     }
+    
+    // Reload the page when files are changed outside the app, unless a
+    // block is being edited
+    EventsOn('pages:changed', () => {
+        if (!document.querySelector('.block-text.editing')) {
+            loadPage(currentPage);
+        }
+    });
+    
+    EventsOn('page:conflict', resolveConflict);
+    
+    // Double-click the title to rename the page
+    pageTitle.addEventListener('dblclick', renameCurrentPage);
+    
+    document.getElementById('deleteButton').addEventListener('click', deleteCurrentPage);
+    document.getElementById('trashButton').addEventListener('click', restoreFromTrash);
+    document.getElementById('historyButton').addEventListener('click', showPageHistory);
+}
+
+// Move the current page to the trash and go back
+async function deleteCurrentPage() {
+    const title = pageTitle.textContent;
+    if (!confirm(`Move "${title}" to the trash? Links to it are kept.`)) {
+        return;
+    }
+    try {
+        await DeletePage(currentPage);
+    } catch (err) {
+        alert(`Failed to delete page: ${err}`);
+        return;
+    }
+    // Don't go back to the deleted page, which would recreate it
+    const deleted = currentPage.toLowerCase();
+    navigationHistory = navigationHistory.filter(name => name.toLowerCase() !== deleted);
+    currentPage = navigationHistory.pop() || getTodayPageName();
+    backButton.disabled = navigationHistory.length === 0;
+    loadPage(currentPage);
+}
+
+// List the trash and restore the page picked by number
+async function restoreFromTrash() {
+    try {
+        const entries = await GetTrash();
+        if (entries.length === 0) {
+            alert('The trash is empty.');
+            return;
+        }
+        const list = entries.slice(0, 20).map((entry, i) =>
+            `${i + 1}. ${entry.title} (deleted ${new Date(entry.deletedAt).toLocaleString()})`);
+        const choice = prompt(`Restore which page?\n\n${list.join('\n')}`, '1');
+        const entry = entries[parseInt(choice, 10) - 1];
+        if (!entry) {
+            return;
+        }
+        const restored = await RestorePage(entry.id);
+        loadPage(restored.title);
+    } catch (err) {
+        alert(`Failed to restore page: ${err}`);
+    }
+}
+
+// Pick an earlier version of the current page, show how it differs from the
+// page now and offer to restore it
+async function showPageHistory() {
+    try {
+        const versions = await GetPageHistory(currentPage);
+        if (versions.length === 0) {
+            alert('This page has no saved versions yet.');
+            return;
+        }
+        const list = versions.slice(0, 20).map((version, i) =>
+            `${i + 1}. ${new Date(version.savedAt).toLocaleString()}${version.current ? ' (current)' : ''}`);
+        const choice = prompt(`Compare which version with the page now?\n\n${list.join('\n')}`, '1');
+        const version = versions[parseInt(choice, 10) - 1];
+        if (!version) {
+            return;
+        }
+        
+        const diff = await DiffPageVersions(currentPage, version.hash, '');
+        const changes = [
+            ...(diff.Properties || []).map(change => `property ${change.Key}: ${change.Old || '(none)'} → ${change.New || '(none)'}`),
+            ...(diff.Blocks || []).map(change => {
+                const content = change.Type === 'added' ? change.NewContent : change.OldContent;
+                const edit = change.Type === 'edited' ? ` → ${change.NewContent}` : '';
+                return `${change.Type}${change.Moved ? ' and moved' : ''}: ${content}${edit}`;
+            }),
+        ];
+        if (changes.length === 0) {
+            alert('This version is the same as the page now.');
+            return;
+        }
+        if (!confirm(`Changes since this version:\n\n${changes.slice(0, 30).join('\n')}\n\nRestore this version?`)) {
+            return;
+        }
+        await RestorePageVersion(currentPage, version.hash);
+        loadPage(currentPage);
+    } catch (err) {
+        alert(`Failed to show page history: ${err}`);
+    }
+}
+
+// Rename the current page after confirming the pages it changes
+async function renameCurrentPage() {
+    const newName = prompt('Rename page to:', pageTitle.textContent);
+    if (!newName || newName.trim() === pageTitle.textContent) {
+        return;
+    }
+    try {
+        const preview = await PreviewRename(currentPage, newName);
+        let message = preview.merged
+            ? `Merge "${preview.oldName}" into the existing page "${preview.newName}"?`
+            : `Rename "${preview.oldName}" to "${preview.newName}"?`;
+        if (preview.pages && preview.pages.length > 0) {
+            message += `\n\nReferences will be updated in ${preview.pages.length} page(s):\n` +
+                preview.pages.slice(0, 20).join('\n');
+        }
+        if (!confirm(message)) {
+            return;
+        }
+        const keepAlias = confirm(`Keep "${preview.oldName}" as an alias?`);
+        const result = await RenamePage(currentPage, newName, keepAlias);
+        showRenamed(result.oldName, result.newName);
+    } catch (err) {
+        alert(`Failed to rename page: ${err}`);
+    }
+}
+
+// Undo the last rename
+async function undoRename() {
+    try {
+        const result = await UndoRename();
+        showRenamed(result.newName, result.oldName);
+    } catch (err) {
+        alert(`${err}`);
+    }
+}
+
+// Follow a page to its new name, including in the back history
+function showRenamed(oldName, newName) {
+    const sameName = (name) => name.toLowerCase() === oldName.toLowerCase();
+    navigationHistory = navigationHistory.map(name => sameName(name) ? newName : name);
+    if (sameName(currentPage)) {
+        currentPage = newName;
+    }
+    loadPage(currentPage);
+}
+
+// Ask which version to keep when a save is refused because the file was
+// changed in another editor
+async function resolveConflict(conflict) {
+    const keepLocal = confirm(
+        `"${conflict.page}" was changed in another editor since it was opened.\n\n` +
+        'OK keeps your version and overwrites the other change. ' +
+        'Cancel keeps the version on disk and discards your edit.'
+    );
+    try {
+        await ResolveConflict(conflict.page, keepLocal ? conflict.local : conflict.disk);
+    } catch (err) {
+        // Changed again while deciding; a new conflict event follows
+        console.error('Failed to resolve conflict:', err);
+        return;
+    }
+    loadPage(currentPage);
 }
 
 // Load and display a page
@@ -1081,13 +1245,26 @@ function renderLinkedReferences(backlinks) {
         sourceDiv.textContent = backlink.sourcePage;
         sourceDiv.onclick = () => navigateToPage(backlink.sourcePage);
         
-        const contentDiv = document.createElement('div');
-        contentDiv.className = 'reference-content';
-        // TODO: In future, show actual block content with context
-        contentDiv.textContent = `${backlink.blockIds.length} reference${backlink.blockIds.length > 1 ? 's' : ''}`;
-        
         referenceDiv.appendChild(sourceDiv);
-        referenceDiv.appendChild(contentDiv);
+        
+        // Show each referencing block with the blocks enclosing it
+        (backlink.blocks || []).forEach(block => {
+            const contentDiv = document.createElement('div');
+            contentDiv.className = 'reference-content';
+            
+            if (block.breadcrumb && block.breadcrumb.length > 0) {
+                const breadcrumbDiv = document.createElement('div');
+                breadcrumbDiv.className = 'reference-breadcrumb';
+                breadcrumbDiv.textContent = block.breadcrumb.join(' › ');
+                contentDiv.appendChild(breadcrumbDiv);
+            }
+            
+            const textDiv = document.createElement('div');
+            textDiv.innerHTML = renderSegmentsToHTML(block.segments);
+            contentDiv.appendChild(textDiv);
+            referenceDiv.appendChild(contentDiv);
+        });
+        
         linkedReferencesContainer.appendChild(referenceDiv);
     });
 }
@@ -1537,6 +1714,14 @@ document.addEventListener('keydown', (event) => {
         }
     }
     
+    // Cmd/Ctrl + Shift + Z: Undo the last page rename
+    if ((event.metaKey || event.ctrlKey) && event.shiftKey && event.key.toLowerCase() === 'z') {
+        if (!document.querySelector('.block-text.editing')) {
+            event.preventDefault();
+            undoRename();
+        }
+    }
+    
     // Cmd/Ctrl + Shift + Left Arrow: Collapse all blocks
     if ((event.metaKey || event.ctrlKey) && event.shiftKey && event.key === 'ArrowLeft') {
         event.preventDefault();
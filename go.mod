@@ -2,9 +2,15 @@ module github.com/rehanog/seq2b
 
 go 1.24.4
 
+require (
+	github.com/dgraph-io/badger/v4 v4.8.0
+	github.com/fsnotify/fsnotify v1.10.1
+	golang.org/x/text v0.26.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
 require (
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
-	github.com/dgraph-io/badger/v4 v4.8.0 // indirect
 	github.com/dgraph-io/ristretto/v2 v2.2.0 // indirect
 	github.com/dustin/go-humanize v1.0.1 // indirect
 	github.com/go-logr/logr v1.4.3 // indirect
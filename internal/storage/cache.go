@@ -31,7 +31,7 @@ type CacheMetadata struct {
 }
 
 const (
-	cacheVersion = "1.0"
+	cacheVersion = "1.2" // Bumped when parsed pages gain fields, so older caches are rebuilt
 	metadataKey  = "cache_metadata"
 	pagePrefix   = "page:"
 	backlinksPrefix = "backlinks:"
@@ -39,8 +39,14 @@ const (
 
 // NewCacheManager creates a new cache manager
 func NewCacheManager(libraryPath string) (*CacheManager, error) {
+	return NewCacheManagerIn(libraryPath, "")
+}
+
+// NewCacheManagerIn creates a cache manager keeping its files in cacheDir,
+// or in the default location for libraryPath if cacheDir is empty
+func NewCacheManagerIn(libraryPath string, cacheDir string) (*CacheManager, error) {
 	// Determine cache directory based on library path
-	cacheDir, err := getCacheDir(libraryPath)
+	cacheDir, err := getCacheDir(libraryPath, cacheDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cache directory: %w", err)
 	}
@@ -246,12 +252,11 @@ func (cm *CacheManager) Clear() error {
 	return cm.db.DropAll()
 }
 
-// getCacheDir returns the appropriate cache directory for the platform
-func getCacheDir(libraryPath string) (string, error) {
-	var cacheDir string
-	
+// getCacheDir returns the appropriate cache directory for the platform.
+// A cacheDir given by the caller is always used, even in test mode.
+func getCacheDir(libraryPath string, cacheDir string) (string, error) {
 	// If running tests, use a temp directory within the repo
-	if os.Getenv("SEQ2B_TEST_MODE") == "true" || libraryPath == "" {
+	if cacheDir == "" && (os.Getenv("SEQ2B_TEST_MODE") == "true" || libraryPath == "") {
 		// Find the seq2b root directory by looking for go.mod
 		currentDir, err := os.Getwd()
 		if err != nil {
@@ -274,9 +279,9 @@ func getCacheDir(libraryPath string) (string, error) {
 			}
 			rootDir = parent
 		}
-	} else {
+	} else if cacheDir == "" {
 		// For normal operation, store cache in cache subdirectory of the library
-		cacheDir = filepath.Join(libraryPath, "cache")
+		cacheDir = DefaultCacheDir(libraryPath)
 	}
 	
 	// Create directory if it doesn't exist
@@ -285,4 +290,10 @@ func getCacheDir(libraryPath string) (string, error) {
 	}
 
 	return cacheDir, nil
+}
+
+// DefaultCacheDir is where a library's cache is kept unless another
+// directory is given
+func DefaultCacheDir(libraryPath string) string {
+	return filepath.Join(libraryPath, "cache")
 }
\ No newline at end of file
@@ -319,20 +319,43 @@ func TestCacheManager_ConcurrentAccess(t *testing.T) {
 }
 
 func TestGetCacheDir(t *testing.T) {
-	// Test cache directory creation
-	dir, err := getCacheDir()
+	libraryPath := t.TempDir()
+	chosen := filepath.Join(t.TempDir(), "chosen")
+	
+	// A directory chosen by the caller wins, even in test mode
+	t.Setenv("SEQ2B_TEST_MODE", "true")
+	dir, err := getCacheDir(libraryPath, chosen)
 	if err != nil {
-		t.Errorf("getCacheDir failed: %v", err)
+		t.Fatalf("getCacheDir failed: %v", err)
+	}
+	if dir != chosen {
+		t.Errorf("Cache directory = %s, want %s", dir, chosen)
 	}
 	
-	// Verify directory exists
-	if _, err := os.Stat(dir); os.IsNotExist(err) {
-		t.Error("Cache directory was not created")
+	// Test mode keeps other caches out of the library
+	dir, err = getCacheDir(libraryPath, "")
+	if err != nil {
+		t.Fatalf("getCacheDir failed: %v", err)
+	}
+	if dir == filepath.Join(libraryPath, "cache") {
+		t.Errorf("Test mode cache should not be in the library, got %s", dir)
 	}
 	
-	// Should contain "seq2b" in path
-	if filepath.Base(dir) != "seq2b" {
-		t.Errorf("Cache directory should end with 'seq2b', got: %s", dir)
+	// Otherwise the cache is in the library
+	t.Setenv("SEQ2B_TEST_MODE", "")
+	dir, err = getCacheDir(libraryPath, "")
+	if err != nil {
+		t.Fatalf("getCacheDir failed: %v", err)
+	}
+	if dir != filepath.Join(libraryPath, "cache") {
+		t.Errorf("Cache directory = %s, want cache/ in the library", dir)
+	}
+	
+	// Verify directories are created
+	for _, dir := range []string{chosen, dir} {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			t.Errorf("Cache directory %s was not created", dir)
+		}
 	}
 }
 
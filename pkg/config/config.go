@@ -0,0 +1,223 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package config reads a vault's settings from seq2b.yaml in the library
+// root. Settings the file leaves out are taken from Logseq's
+// logseq/config.edn where it has an equivalent, and otherwise default to
+// what seq2b has always done.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	
+	"gopkg.in/yaml.v3"
+	
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// FileName is the vault config file, in the library root
+const FileName = "seq2b.yaml"
+
+// Patterns used to pull directory settings out of logseq/config.edn. The
+// journal and filename settings are read by the parser's loaders.
+var (
+	ednPagesDirPattern    = regexp.MustCompile(`:pages-directory\s+"([^"]*)"`)
+	ednJournalsDirPattern = regexp.MustCompile(`:journals-directory\s+"([^"]*)"`)
+)
+
+// Config holds a vault's settings. Directories are written with forward
+// slashes and are relative to the library root.
+type Config struct {
+	IndentWidth           int                   `yaml:"indent-width"`             // Spaces per nesting level
+	JournalTitleFormat    string                `yaml:"journal-title-format"`     // date-fns tokens, e.g. "MMM do, yyyy"
+	JournalFileNameFormat string                `yaml:"journal-file-name-format"` // e.g. "yyyy_MM_dd"
+	TodoKeywords          []string              `yaml:"todo-keywords"`            // Words marking a block as a task
+	PagesDir              string                `yaml:"pages-directory"`
+	JournalsDir           string                `yaml:"journals-directory"`
+	CacheDir              string                `yaml:"cache-directory"`      // May be absolute; empty for cache/ in each parsed directory
+	FileNameFormat        parser.FileNameFormat `yaml:"file-name-format"`     // triple-lowbar or legacy
+	TrashRetentionDays    int                   `yaml:"trash-retention-days"` // Days deleted pages are kept, 0 until purged by hand
+}
+
+// Default returns the settings of a vault without any config
+func Default() *Config {
+	return &Config{
+		IndentWidth:           parser.DefaultIndentWidth,
+		JournalTitleFormat:    parser.DefaultJournalFormat.TitleFormat,
+		JournalFileNameFormat: parser.DefaultJournalFormat.FileNameFormat,
+		TodoKeywords:          parser.DefaultTodoKeywords.Words(),
+		PagesDir:              "pages",
+		JournalsDir:           "journals",
+		FileNameFormat:        parser.DefaultFileNameFormat,
+	}
+}
+
+// Load reads the settings of the library at libraryPath: seq2b.yaml over
+// logseq/config.edn over the defaults. logseq/config.edn is only read.
+// Unknown keys and invalid values are errors, and then the defaults are
+// returned along with the error.
+func Load(libraryPath string) (*Config, error) {
+	config := Default()
+	if err := config.importLogseq(libraryPath); err != nil {
+		return Default(), fmt.Errorf("logseq/config.edn: %w", err)
+	}
+	
+	content, err := os.ReadFile(filepath.Join(libraryPath, FileName))
+	if err != nil && !os.IsNotExist(err) {
+		return Default(), fmt.Errorf("error reading %s: %w", FileName, err)
+	}
+	if err == nil {
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(config); err != nil && err != io.EOF {
+			return Default(), fmt.Errorf("%s: %w", FileName, err)
+		}
+	}
+	
+	if err := config.Validate(); err != nil {
+		return Default(), fmt.Errorf("invalid vault config: %w", err)
+	}
+	return config, nil
+}
+
+// importLogseq takes the settings Logseq's config.edn has an equivalent
+// for: journal formats, filename format and directories
+func (c *Config) importLogseq(libraryPath string) error {
+	journalFormat, err := parser.LoadJournalFormat(libraryPath)
+	if err != nil {
+		return err
+	}
+	c.JournalTitleFormat = journalFormat.TitleFormat
+	c.JournalFileNameFormat = journalFormat.FileNameFormat
+	
+	if c.FileNameFormat, err = parser.LoadFileNameFormat(libraryPath); err != nil {
+		return err
+	}
+	
+	content, err := os.ReadFile(filepath.Join(libraryPath, "logseq", "config.edn"))
+	if err != nil {
+		return nil // Already reported by the loaders above if it's not missing
+	}
+	if matches := ednPagesDirPattern.FindSubmatch(content); matches != nil {
+		c.PagesDir = string(matches[1])
+	}
+	if matches := ednJournalsDirPattern.FindSubmatch(content); matches != nil {
+		c.JournalsDir = string(matches[1])
+	}
+	return nil
+}
+
+// Validate checks every setting, reporting all the problems found
+func (c *Config) Validate() error {
+	var errs []error
+	if c.IndentWidth < 1 || c.IndentWidth > 8 {
+		errs = append(errs, fmt.Errorf("indent-width must be between 1 and 8, got %d", c.IndentWidth))
+	}
+	if err := c.JournalFormat().Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := parser.NewTodoKeywords(c.TodoKeywords...); err != nil {
+		errs = append(errs, fmt.Errorf("todo-keywords: %w", err))
+	}
+	for _, dir := range []struct{ key, value string }{
+		{"pages-directory", c.PagesDir},
+		{"journals-directory", c.JournalsDir},
+	} {
+		if !filepath.IsLocal(filepath.FromSlash(dir.value)) {
+			errs = append(errs, fmt.Errorf("%s must be a folder inside the library, got %q", dir.key, dir.value))
+		}
+	}
+	if filepath.Clean(filepath.FromSlash(c.PagesDir)) == filepath.Clean(filepath.FromSlash(c.JournalsDir)) {
+		errs = append(errs, fmt.Errorf("pages-directory and journals-directory must differ, both are %q", c.PagesDir))
+	}
+	if cacheDir := filepath.FromSlash(c.CacheDir); cacheDir != "" && !filepath.IsAbs(cacheDir) && !filepath.IsLocal(cacheDir) {
+		errs = append(errs, fmt.Errorf("cache-directory must be inside the library or an absolute path, got %q", c.CacheDir))
+	}
+	if err := c.FileNameFormat.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("file-name-format: %w", err))
+	}
+	if c.TrashRetentionDays < 0 {
+		errs = append(errs, fmt.Errorf("trash-retention-days must not be negative, got %d", c.TrashRetentionDays))
+	}
+	return errors.Join(errs...)
+}
+
+// JournalFormat returns the journal title and filename formats
+func (c *Config) JournalFormat() parser.JournalFormat {
+	return parser.JournalFormat{
+		TitleFormat:    c.JournalTitleFormat,
+		FileNameFormat: c.JournalFileNameFormat,
+	}
+}
+
+// Keywords returns the TODO keywords, or the defaults if they're invalid
+func (c *Config) Keywords() *parser.TodoKeywords {
+	if slices.Equal(c.TodoKeywords, parser.DefaultTodoKeywords.Words()) {
+		return parser.DefaultTodoKeywords
+	}
+	keywords, err := parser.NewTodoKeywords(c.TodoKeywords...)
+	if err != nil {
+		return parser.DefaultTodoKeywords
+	}
+	return keywords
+}
+
+// PagesPath returns the pages directory of the library at libraryPath
+func (c *Config) PagesPath(libraryPath string) string {
+	return filepath.Join(libraryPath, filepath.FromSlash(c.PagesDir))
+}
+
+// JournalsPath returns the journals directory of the library at
+// libraryPath
+func (c *Config) JournalsPath(libraryPath string) string {
+	return filepath.Join(libraryPath, filepath.FromSlash(c.JournalsDir))
+}
+
+// CachePath returns the directory holding the caches of the library at
+// libraryPath, one per parsed directory, or an empty string for the
+// parser's default of cache/ in each parsed directory
+func (c *Config) CachePath(libraryPath string) string {
+	cacheDir := filepath.FromSlash(c.CacheDir)
+	if cacheDir == "" || filepath.IsAbs(cacheDir) {
+		return cacheDir
+	}
+	return filepath.Join(libraryPath, cacheDir)
+}
+
+// ParseOptions returns parser options for the library at libraryPath
+// with these settings
+func (c *Config) ParseOptions(libraryPath string) parser.ParseOptions {
+	opts := parser.DefaultParseOptions()
+	opts.JournalFormat = c.JournalFormat()
+	opts.FileNameFormat = c.FileNameFormat
+	opts.IndentWidth = c.IndentWidth
+	opts.TodoKeywords = c.Keywords()
+	opts.CacheDir = c.CachePath(libraryPath)
+	return opts
+}
\ No newline at end of file
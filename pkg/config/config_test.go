@@ -0,0 +1,233 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// writeLibrary creates a library holding the given files
+func writeLibrary(t *testing.T, files map[string]string) string {
+	t.Helper()
+	libDir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(libDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return libDir
+}
+
+func TestLoadDefaults(t *testing.T) {
+	for name, files := range map[string]map[string]string{
+		"no config":  nil,
+		"empty file": {FileName: ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config, err := Load(writeLibrary(t, files))
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if !reflect.DeepEqual(config, Default()) {
+				t.Errorf("Load = %+v, want the defaults", config)
+			}
+			if opts := config.ParseOptions("/lib"); opts.TodoKeywords != parser.DefaultTodoKeywords || opts.CacheDir != "" {
+				t.Errorf("Default options should match the parser's: %+v", opts)
+			}
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	libDir := writeLibrary(t, map[string]string{FileName: `
+indent-width: 4
+journal-title-format: "yyyy-MM-dd"
+journal-file-name-format: "yyyy_MM_dd"
+todo-keywords: [TODO, BLOCKED, DONE]
+pages-directory: notes
+journals-directory: notes/daily
+cache-directory: .cache/seq2b
+file-name-format: legacy
+trash-retention-days: 30
+`})
+	config, err := Load(libDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	want := &Config{
+		IndentWidth:           4,
+		JournalTitleFormat:    "yyyy-MM-dd",
+		JournalFileNameFormat: "yyyy_MM_dd",
+		TodoKeywords:          []string{"TODO", "BLOCKED", "DONE"},
+		PagesDir:              "notes",
+		JournalsDir:           "notes/daily",
+		CacheDir:              ".cache/seq2b",
+		FileNameFormat:        parser.FileNameLegacy,
+		TrashRetentionDays:    30,
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("Load = %+v, want %+v", config, want)
+	}
+
+	opts := config.ParseOptions(libDir)
+	if opts.IndentWidth != 4 || opts.FileNameFormat != parser.FileNameLegacy || opts.JournalFormat.TitleFormat != "yyyy-MM-dd" {
+		t.Errorf("Unexpected parse options %+v", opts)
+	}
+	if !reflect.DeepEqual(opts.TodoKeywords.Words(), want.TodoKeywords) {
+		t.Errorf("TODO keywords = %q", opts.TodoKeywords.Words())
+	}
+	if opts.CacheDir != filepath.Join(libDir, ".cache", "seq2b") {
+		t.Errorf("Cache directory = %q", opts.CacheDir)
+	}
+	if config.JournalsPath(libDir) != filepath.Join(libDir, "notes", "daily") {
+		t.Errorf("Journals path = %q", config.JournalsPath(libDir))
+	}
+}
+
+func TestImportLogseqConfig(t *testing.T) {
+	edn := `{:journal/page-title-format "EEEE, MMMM do yyyy"
+ :journal/file-name-format "yyyy_MM_dd"
+ :pages-directory "wiki"
+ :journals-directory "days"
+ :file/name-format :triple-lowbar
+ :ui/show-brackets? true}`
+
+	config, err := Load(writeLibrary(t, map[string]string{"logseq/config.edn": edn}))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if config.JournalTitleFormat != "EEEE, MMMM do yyyy" || config.JournalFileNameFormat != "yyyy_MM_dd" {
+		t.Errorf("Journal formats not imported: %+v", config)
+	}
+	if config.PagesDir != "wiki" || config.JournalsDir != "days" {
+		t.Errorf("Directories not imported: %+v", config)
+	}
+	if config.FileNameFormat != parser.FileNameTripleLowbar {
+		t.Errorf("File name format not imported: %q", config.FileNameFormat)
+	}
+
+	// seq2b.yaml wins over config.edn, key by key
+	libDir := writeLibrary(t, map[string]string{
+		"logseq/config.edn": edn,
+		FileName:            "pages-directory: pages\n",
+	})
+	config, err = Load(libDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if config.PagesDir != "pages" || config.JournalsDir != "days" {
+		t.Errorf("Expected pages from seq2b.yaml and journals from config.edn: %+v", config)
+	}
+	if content, _ := os.ReadFile(filepath.Join(libDir, "logseq", "config.edn")); string(content) != edn {
+		t.Error("config.edn should never be written")
+	}
+
+	// Logseq's default for a config without :file/name-format
+	config, err = Load(writeLibrary(t, map[string]string{"logseq/config.edn": "{}"}))
+	if err != nil || config.FileNameFormat != parser.FileNameLegacy {
+		t.Errorf("Config without :file/name-format = %q, %v", config.FileNameFormat, err)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  map[string]string
+		errors []string // Each must appear in the error
+	}{
+		{
+			name:   "unknown key",
+			files:  map[string]string{FileName: "indent-width: 2\nindent: 4\n"},
+			errors: []string{FileName, "line 2", "indent"},
+		},
+		{
+			name:   "wrong type",
+			files:  map[string]string{FileName: "indent-width: four\n"},
+			errors: []string{FileName, "four"},
+		},
+		{
+			name: "every invalid value",
+			files: map[string]string{FileName: `
+indent-width: 0
+journal-title-format: "MMM qq"
+todo-keywords: [TODO, todo]
+pages-directory: ../elsewhere
+journals-directory: /abs/journals
+cache-directory: ../cache
+file-name-format: dashes
+trash-retention-days: -1
+`},
+			errors: []string{
+				"indent-width must be between 1 and 8, got 0",
+				"invalid journal title format",
+				`todo-keywords: invalid TODO keyword "todo"`,
+				`pages-directory must be a folder inside the library, got "../elsewhere"`,
+				`journals-directory must be a folder inside the library, got "/abs/journals"`,
+				`cache-directory must be inside the library or an absolute path, got "../cache"`,
+				"file-name-format",
+				"trash-retention-days must not be negative, got -1",
+			},
+		},
+		{
+			name:   "same pages and journals",
+			files:  map[string]string{FileName: "pages-directory: notes\njournals-directory: notes/\n"},
+			errors: []string{"must differ"},
+		},
+		{
+			name:   "empty keyword list",
+			files:  map[string]string{FileName: "todo-keywords: []\n"},
+			errors: []string{"no TODO keywords"},
+		},
+		{
+			name:   "invalid config.edn",
+			files:  map[string]string{"logseq/config.edn": `{:journal/page-title-format "MMM qq"}`},
+			errors: []string{"logseq/config.edn", "invalid journal title format"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Load(writeLibrary(t, tt.files))
+			if err == nil {
+				t.Fatal("Load should fail")
+			}
+			for _, want := range tt.errors {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Error %q should mention %q", err, want)
+				}
+			}
+			if !reflect.DeepEqual(config, Default()) {
+				t.Errorf("A failed load should return the defaults, got %+v", config)
+			}
+		})
+	}
+}
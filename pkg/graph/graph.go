@@ -0,0 +1,302 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package graph analyses the page link graph built by the parser: connected
+// components, page importance, paths between pages and link suggestions.
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// Graph is a directed graph of pages, with an edge from each page to every
+// page it links to. Pages that are only linked to are nodes too.
+type Graph struct {
+	keys   []string       // Page key of each node, sorted
+	titles []string       // Display name of each node
+	ids    map[string]int // Page key -> node
+	out    [][]int        // Pages each node links to, sorted
+	in     [][]int        // Pages linking to each node, sorted
+	links  [][]int        // Pages linked either way, sorted
+	keyOf  func(string) string
+}
+
+// Score is a page with a ranking score
+type Score struct {
+	Page  string
+	Score float64
+}
+
+// Hop is a page reached at some distance from a starting page
+type Hop struct {
+	Page     string
+	Distance int
+}
+
+// New builds a graph from a backlink index. Self-links are ignored.
+func New(idx *parser.BacklinkIndex) *Graph {
+	keySet := make(map[string]bool)
+	for source, targets := range idx.ForwardLinks {
+		keySet[source] = true
+		for target := range targets {
+			keySet[target] = true
+		}
+	}
+	for target := range idx.BackwardLinks {
+		keySet[target] = true
+	}
+	
+	g := &Graph{
+		keys:  make([]string, 0, len(keySet)),
+		ids:   make(map[string]int, len(keySet)),
+		keyOf: idx.Key,
+	}
+	for key := range keySet {
+		g.keys = append(g.keys, key)
+	}
+	sort.Strings(g.keys)
+	
+	g.titles = make([]string, len(g.keys))
+	for id, key := range g.keys {
+		g.ids[key] = id
+		g.titles[id] = key
+		if title, ok := idx.Titles[key]; ok {
+			g.titles[id] = title
+		}
+	}
+	
+	g.out = make([][]int, len(g.keys))
+	g.in = make([][]int, len(g.keys))
+	for source, targets := range idx.ForwardLinks {
+		from := g.ids[source]
+		for target := range targets {
+			to := g.ids[target]
+			g.out[from] = append(g.out[from], to)
+			g.in[to] = append(g.in[to], from)
+		}
+	}
+	g.links = make([][]int, len(g.keys))
+	for id := range g.keys {
+		sort.Ints(g.out[id])
+		sort.Ints(g.in[id])
+		g.links[id] = mergeSorted(g.out[id], g.in[id])
+	}
+	
+	return g
+}
+
+// Len returns the number of pages in the graph
+func (g *Graph) Len() int {
+	return len(g.keys)
+}
+
+// Pages returns the display names of all pages, in key order
+func (g *Graph) Pages() []string {
+	return append([]string(nil), g.titles...)
+}
+
+// node finds a page by any spelling of its name
+func (g *Graph) node(name string) (int, error) {
+	if id, ok := g.ids[g.keyOf(name)]; ok {
+		return id, nil
+	}
+	return 0, fmt.Errorf("page '%s' not in graph", name)
+}
+
+// neighbours returns the pages linked to or from a node
+func (g *Graph) neighbours(id int) []int {
+	return g.links[id]
+}
+
+// Components returns the weakly connected components of the graph, largest
+// first, each sorted by title. Link direction is ignored.
+func (g *Graph) Components() [][]string {
+	seen := make([]bool, len(g.keys))
+	var components [][]string
+	
+	for start := range g.keys {
+		if seen[start] {
+			continue
+		}
+		seen[start] = true
+		queue := []int{start}
+		var component []string
+		
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			component = append(component, g.titles[id])
+			for _, next := range g.neighbours(id) {
+				if !seen[next] {
+					seen[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		
+		sortTitles(component)
+		components = append(components, component)
+	}
+	
+	sort.SliceStable(components, func(i, j int) bool {
+		return len(components[i]) > len(components[j])
+	})
+	return components
+}
+
+// ShortestPath returns the pages on a shortest path between two pages,
+// both included. Links are followed in either direction, since a backlink
+// relates two pages as much as a forward link does.
+func (g *Graph) ShortestPath(from, to string) ([]string, error) {
+	start, err := g.node(from)
+	if err != nil {
+		return nil, err
+	}
+	end, err := g.node(to)
+	if err != nil {
+		return nil, err
+	}
+	
+	previous := make([]int, len(g.keys))
+	for id := range previous {
+		previous[id] = -1
+	}
+	previous[start] = start
+	queue := []int{start}
+	
+	for len(queue) > 0 && previous[end] == -1 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, next := range g.neighbours(id) {
+			if previous[next] == -1 {
+				previous[next] = id
+				queue = append(queue, next)
+			}
+		}
+	}
+	
+	if previous[end] == -1 {
+		return nil, fmt.Errorf("no path from '%s' to '%s'", from, to)
+	}
+	
+	var path []string
+	for id := end; id != start; id = previous[id] {
+		path = append(path, g.titles[id])
+	}
+	path = append(path, g.titles[start])
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, nil
+}
+
+// Neighbourhood returns the pages within maxDistance links of a page, in
+// either direction, nearest first and then by title. The page itself is
+// not included.
+func (g *Graph) Neighbourhood(page string, maxDistance int) ([]Hop, error) {
+	start, err := g.node(page)
+	if err != nil {
+		return nil, err
+	}
+	
+	distance := map[int]int{start: 0}
+	frontier := []int{start}
+	var hops []Hop
+	
+	for depth := 1; depth <= maxDistance && len(frontier) > 0; depth++ {
+		var next []int
+		for _, id := range frontier {
+			for _, neighbour := range g.neighbours(id) {
+				if _, seen := distance[neighbour]; seen {
+					continue
+				}
+				distance[neighbour] = depth
+				next = append(next, neighbour)
+				hops = append(hops, Hop{Page: g.titles[neighbour], Distance: depth})
+			}
+		}
+		frontier = next
+	}
+	
+	sort.Slice(hops, func(i, j int) bool {
+		if hops[i].Distance != hops[j].Distance {
+			return hops[i].Distance < hops[j].Distance
+		}
+		return titleLess(hops[i].Page, hops[j].Page)
+	})
+	return hops, nil
+}
+
+// AtDistance returns the pages exactly n links away from a page, by title
+func (g *Graph) AtDistance(page string, n int) ([]string, error) {
+	hops, err := g.Neighbourhood(page, n)
+	if err != nil {
+		return nil, err
+	}
+	
+	pages := []string{}
+	for _, hop := range hops {
+		if hop.Distance == n {
+			pages = append(pages, hop.Page)
+		}
+	}
+	return pages, nil
+}
+
+// mergeSorted merges two sorted slices, dropping duplicates
+func mergeSorted(a, b []int) []int {
+	merged := make([]int, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		var next int
+		switch {
+		case j == len(b) || (i < len(a) && a[i] < b[j]):
+			next, i = a[i], i+1
+		case i == len(a) || b[j] < a[i]:
+			next, j = b[j], j+1
+		default:
+			next, i, j = a[i], i+1, j+1
+		}
+		merged = append(merged, next)
+	}
+	return merged
+}
+
+// titleLess orders titles case-insensitively, falling back to exact order
+func titleLess(a, b string) bool {
+	lowerA, lowerB := strings.ToLower(a), strings.ToLower(b)
+	if lowerA != lowerB {
+		return lowerA < lowerB
+	}
+	return a < b
+}
+
+// sortTitles sorts page titles with titleLess
+func sortTitles(titles []string) {
+	sort.Slice(titles, func(i, j int) bool {
+		return titleLess(titles[i], titles[j])
+	})
+}
\ No newline at end of file
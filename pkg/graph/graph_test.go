@@ -0,0 +1,213 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package graph
+
+import (
+	"fmt"
+	"math"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// buildGraph indexes pages given as title -> block contents
+func buildGraph(t testing.TB, pages map[string][]string) *Graph {
+	t.Helper()
+	idx := parser.NewBacklinkIndex()
+	for title, blocks := range pages {
+		content := "# " + title + "\n"
+		for _, block := range blocks {
+			content += "\n- " + block
+		}
+		result, err := parser.ParseFile(content)
+		if err != nil {
+			t.Fatalf("ParseFile(%s) failed: %v", title, err)
+		}
+		idx.AddPage(result.Page)
+	}
+	return New(idx)
+}
+
+// Two clusters: a hub index linking to topics, and a separate pair
+var samplePages = map[string][]string{
+	"Index":   {"[[Go]] and [[Rust]]", "Also [[Zig]]"},
+	"Reading": {"[[Go]] book", "[[Rust]] book"},
+	"Go":      {"Compiled, see [[Rust]]"},
+	"Rust":    {"Systems language"},
+	"Zig":     {"Self link [[Zig]]"},
+	"Island":  {"Points to [[Far Shore]]"},
+}
+
+func TestComponents(t *testing.T) {
+	g := buildGraph(t, samplePages)
+
+	want := [][]string{
+		{"Go", "Index", "Reading", "Rust", "Zig"},
+		{"Far Shore", "Island"},
+	}
+	if got := g.Components(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Components() = %v, want %v", got, want)
+	}
+	if g.Len() != 7 {
+		t.Errorf("Len() = %d, want 7 including the unwritten Far Shore", g.Len())
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	g := buildGraph(t, samplePages)
+
+	path, err := g.ShortestPath("reading", "ZIG")
+	if err != nil {
+		t.Fatalf("ShortestPath failed: %v", err)
+	}
+	// Reading -> Go <- Index -> Zig, following links either way
+	if len(path) != 4 || path[0] != "Reading" || path[3] != "Zig" {
+		t.Errorf("Unexpected path %v", path)
+	}
+
+	if _, err := g.ShortestPath("Go", "Island"); err == nil {
+		t.Error("Expected an error between separate components")
+	}
+	if _, err := g.ShortestPath("Go", "Nowhere"); err == nil {
+		t.Error("Expected an error for an unknown page")
+	}
+	if path, _ := g.ShortestPath("Go", "Go"); !reflect.DeepEqual(path, []string{"Go"}) {
+		t.Errorf("Path to itself = %v", path)
+	}
+}
+
+func TestNeighbourhood(t *testing.T) {
+	g := buildGraph(t, samplePages)
+
+	hops, err := g.Neighbourhood("Zig", 2)
+	if err != nil {
+		t.Fatalf("Neighbourhood failed: %v", err)
+	}
+	want := []Hop{{"Index", 1}, {"Go", 2}, {"Rust", 2}}
+	if !reflect.DeepEqual(hops, want) {
+		t.Errorf("Neighbourhood(Zig, 2) = %v, want %v", hops, want)
+	}
+
+	pages, _ := g.AtDistance("Zig", 3)
+	if !reflect.DeepEqual(pages, []string{"Reading"}) {
+		t.Errorf("AtDistance(Zig, 3) = %v", pages)
+	}
+}
+
+func TestPageRank(t *testing.T) {
+	g := buildGraph(t, samplePages)
+	scores := g.PageRank(0.85, 100, 1e-9)
+
+	total := 0.0
+	for _, score := range scores {
+		total += score.Score
+	}
+	if math.Abs(total-1) > 1e-6 {
+		t.Errorf("Scores should sum to 1, got %f", total)
+	}
+	// Rust is linked from three pages, including Go which is itself popular
+	if scores[0].Page != "Rust" {
+		t.Errorf("Expected Rust to rank first, got %v", scores)
+	}
+}
+
+func TestHITS(t *testing.T) {
+	g := buildGraph(t, samplePages)
+	hubs, authorities := g.HITS(50)
+
+	if hubs[0].Page != "Index" {
+		t.Errorf("Expected Index as top hub, got %v", hubs)
+	}
+	if authorities[0].Page != "Rust" {
+		t.Errorf("Expected Rust as top authority, got %v", authorities)
+	}
+}
+
+func TestSuggestLinks(t *testing.T) {
+	g := buildGraph(t, samplePages)
+
+	// Zig is cited by Index alongside Go and Rust
+	suggestions, err := g.SuggestLinks("Zig", 0)
+	if err != nil {
+		t.Fatalf("SuggestLinks failed: %v", err)
+	}
+	want := []Suggestion{
+		{Page: "Go", Score: 1, CitedWith: []string{"Index"}},
+		{Page: "Rust", Score: 1, CitedWith: []string{"Index"}},
+	}
+	if !reflect.DeepEqual(suggestions, want) {
+		t.Errorf("SuggestLinks(Zig) = %+v, want %+v", suggestions, want)
+	}
+
+	// Go already links to Rust, so only Zig is suggested
+	suggestions, _ = g.SuggestLinks("Go", 1)
+	if len(suggestions) != 1 || suggestions[0].Page != "Zig" {
+		t.Errorf("SuggestLinks(Go, 1) = %+v", suggestions)
+	}
+}
+
+// loadTestVault generates a vault with tools/generate-test-vault and parses
+// it, so benchmarks run on the same shape of data as the other tools
+func loadTestVault(b *testing.B, pages int) *parser.MultiPageResult {
+	b.Helper()
+	dir := b.TempDir()
+	cmd := exec.Command("go", "run", "../../tools/generate-test-vault",
+		"-pages", fmt.Sprint(pages), "-seed", "42", "-output", dir)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		b.Skipf("generate-test-vault failed: %v\n%s", err, output)
+	}
+
+	result, err := parser.ParseDirectory(filepath.Join(dir, "pages"))
+	if err != nil {
+		b.Fatalf("ParseDirectory failed: %v", err)
+	}
+	return result
+}
+
+func BenchmarkGraphAnalytics(b *testing.B) {
+	result := loadTestVault(b, 5000)
+	g := New(result.Backlinks)
+	some := g.Pages()[g.Len()/2]
+	b.Logf("%d pages, %d components", g.Len(), len(g.Components()))
+
+	benchmarks := map[string]func(){
+		"New":           func() { New(result.Backlinks) },
+		"Components":    func() { g.Components() },
+		"PageRank":      func() { g.PageRank(0.85, 100, 1e-6) },
+		"HITS":          func() { g.HITS(50) },
+		"ShortestPath":  func() { g.ShortestPath(g.Pages()[0], some) },
+		"Neighbourhood": func() { g.Neighbourhood(some, 3) },
+		"SuggestLinks":  func() { g.SuggestLinks(some, 10) },
+	}
+	for _, name := range []string{"New", "Components", "PageRank", "HITS", "ShortestPath", "Neighbourhood", "SuggestLinks"} {
+		run := benchmarks[name]
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				run()
+			}
+		})
+	}
+}
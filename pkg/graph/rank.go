@@ -0,0 +1,143 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package graph
+
+import (
+	"math"
+	"sort"
+)
+
+// PageRank scores pages by the chance a reader following random links ends
+// up on them. damping is the chance of following a link rather than
+// jumping to a random page, usually 0.85. Iteration stops once scores move
+// less than tolerance in total, or after maxIterations.
+func (g *Graph) PageRank(damping float64, maxIterations int, tolerance float64) []Score {
+	n := len(g.keys)
+	if n == 0 {
+		return []Score{}
+	}
+	
+	rank := make([]float64, n)
+	next := make([]float64, n)
+	for id := range rank {
+		rank[id] = 1 / float64(n)
+	}
+	
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		// Pages without outgoing links share their rank with every page
+		dangling := 0.0
+		for id := range rank {
+			if len(g.out[id]) == 0 {
+				dangling += rank[id]
+			}
+		}
+		
+		base := (1-damping)/float64(n) + damping*dangling/float64(n)
+		for id := range next {
+			next[id] = base
+		}
+		for id, targets := range g.out {
+			if len(targets) == 0 {
+				continue
+			}
+			share := damping * rank[id] / float64(len(targets))
+			for _, target := range targets {
+				next[target] += share
+			}
+		}
+		
+		change := 0.0
+		for id := range rank {
+			change += math.Abs(next[id] - rank[id])
+		}
+		rank, next = next, rank
+		if change < tolerance {
+			break
+		}
+	}
+	
+	return g.scores(rank)
+}
+
+// HITS computes hub and authority scores. A good hub links to many good
+// authorities, such as an index or map-of-content page; a good authority
+// is linked from many good hubs.
+func (g *Graph) HITS(iterations int) (hubs, authorities []Score) {
+	n := len(g.keys)
+	hub := make([]float64, n)
+	authority := make([]float64, n)
+	for id := range hub {
+		hub[id] = 1
+		authority[id] = 1
+	}
+	
+	for iteration := 0; iteration < iterations; iteration++ {
+		for id := range authority {
+			authority[id] = 0
+			for _, source := range g.in[id] {
+				authority[id] += hub[source]
+			}
+		}
+		normalise(authority)
+		
+		for id := range hub {
+			hub[id] = 0
+			for _, target := range g.out[id] {
+				hub[id] += authority[target]
+			}
+		}
+		normalise(hub)
+	}
+	
+	return g.scores(hub), g.scores(authority)
+}
+
+// normalise scales values to unit length, leaving all-zero values alone
+func normalise(values []float64) {
+	sum := 0.0
+	for _, value := range values {
+		sum += value * value
+	}
+	if sum == 0 {
+		return
+	}
+	norm := math.Sqrt(sum)
+	for i := range values {
+		values[i] /= norm
+	}
+}
+
+// scores pairs values with page titles, highest first and then by title
+func (g *Graph) scores(values []float64) []Score {
+	scores := make([]Score, len(values))
+	for id, value := range values {
+		scores[id] = Score{Page: g.titles[id], Score: value}
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return titleLess(scores[i].Page, scores[j].Page)
+	})
+	return scores
+}
\ No newline at end of file
@@ -0,0 +1,79 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package graph
+
+import "sort"
+
+// Suggestion is a page that may be worth linking, with the pages that cite
+// it together with the page being edited
+type Suggestion struct {
+	Page      string
+	Score     int      // Number of pages citing both
+	CitedWith []string // Those pages, by title
+}
+
+// SuggestLinks proposes pages to link from a page based on co-citation:
+// two pages that are often linked from the same places are likely
+// related. Pages already linked in either direction are left out. At most
+// limit suggestions are returned, strongest first; zero means no limit.
+func (g *Graph) SuggestLinks(page string, limit int) ([]Suggestion, error) {
+	id, err := g.node(page)
+	if err != nil {
+		return nil, err
+	}
+	
+	linked := map[int]bool{id: true}
+	for _, neighbour := range g.neighbours(id) {
+		linked[neighbour] = true
+	}
+	
+	citedWith := make(map[int][]string)
+	for _, citing := range g.in[id] {
+		for _, other := range g.out[citing] {
+			if !linked[other] {
+				citedWith[other] = append(citedWith[other], g.titles[citing])
+			}
+		}
+	}
+	
+	suggestions := make([]Suggestion, 0, len(citedWith))
+	for other, citing := range citedWith {
+		sortTitles(citing)
+		suggestions = append(suggestions, Suggestion{
+			Page:      g.titles[other],
+			Score:     len(citing),
+			CitedWith: citing,
+		})
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return titleLess(suggestions[i].Page, suggestions[j].Page)
+	})
+	
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
\ No newline at end of file
@@ -0,0 +1,213 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package history keeps the versions of pages saved in a vault. Each
+// version's content is stored once under its content hash, however many
+// pages or saves share it, and each page has a log of the versions saved
+// for it.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+// Version is one saved version of a page
+type Version struct {
+	Hash    string    `json:"hash"` // parser.ContentHash of the content
+	SavedAt time.Time `json:"savedAt"`
+}
+
+// Store is a history directory. Contents are kept in objects/, named by
+// hash, and each page's versions are listed oldest first in
+// pages/<page key>.jsonl, one JSON Version per line.
+type Store struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// New returns the store in dir, which is created when the first version is
+// recorded
+func New(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Dir returns the store's directory
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Record adds content as the latest version of the page with the given
+// key. Saving the same content twice in a row records one version, and
+// false is returned the second time; content the page had earlier is
+// recorded again, as it's a new version in the page's log.
+func (s *Store) Record(key string, content []byte, savedAt time.Time) (Version, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	version := Version{Hash: parser.ContentHash(content), SavedAt: savedAt}
+	versions, err := s.versions(key)
+	if err != nil {
+		return Version{}, false, err
+	}
+	if len(versions) > 0 && versions[len(versions)-1].Hash == version.Hash {
+		return versions[len(versions)-1], false, nil
+	}
+	
+	if err := s.writeObject(version.Hash, content); err != nil {
+		return Version{}, false, fmt.Errorf("failed to record version: %w", err)
+	}
+	line, err := json.Marshal(version)
+	if err != nil {
+		return Version{}, false, err
+	}
+	logPath := s.logPath(key)
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		return Version{}, false, fmt.Errorf("failed to record version: %w", err)
+	}
+	file, err := os.OpenFile(logPath, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return Version{}, false, fmt.Errorf("failed to record version: %w", err)
+	}
+	
+	// Start a new line if the last write was cut short
+	if info, err := file.Stat(); err == nil && info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := file.ReadAt(last, info.Size()-1); err == nil && last[0] != '\n' {
+			line = append([]byte{'\n'}, line...)
+		}
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return Version{}, false, fmt.Errorf("failed to record version: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return Version{}, false, fmt.Errorf("failed to record version: %w", err)
+	}
+	return version, true, nil
+}
+
+// Versions lists the versions recorded for a page, oldest first
+func (s *Store) Versions(key string) ([]Version, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.versions(key)
+}
+
+// Content returns the content of a recorded version
+func (s *Store) Content(hash string) ([]byte, error) {
+	if !validHash(hash) {
+		return nil, fmt.Errorf("invalid version hash '%s'", hash)
+	}
+	content, err := os.ReadFile(s.objectPath(hash))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("version '%s' not found", hash)
+		}
+		return nil, err
+	}
+	return content, nil
+}
+
+// versions reads a page's log. A line cut short by a crash mid-write is
+// skipped.
+func (s *Store) versions(key string) ([]Version, error) {
+	file, err := os.Open(s.logPath(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+	
+	var versions []Version
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var version Version
+		if err := json.Unmarshal(scanner.Bytes(), &version); err == nil && validHash(version.Hash) {
+			versions = append(versions, version)
+		}
+	}
+	return versions, scanner.Err()
+}
+
+// writeObject stores content under its hash, unless it's already there.
+// Objects are written to a temporary file and renamed into place, so one
+// is never seen half written.
+func (s *Store) writeObject(hash string, content []byte) error {
+	objectPath := s.objectPath(hash)
+	if _, err := os.Stat(objectPath); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(objectPath), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(objectPath), "."+hash+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), objectPath)
+}
+
+// objectPath spreads objects over subdirectories by the first two
+// characters of their hash
+func (s *Store) objectPath(hash string) string {
+	return filepath.Join(s.dir, "objects", hash[:2], hash)
+}
+
+// logPath names a page's log with the vault's filename encoding, so any
+// page key makes a valid filename
+func (s *Store) logPath(key string) string {
+	name := strings.TrimSuffix(parser.FileNameTripleLowbar.Encode(key), ".md")
+	return filepath.Join(s.dir, "pages", name+".jsonl")
+}
+
+// validHash reports whether hash could be a content hash, so a hash from
+// the caller can't name a path outside the store
+func validHash(hash string) bool {
+	if len(hash) != 64 {
+		return false
+	}
+	for _, c := range hash {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
\ No newline at end of file
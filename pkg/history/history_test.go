@@ -0,0 +1,141 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rehanog/seq2b/pkg/parser"
+)
+
+func TestRecordAndVersions(t *testing.T) {
+	store := New(t.TempDir())
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	saves := []struct {
+		content  string
+		recorded bool
+	}{
+		{"- one\n", true},
+		{"- one\n", false}, // Unchanged
+		{"- one\n- two\n", true},
+		{"- one\n", true}, // Back to an earlier version
+	}
+	for i, save := range saves {
+		version, recorded, err := store.Record("project", []byte(save.content), start.Add(time.Duration(i)*time.Minute))
+		if err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+		if recorded != save.recorded {
+			t.Errorf("Save %d: recorded = %v, want %v", i, recorded, save.recorded)
+		}
+		if version.Hash != parser.ContentHash([]byte(save.content)) {
+			t.Errorf("Save %d: hash %s doesn't match content", i, version.Hash)
+		}
+	}
+
+	versions, err := store.Versions("project")
+	if err != nil {
+		t.Fatalf("Versions failed: %v", err)
+	}
+	if len(versions) != 3 {
+		t.Fatalf("Expected 3 versions, got %+v", versions)
+	}
+	if !versions[0].SavedAt.Equal(start) || !versions[2].SavedAt.Equal(start.Add(3*time.Minute)) {
+		t.Errorf("Unexpected save times %+v", versions)
+	}
+	if versions[0].Hash != versions[2].Hash {
+		t.Error("Same content should have the same hash")
+	}
+	content, err := store.Content(versions[1].Hash)
+	if err != nil || string(content) != "- one\n- two\n" {
+		t.Errorf("Content = %q, %v", content, err)
+	}
+
+	// Content shared by versions is stored once
+	objects, _ := filepath.Glob(filepath.Join(store.Dir(), "objects", "*", "*"))
+	if len(objects) != 2 {
+		t.Errorf("Expected 2 objects, got %v", objects)
+	}
+
+	if versions, err := store.Versions("other"); err != nil || len(versions) != 0 {
+		t.Errorf("Page without history: %+v, %v", versions, err)
+	}
+}
+
+func TestPageKeysAsFileNames(t *testing.T) {
+	store := New(t.TempDir())
+	for _, key := range []string{"projects/seq2b", "projects___seq2b", "what? *now*"} {
+		if _, _, err := store.Record(key, []byte(key), time.Now()); err != nil {
+			t.Fatalf("Record(%q) failed: %v", key, err)
+		}
+	}
+	for _, key := range []string{"projects/seq2b", "projects___seq2b", "what? *now*"} {
+		versions, err := store.Versions(key)
+		if err != nil || len(versions) != 1 || versions[0].Hash != parser.ContentHash([]byte(key)) {
+			t.Errorf("Versions(%q) = %+v, %v", key, versions, err)
+		}
+	}
+}
+
+func TestContentRejectsBadHashes(t *testing.T) {
+	store := New(t.TempDir())
+	for _, hash := range []string{"", "../../pages/secret.md", parser.ContentHash([]byte("x"))[:10]} {
+		if _, err := store.Content(hash); err == nil {
+			t.Errorf("Content(%q) should fail", hash)
+		}
+	}
+	if _, err := store.Content(parser.ContentHash([]byte("never recorded"))); err == nil {
+		t.Error("Content of an unknown version should fail")
+	}
+}
+
+func TestTruncatedLogLine(t *testing.T) {
+	store := New(t.TempDir())
+	if _, _, err := store.Record("project", []byte("- one\n"), time.Now()); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	logPath := filepath.Join(store.Dir(), "pages", "project.jsonl")
+	file, err := os.OpenFile(logPath, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatalf("Failed to open log: %v", err)
+	}
+	file.WriteString(`{"hash":"ab`)
+	file.Close()
+
+	versions, err := store.Versions("project")
+	if err != nil || len(versions) != 1 {
+		t.Errorf("Versions = %+v, %v; want the complete line only", versions, err)
+	}
+
+	// The next version is recorded on a line of its own
+	if _, _, err := store.Record("project", []byte("- two\n"), time.Now()); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if versions, err := store.Versions("project"); err != nil || len(versions) != 2 {
+		t.Errorf("Versions after truncation = %+v, %v", versions, err)
+	}
+}
@@ -0,0 +1,239 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import "sort"
+
+// AliasConflict is an alias that can't be resolved to a single page,
+// either because several pages declare it or because a page with that
+// name already exists
+type AliasConflict struct {
+	Alias string   // The alias as written
+	Pages []string // Titles of the pages claiming it, sorted
+}
+
+// registerAliases records a page and the aliases it declares, then
+// re-resolves every alias whose target may have changed
+func (idx *BacklinkIndex) registerAliases(page *Page) {
+	if idx.pageAliases == nil {
+		idx.pageAliases = make(map[string][]string)
+		idx.aliasClaims = make(map[string]map[string]bool)
+	}
+	if idx.Aliases == nil {
+		idx.Aliases = make(map[string]string)
+	}
+	
+	pageKey := idx.rawKey(page.Title)
+	affected := []string{pageKey}
+	
+	aliases := idx.declaredAliases(page)
+	aliasKeys := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		aliasKey := idx.rawKey(alias)
+		aliasKeys = append(aliasKeys, aliasKey)
+		
+		if idx.aliasClaims[aliasKey] == nil {
+			idx.aliasClaims[aliasKey] = make(map[string]bool)
+		}
+		idx.aliasClaims[aliasKey][pageKey] = true
+		// The declared spelling beats link text, but not a page title
+		if _, isPage := idx.pageAliases[aliasKey]; !isPage {
+			idx.Titles[aliasKey] = alias
+		}
+		affected = append(affected, aliasKey)
+	}
+	idx.pageAliases[pageKey] = aliasKeys
+	
+	for _, aliasKey := range affected {
+		idx.resolveAlias(aliasKey)
+	}
+}
+
+// unregisterAliases forgets an indexed page and the aliases it declares,
+// then re-resolves every alias whose target may have changed
+func (idx *BacklinkIndex) unregisterAliases(pageKey string) {
+	affected := []string{pageKey}
+	
+	for _, aliasKey := range idx.pageAliases[pageKey] {
+		delete(idx.aliasClaims[aliasKey], pageKey)
+		if len(idx.aliasClaims[aliasKey]) == 0 {
+			delete(idx.aliasClaims, aliasKey)
+		}
+		affected = append(affected, aliasKey)
+	}
+	delete(idx.pageAliases, pageKey)
+	
+	for _, aliasKey := range affected {
+		idx.resolveAlias(aliasKey)
+		idx.forgetTitle(aliasKey)
+	}
+}
+
+// declaredAliases returns the aliases a page declares, one per key and
+// leaving out the page's own name
+func (idx *BacklinkIndex) declaredAliases(page *Page) []string {
+	pageKey := idx.rawKey(page.Title)
+	seen := map[string]bool{pageKey: true}
+	
+	var aliases []string
+	for _, alias := range page.Aliases() {
+		aliasKey := idx.rawKey(alias)
+		if seen[aliasKey] {
+			continue
+		}
+		seen[aliasKey] = true
+		aliases = append(aliases, alias)
+	}
+	return aliases
+}
+
+// aliasKeys returns the keys of the aliases a page declares
+func (idx *BacklinkIndex) aliasKeys(page *Page) []string {
+	aliases := idx.declaredAliases(page)
+	keys := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		keys = append(keys, idx.rawKey(alias))
+	}
+	return keys
+}
+
+// resolveAlias works out which page an alias key points at and moves any
+// references whose target changed as a result
+func (idx *BacklinkIndex) resolveAlias(aliasKey string) {
+	target := aliasKey
+	if _, isPage := idx.pageAliases[aliasKey]; !isPage && len(idx.aliasClaims[aliasKey]) == 1 {
+		for pageKey := range idx.aliasClaims[aliasKey] {
+			target = pageKey
+		}
+	}
+	
+	current := aliasKey
+	if resolved, ok := idx.Aliases[aliasKey]; ok {
+		current = resolved
+	}
+	if target == current {
+		return
+	}
+	
+	if target == aliasKey {
+		delete(idx.Aliases, aliasKey)
+	} else {
+		idx.Aliases[aliasKey] = target
+	}
+	idx.moveLinks(aliasKey, current, target)
+}
+
+// moveLinks re-targets references written as aliasKey from one page key to
+// another
+func (idx *BacklinkIndex) moveLinks(aliasKey, from, to string) {
+	var moved []BlockReference
+	var movedSources []string
+	
+	for sourceKey, refs := range idx.BackwardLinks[from] {
+		keep := refs[:0:0]
+		for _, ref := range refs {
+			if idx.rawKey(ref.Link) == aliasKey {
+				moved = append(moved, ref)
+				movedSources = append(movedSources, sourceKey)
+			} else {
+				keep = append(keep, ref)
+			}
+		}
+		if len(keep) == len(refs) {
+			continue
+		}
+		idx.setLinks(sourceKey, from, keep)
+	}
+	
+	if refs, ok := idx.selfLinks[from]; ok {
+		keep := refs[:0:0]
+		for _, ref := range refs {
+			if idx.rawKey(ref.Link) == aliasKey {
+				moved = append(moved, ref)
+				movedSources = append(movedSources, from)
+			} else {
+				keep = append(keep, ref)
+			}
+		}
+		if len(keep) == 0 {
+			delete(idx.selfLinks, from)
+		} else {
+			idx.selfLinks[from] = keep
+		}
+	}
+	
+	for i, ref := range moved {
+		idx.addLink(movedSources[i], to, ref)
+	}
+}
+
+// setLinks replaces the references from source to target, removing empty
+// entries so orphan detection stays accurate
+func (idx *BacklinkIndex) setLinks(sourceKey, targetKey string, refs []BlockReference) {
+	if len(refs) > 0 {
+		if idx.ForwardLinks[sourceKey] == nil {
+			idx.ForwardLinks[sourceKey] = make(map[string][]BlockReference)
+		}
+		if idx.BackwardLinks[targetKey] == nil {
+			idx.BackwardLinks[targetKey] = make(map[string][]BlockReference)
+		}
+		// The two maps must not share a backing array
+		idx.ForwardLinks[sourceKey][targetKey] = refs
+		idx.BackwardLinks[targetKey][sourceKey] = append([]BlockReference(nil), refs...)
+		return
+	}
+	
+	delete(idx.ForwardLinks[sourceKey], targetKey)
+	delete(idx.BackwardLinks[targetKey], sourceKey)
+	if len(idx.BackwardLinks[targetKey]) == 0 {
+		delete(idx.BackwardLinks, targetKey)
+	}
+}
+
+// AliasConflicts lists aliases that are declared by more than one page or
+// that collide with an existing page, sorted by alias
+func (idx *BacklinkIndex) AliasConflicts() []AliasConflict {
+	conflicts := []AliasConflict{}
+	
+	for aliasKey, claims := range idx.aliasClaims {
+		_, isPage := idx.pageAliases[aliasKey]
+		if len(claims) == 0 || (len(claims) == 1 && !isPage) {
+			continue
+		}
+		
+		conflict := AliasConflict{Alias: idx.Titles[aliasKey]}
+		if isPage {
+			conflict.Pages = append(conflict.Pages, idx.Titles[aliasKey])
+		}
+		for pageKey := range claims {
+			conflict.Pages = append(conflict.Pages, idx.Titles[pageKey])
+		}
+		sort.Strings(conflict.Pages)
+		conflicts = append(conflicts, conflict)
+	}
+	
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Alias < conflicts[j].Alias
+	})
+	return conflicts
+}
\ No newline at end of file
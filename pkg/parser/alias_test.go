@@ -0,0 +1,115 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// aliasPage builds a page with optional alias:: and one block per content
+func aliasPage(title, alias string, contents ...string) *Page {
+	page := &Page{Title: title, Properties: map[string]string{}}
+	if alias != "" {
+		page.Properties["alias"] = alias
+	}
+	for i, content := range contents {
+		page.Blocks = append(page.Blocks, &Block{ID: fmt.Sprintf("block-%d", i+1), Content: content})
+	}
+	page.AllBlocks = page.Blocks
+	return page
+}
+
+func TestBacklinkIndexResolvesAliases(t *testing.T) {
+	orders := map[string][]*Page{
+		"alias page first": {
+			aliasPage("Syntax Tree", "AST, [[Abstract Syntax Tree]]"),
+			aliasPage("Notes", "", "Built the [[AST]]", "Read about [[Syntax Tree]]", "See [[Abstract Syntax Tree]]"),
+		},
+		"alias page last": {
+			aliasPage("Notes", "", "Built the [[AST]]", "Read about [[Syntax Tree]]", "See [[Abstract Syntax Tree]]"),
+			aliasPage("Syntax Tree", "AST, [[Abstract Syntax Tree]]"),
+		},
+	}
+
+	for name, pages := range orders {
+		t.Run(name, func(t *testing.T) {
+			idx := NewBacklinkIndex()
+			for _, page := range pages {
+				idx.AddPage(page)
+			}
+
+			for _, spelling := range []string{"Syntax Tree", "AST", "Abstract Syntax Tree"} {
+				if refs := idx.GetBacklinks(spelling)[PageKey("Notes")]; len(refs) != 3 {
+					t.Errorf("GetBacklinks(%q) = %d refs from Notes, want 3", spelling, len(refs))
+				}
+			}
+			if refs := idx.GetForwardLinks("Notes")[PageKey("Syntax Tree")]; len(refs) != 3 {
+				t.Errorf("Notes forward links = %d, want 3", len(refs))
+			}
+			if _, ok := idx.BackwardLinks[PageKey("AST")]; ok {
+				t.Error("Alias should not remain a separate target")
+			}
+			if got := idx.Title("AST"); got != "Syntax Tree" {
+				t.Errorf("Title(AST) = %q, want Syntax Tree", got)
+			}
+		})
+	}
+}
+
+func TestBacklinkIndexAliasSelfLinks(t *testing.T) {
+	idx := NewBacklinkIndex()
+	idx.AddPage(aliasPage("Syntax Tree", "AST", "Also known as [[AST]]"))
+
+	if !idx.IsOrphanPage("Syntax Tree") {
+		t.Error("A link to the page's own alias is a self-reference")
+	}
+}
+
+func TestBacklinkIndexAliasConflicts(t *testing.T) {
+	idx := NewBacklinkIndex()
+	idx.AddPage(aliasPage("Notes", "", "Links to [[Shared]] and [[Existing]]"))
+	idx.AddPage(aliasPage("Page A", "Shared, Existing"))
+	idx.AddPage(aliasPage("Page B", "Shared"))
+	idx.AddPage(aliasPage("Existing", ""))
+
+	want := []AliasConflict{
+		{Alias: "Existing", Pages: []string{"Existing", "Page A"}},
+		{Alias: "Shared", Pages: []string{"Page A", "Page B"}},
+	}
+	if got := idx.AliasConflicts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AliasConflicts() = %+v, want %+v", got, want)
+	}
+
+	// Conflicting aliases resolve to no page at all; the real page keeps its name
+	if len(idx.GetBacklinks("Page A")) != 0 {
+		t.Error("Conflicting aliases should not resolve to Page A")
+	}
+	if refs := idx.GetBacklinks("Existing")[PageKey("Notes")]; len(refs) != 1 {
+		t.Errorf("Existing page should keep its backlink, got %d", len(refs))
+	}
+	if refs := idx.GetBacklinks("Shared")[PageKey("Notes")]; len(refs) != 1 {
+		t.Errorf("Unresolved alias should keep its own backlinks, got %d", len(refs))
+	}
+}
@@ -34,13 +34,29 @@ type BacklinkIndex struct {
 	
 	// Backward links: target page -> source pages that reference it
 	BackwardLinks map[string]map[string][]BlockReference
+	
+	// Titles maps page keys back to a display name, preferring the title
+	// of an indexed page over the spelling used in links
+	Titles map[string]string
+	
+	// Aliases maps alias keys to the key of the page declaring them.
+	// Conflicting aliases are left out and reported by AliasConflicts.
+	Aliases map[string]string
+	
+	// KeyFunc maps page names to the keys used above; PageKey when nil
+	KeyFunc func(string) string
+	
+	pageAliases map[string][]string         // Indexed page key -> alias keys it declares
+	aliasClaims map[string]map[string]bool  // Alias key -> page keys declaring it
+	selfLinks   map[string][]BlockReference // Page key -> links resolving to itself
 }
 
 // BlockReference records where a page reference appears
 type BlockReference struct {
 	PageName string // The page containing this reference
 	BlockID  string
-	Position int // character position in block content
+	Position int    // character position in block content
+	Link     string // Link text as written, which may be an alias
 }
 
 // NewBacklinkIndex creates a new empty backlink index
@@ -48,13 +64,43 @@ func NewBacklinkIndex() *BacklinkIndex {
 	return &BacklinkIndex{
 		ForwardLinks:  make(map[string]map[string][]BlockReference),
 		BackwardLinks: make(map[string]map[string][]BlockReference),
+		Titles:        make(map[string]string),
+		Aliases:       make(map[string]string),
+	}
+}
+
+// Key returns the canonical key for a page name, resolving aliases to the
+// page that declares them
+func (idx *BacklinkIndex) Key(pageName string) string {
+	key := idx.rawKey(pageName)
+	if canonical, ok := idx.Aliases[key]; ok {
+		return canonical
+	}
+	return key
+}
+
+// rawKey returns the key for a page name without resolving aliases
+func (idx *BacklinkIndex) rawKey(pageName string) string {
+	if idx.KeyFunc != nil {
+		return idx.KeyFunc(pageName)
 	}
+	return PageKey(pageName)
 }
 
+// Title returns the display name for a page key or name
+func (idx *BacklinkIndex) Title(pageName string) string {
+	if title, ok := idx.Titles[idx.Key(pageName)]; ok {
+		return title
+	}
+	return pageName
+}
+
+// Matches [[page]] references
+var pageLinkPattern = regexp.MustCompile(`\[\[(.*?)\]\]`)
+
 // ExtractPageLinks finds all [[page]] references in text
 func ExtractPageLinks(text string) []string {
-	linkPattern := regexp.MustCompile(`\[\[(.*?)\]\]`)
-	matches := linkPattern.FindAllStringSubmatch(text, -1)
+	matches := pageLinkPattern.FindAllStringSubmatch(text, -1)
 	
 	links := make([]string, 0, len(matches))
 	for _, match := range matches {
@@ -66,59 +112,218 @@ func ExtractPageLinks(text string) []string {
 	return links
 }
 
-// AddPage adds a single page to the backlink index
+// AddPage adds a single page to the backlink index. Adding a page that is
+// already indexed replaces the earlier version rather than duplicating its
+// references.
 func (idx *BacklinkIndex) AddPage(page *Page) {
+	if idx.isIndexed(page.Title) {
+		idx.UpdatePage(page)
+		return
+	}
+	idx.addPage(page)
+}
+
+// addPage indexes a page that is not in the index yet
+func (idx *BacklinkIndex) addPage(page *Page) {
 	pageName := page.Title
+	if idx.Titles == nil {
+		idx.Titles = make(map[string]string)
+	}
+	idx.registerAliases(page)
+	pageKey := idx.Key(pageName)
+	idx.Titles[pageKey] = pageName
 	
 	// Initialize forward links for this page if needed
-	if idx.ForwardLinks[pageName] == nil {
-		idx.ForwardLinks[pageName] = make(map[string][]BlockReference)
+	if idx.ForwardLinks[pageKey] == nil {
+		idx.ForwardLinks[pageKey] = make(map[string][]BlockReference)
 	}
 	
-	// Scan all blocks for references
+	for targetKey, refs := range idx.pageLinks(page) {
+		for _, ref := range refs {
+			idx.addLink(pageKey, targetKey, ref)
+		}
+	}
+}
+
+// pageLinks scans a page's blocks for references and groups them by target
+// key, recording a display name for targets not seen before
+func (idx *BacklinkIndex) pageLinks(page *Page) map[string][]BlockReference {
+	links := make(map[string][]BlockReference)
+	
 	for _, block := range page.AllBlocks {
-		links := ExtractPageLinks(block.Content)
-		
-		for _, targetPage := range links {
-			// Skip self-references
-			if targetPage == pageName {
-				continue
+		for _, targetPage := range ExtractPageLinks(block.Content) {
+			targetKey := idx.Key(targetPage)
+			if _, ok := idx.Titles[targetKey]; !ok {
+				idx.Titles[targetKey] = targetPage
 			}
 			
 			// Find position of this link in the content
 			pos := strings.Index(block.Content, "[["+targetPage+"]]")
 			
-			ref := BlockReference{
-				PageName: pageName,
+			links[targetKey] = append(links[targetKey], BlockReference{
+				PageName: page.Title,
 				BlockID:  block.ID,
 				Position: pos,
-			}
-			
-			// Add forward link
-			idx.ForwardLinks[pageName][targetPage] = append(
-				idx.ForwardLinks[pageName][targetPage], ref)
-			
-			// Add backward link
-			if idx.BackwardLinks[targetPage] == nil {
-				idx.BackwardLinks[targetPage] = make(map[string][]BlockReference)
-			}
-			idx.BackwardLinks[targetPage][pageName] = append(
-				idx.BackwardLinks[targetPage][pageName], ref)
+				Link:     targetPage,
+			})
+		}
+	}
+	
+	return links
+}
+
+// isIndexed reports whether a page with this name has been added
+func (idx *BacklinkIndex) isIndexed(pageName string) bool {
+	_, indexed := idx.pageAliases[idx.rawKey(pageName)]
+	return indexed
+}
+
+// UpdatePage replaces the indexed version of a page with a new one. Only
+// the references that differ are touched; a page that changes its aliases
+// is removed and added again, since that can move other pages' links too.
+// Pages not in the index yet are added.
+func (idx *BacklinkIndex) UpdatePage(page *Page) {
+	pageKey := idx.rawKey(page.Title)
+	oldAliases, indexed := idx.pageAliases[pageKey]
+	if !indexed || !equalStrings(oldAliases, idx.aliasKeys(page)) {
+		idx.RemovePage(page.Title)
+		idx.addPage(page)
+		return
+	}
+	idx.Titles[pageKey] = page.Title
+	
+	links := idx.pageLinks(page)
+	if self := links[pageKey]; len(self) > 0 {
+		if idx.selfLinks == nil {
+			idx.selfLinks = make(map[string][]BlockReference)
+		}
+		idx.selfLinks[pageKey] = self
+	} else {
+		delete(idx.selfLinks, pageKey)
+	}
+	delete(links, pageKey)
+	
+	// Drop targets the page no longer links to
+	for targetKey := range idx.ForwardLinks[pageKey] {
+		if _, ok := links[targetKey]; !ok {
+			idx.setLinks(pageKey, targetKey, nil)
+			idx.forgetTitle(targetKey)
+		}
+	}
+	
+	// Replace the references that changed
+	for targetKey, refs := range links {
+		if !equalReferences(idx.ForwardLinks[pageKey][targetKey], refs) {
+			idx.setLinks(pageKey, targetKey, refs)
 		}
 	}
 }
 
-// GetBacklinks returns all pages that link TO the given page
+// RemovePage removes a page and the references it makes. References to the
+// page from other pages stay, just as they would after a rebuild, so the
+// page becomes a link target without a file.
+func (idx *BacklinkIndex) RemovePage(pageName string) {
+	pageKey := idx.rawKey(pageName)
+	if _, indexed := idx.pageAliases[pageKey]; !indexed {
+		return
+	}
+	
+	for targetKey := range idx.ForwardLinks[pageKey] {
+		idx.setLinks(pageKey, targetKey, nil)
+		idx.forgetTitle(targetKey)
+	}
+	delete(idx.ForwardLinks, pageKey)
+	delete(idx.selfLinks, pageKey)
+	
+	idx.unregisterAliases(pageKey)
+}
+
+// RenamePage re-indexes a page under its new title. Links written with the
+// old name are not rewritten, so they keep pointing at the old name until
+// the pages containing them are updated.
+func (idx *BacklinkIndex) RenamePage(oldName string, page *Page) {
+	idx.RemovePage(oldName)
+	idx.AddPage(page)
+}
+
+// forgetTitle drops the display name of a key that nothing refers to any
+// more
+func (idx *BacklinkIndex) forgetTitle(key string) {
+	if _, isPage := idx.pageAliases[key]; isPage {
+		return
+	}
+	if len(idx.BackwardLinks[key]) > 0 || len(idx.aliasClaims[key]) > 0 {
+		return
+	}
+	delete(idx.Titles, key)
+}
+
+// equalReferences reports whether two reference lists are identical
+func equalReferences(a, b []BlockReference) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// equalStrings reports whether two string slices are identical
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// addLink records a reference from one page key to another. Self-references
+// are kept aside so they can move if an alias stops resolving to the page.
+func (idx *BacklinkIndex) addLink(sourceKey, targetKey string, ref BlockReference) {
+	if sourceKey == targetKey {
+		if idx.selfLinks == nil {
+			idx.selfLinks = make(map[string][]BlockReference)
+		}
+		idx.selfLinks[sourceKey] = append(idx.selfLinks[sourceKey], ref)
+		return
+	}
+	
+	// Add forward link
+	if idx.ForwardLinks[sourceKey] == nil {
+		idx.ForwardLinks[sourceKey] = make(map[string][]BlockReference)
+	}
+	idx.ForwardLinks[sourceKey][targetKey] = append(
+		idx.ForwardLinks[sourceKey][targetKey], ref)
+	
+	// Add backward link
+	if idx.BackwardLinks[targetKey] == nil {
+		idx.BackwardLinks[targetKey] = make(map[string][]BlockReference)
+	}
+	idx.BackwardLinks[targetKey][sourceKey] = append(
+		idx.BackwardLinks[targetKey][sourceKey], ref)
+}
+
+// GetBacklinks returns all pages that link TO the given page, keyed by
+// source page key. Any spelling of the page name may be used.
 func (idx *BacklinkIndex) GetBacklinks(pageName string) map[string][]BlockReference {
-	return idx.BackwardLinks[pageName]
+	return idx.BackwardLinks[idx.Key(pageName)]
 }
 
-// GetForwardLinks returns all pages that this page links TO
+// GetForwardLinks returns all pages that this page links TO, keyed by
+// target page key
 func (idx *BacklinkIndex) GetForwardLinks(pageName string) map[string][]BlockReference {
-	return idx.ForwardLinks[pageName]
+	return idx.ForwardLinks[idx.Key(pageName)]
 }
 
-// GetAllPages returns all pages in the index (both sources and targets)
+// GetAllPages returns the display names of all pages in the index (both
+// sources and targets)
 func (idx *BacklinkIndex) GetAllPages() []string {
 	pageSet := make(map[string]bool)
 	
@@ -134,15 +339,16 @@ func (idx *BacklinkIndex) GetAllPages() []string {
 	
 	pages := make([]string, 0, len(pageSet))
 	for page := range pageSet {
-		pages = append(pages, page)
+		pages = append(pages, idx.Title(page))
 	}
 	return pages
 }
 
 // IsOrphanPage returns true if a page has no incoming or outgoing links
 func (idx *BacklinkIndex) IsOrphanPage(pageName string) bool {
-	hasOutgoing := len(idx.ForwardLinks[pageName]) > 0
-	hasIncoming := len(idx.BackwardLinks[pageName]) > 0
+	key := idx.Key(pageName)
+	hasOutgoing := len(idx.ForwardLinks[key]) > 0
+	hasIncoming := len(idx.BackwardLinks[key]) > 0
 	return !hasOutgoing && !hasIncoming
 }
 
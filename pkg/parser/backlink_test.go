@@ -120,7 +120,7 @@ func TestBacklinkIndex(t *testing.T) {
 		}
 		
 		// Check Page B references from Page A
-		pageBRefs := forwardLinks["Page B"]
+		pageBRefs := forwardLinks[PageKey("Page B")]
 		if len(pageBRefs) != 2 {
 			t.Errorf("Page A -> Page B refs = %d, want 2", len(pageBRefs))
 		}
@@ -134,7 +134,7 @@ func TestBacklinkIndex(t *testing.T) {
 		}
 		
 		// Check that Page B links to Page A
-		if refs, ok := backlinks["Page B"]; !ok || len(refs) != 1 {
+		if refs, ok := backlinks[PageKey("Page B")]; !ok || len(refs) != 1 {
 			t.Errorf("Expected Page B to link to Page A once")
 		}
 	})
@@ -185,3 +185,96 @@ func TestFindOrphanBlocks(t *testing.T) {
 	}
 }
 
+
+func TestPageKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Jan 15th, 2025", "2025-01-15"},
+		{"2025-01-15", "2025-01-15"},
+		{"January 15, 2025", "2025-01-15"},
+		{" 2025/01/15 ", "2025-01-15"},
+		{"Page A", "page a"},
+		{"  Page   A ", "page a"},
+		{"PAGE\tA", "page a"},
+		{"Stra\u00dfe", "strasse"},
+		{"Cafe\u0301", "caf\u00e9"},
+	}
+	for _, tt := range tests {
+		if got := PageKey(tt.name); got != tt.want {
+			t.Errorf("PageKey(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// Custom journal title formats are recognised too
+	format := JournalFormat{TitleFormat: "dd.MM.yyyy", FileNameFormat: "yyyy_MM_dd"}
+	if got := format.PageKey("15.01.2025"); got != "2025-01-15" {
+		t.Errorf("PageKey with custom format = %q, want 2025-01-15", got)
+	}
+}
+
+func TestBacklinkIndexIsCaseInsensitive(t *testing.T) {
+	source := &Page{
+		Title: "Notes",
+		Blocks: []*Block{
+			{ID: "block-1", Content: "Working on [[Project]]"},
+			{ID: "block-2", Content: "Still on [[project]]"},
+			{ID: "block-3", Content: "And [[ PROJECT ]]"},
+		},
+	}
+	source.AllBlocks = source.Blocks
+	target := &Page{Title: "Project", Blocks: []*Block{{ID: "block-1", Content: "Plan"}}}
+	target.AllBlocks = target.Blocks
+
+	idx := NewBacklinkIndex()
+	idx.AddPage(source)
+	idx.AddPage(target)
+
+	if refs := idx.GetBacklinks("project")[PageKey("Notes")]; len(refs) != 3 {
+		t.Errorf("Expected 3 backlinks under one key, got %d", len(refs))
+	}
+	if len(idx.BackwardLinks) != 1 {
+		t.Errorf("Expected a single target, got %v", idx.GetAllPages())
+	}
+	if got := idx.Title("PROJECT"); got != "Project" {
+		t.Errorf("Display title = %q, want Project", got)
+	}
+}
+
+func TestBacklinkIndexCombinesDateSpellings(t *testing.T) {
+	journal := &Page{
+		Title:  "Jan 15th, 2025",
+		Blocks: []*Block{{ID: "block-1", Content: "Daily notes"}},
+	}
+	journal.AllBlocks = journal.Blocks
+
+	source := &Page{
+		Title: "Meetings",
+		Blocks: []*Block{
+			{ID: "block-1", Content: "Kickoff on [[Jan 15th, 2025]]"},
+			{ID: "block-2", Content: "Follow up [[2025-01-15]]"},
+			{ID: "block-3", Content: "Notes from [[January 15, 2025]]"},
+		},
+	}
+	source.AllBlocks = source.Blocks
+
+	idx := NewBacklinkIndex()
+	idx.AddPage(source)
+	idx.AddPage(journal)
+
+	for _, spelling := range []string{"Jan 15th, 2025", "2025-01-15", "January 15, 2025"} {
+		backlinks := idx.GetBacklinks(spelling)
+		if refs := backlinks[PageKey("Meetings")]; len(refs) != 3 {
+			t.Errorf("GetBacklinks(%q) found %d refs, want 3", spelling, len(refs))
+		}
+	}
+
+	// The journal's own title is used for display, not a link spelling
+	if got := idx.Title("2025-01-15"); got != "Jan 15th, 2025" {
+		t.Errorf("Title = %q, want %q", got, "Jan 15th, 2025")
+	}
+	if pages := idx.GetAllPages(); len(pages) != 2 {
+		t.Errorf("GetAllPages = %v, want 2 pages", pages)
+	}
+}
@@ -0,0 +1,266 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// indexSnapshot is the order-independent content of a BacklinkIndex, used
+// to compare an incrementally maintained index with a rebuilt one
+type indexSnapshot struct {
+	Forward   map[string]map[string][]BlockReference
+	Backward  map[string]map[string][]BlockReference
+	Self      map[string][]BlockReference
+	Aliases   map[string]string
+	Titles    map[string]string // Indexed pages only; link text depends on order
+	Conflicts []AliasConflict   // Keyed aliases, as their spelling depends on order
+}
+
+func snapshotIndex(idx *BacklinkIndex, pages map[string]*Page) indexSnapshot {
+	snap := indexSnapshot{
+		Forward:   sortedLinks(idx.ForwardLinks),
+		Backward:  sortedLinks(idx.BackwardLinks),
+		Self:      map[string][]BlockReference{},
+		Aliases:   idx.Aliases,
+		Titles:    map[string]string{},
+		Conflicts: idx.AliasConflicts(),
+	}
+	for key, refs := range idx.selfLinks {
+		snap.Self[key] = sortedReferences(refs)
+	}
+	for key := range pages {
+		snap.Titles[key] = idx.Titles[key]
+	}
+	for i := range snap.Conflicts {
+		snap.Conflicts[i].Alias = PageKey(snap.Conflicts[i].Alias)
+	}
+	sort.Slice(snap.Conflicts, func(i, j int) bool {
+		return snap.Conflicts[i].Alias < snap.Conflicts[j].Alias
+	})
+	return snap
+}
+
+func sortedLinks(links map[string]map[string][]BlockReference) map[string]map[string][]BlockReference {
+	sorted := make(map[string]map[string][]BlockReference, len(links))
+	for from, targets := range links {
+		sorted[from] = make(map[string][]BlockReference, len(targets))
+		for to, refs := range targets {
+			sorted[from][to] = sortedReferences(refs)
+		}
+	}
+	return sorted
+}
+
+func sortedReferences(refs []BlockReference) []BlockReference {
+	sorted := append([]BlockReference(nil), refs...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].BlockID != sorted[j].BlockID {
+			return sorted[i].BlockID < sorted[j].BlockID
+		}
+		if sorted[i].Position != sorted[j].Position {
+			return sorted[i].Position < sorted[j].Position
+		}
+		return sorted[i].Link < sorted[j].Link
+	})
+	return sorted
+}
+
+// rebuildIndex indexes pages from scratch in a random order
+func rebuildIndex(pages map[string]*Page, rng *rand.Rand) *BacklinkIndex {
+	keys := make([]string, 0, len(pages))
+	for key := range pages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	rng.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+	
+	idx := NewBacklinkIndex()
+	for _, key := range keys {
+		idx.AddPage(pages[key])
+	}
+	return idx
+}
+
+// Names are drawn from a small pool with case and spacing variants so that
+// links, aliases and titles collide often
+var randomNames = []string{"Alpha", "alpha", "Beta", "BETA", "Gamma", "Delta", " delta ", "Epsilon"}
+
+func randomPage(rng *rand.Rand, title string) *Page {
+	var alias string
+	if rng.Intn(3) == 0 {
+		aliases := []string{randomNames[rng.Intn(len(randomNames))]}
+		if rng.Intn(2) == 0 {
+			aliases = append(aliases, randomNames[rng.Intn(len(randomNames))])
+		}
+		alias = strings.Join(aliases, ", ")
+	}
+	
+	contents := make([]string, rng.Intn(4))
+	for i := range contents {
+		var parts []string
+		for j := rng.Intn(3); j >= 0; j-- {
+			parts = append(parts, fmt.Sprintf("see [[%s]]", randomNames[rng.Intn(len(randomNames))]))
+		}
+		contents[i] = strings.Join(parts, " and ")
+	}
+	return aliasPage(title, alias, contents...)
+}
+
+// TestBacklinkIndexIncrementalMatchesRebuild applies random sequences of
+// add, update, remove and rename operations and checks after each one that
+// the index equals one rebuilt from the surviving pages
+func TestBacklinkIndexIncrementalMatchesRebuild(t *testing.T) {
+	for seed := int64(1); seed <= 200; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		idx := NewBacklinkIndex()
+		pages := map[string]*Page{}
+		var history []string
+		
+		for step := 0; step < 30; step++ {
+			title := randomNames[rng.Intn(len(randomNames))]
+			key := PageKey(title)
+			
+			switch op := rng.Intn(4); {
+			case op == 0 || len(pages) == 0:
+				page := randomPage(rng, title)
+				idx.AddPage(page)
+				pages[key] = page
+				history = append(history, fmt.Sprintf("add %q alias=%q", title, page.Properties["alias"]))
+			case op == 1:
+				page := randomPage(rng, title)
+				idx.UpdatePage(page)
+				pages[key] = page
+				history = append(history, fmt.Sprintf("update %q alias=%q", title, page.Properties["alias"]))
+			case op == 2:
+				idx.RemovePage(title)
+				delete(pages, key)
+				history = append(history, fmt.Sprintf("remove %q", title))
+			default:
+				newTitle := randomNames[rng.Intn(len(randomNames))]
+				old, exists := pages[key]
+				if !exists {
+					continue
+				}
+				page := aliasPage(newTitle, old.Properties["alias"])
+				page.Blocks, page.AllBlocks = old.Blocks, old.AllBlocks
+				// Renaming onto another page replaces it
+				idx.RemovePage(newTitle)
+				delete(pages, PageKey(newTitle))
+				idx.RenamePage(title, page)
+				delete(pages, key)
+				pages[PageKey(newTitle)] = page
+				history = append(history, fmt.Sprintf("rename %q -> %q", title, newTitle))
+			}
+			
+			got := snapshotIndex(idx, pages)
+			want := snapshotIndex(rebuildIndex(pages, rng), pages)
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("seed %d: index differs from rebuild after:\n  %s\ngot:  %+v\nwant: %+v",
+					seed, strings.Join(history, "\n  "), got, want)
+			}
+		}
+	}
+}
+
+func TestAddPageTwiceDoesNotDuplicateReferences(t *testing.T) {
+	idx := NewBacklinkIndex()
+	page := aliasPage("Notes", "", "Read [[Book]]", "Also [[Book]]")
+	idx.AddPage(page)
+	idx.AddPage(page)
+	
+	if refs := idx.GetBacklinks("Book")[PageKey("Notes")]; len(refs) != 2 {
+		t.Errorf("Expected 2 references after adding twice, got %d", len(refs))
+	}
+}
+
+func TestUpdatePageDiffsReferences(t *testing.T) {
+	idx := NewBacklinkIndex()
+	idx.AddPage(aliasPage("Notes", "", "Read [[Book]]", "Met [[Alice]]"))
+	aliceRefs := idx.GetBacklinks("Alice")[PageKey("Notes")]
+	
+	idx.UpdatePage(aliasPage("Notes", "", "Read [[Paper]]", "Met [[Alice]]"))
+	
+	if _, ok := idx.BackwardLinks[PageKey("Book")]; ok {
+		t.Error("Removed link should leave no backward entry")
+	}
+	if _, ok := idx.Titles[PageKey("Book")]; ok {
+		t.Error("Unreferenced target should lose its title")
+	}
+	if refs := idx.GetBacklinks("Paper")[PageKey("Notes")]; len(refs) != 1 {
+		t.Errorf("Expected new link to Paper, got %v", refs)
+	}
+	// Unchanged references are left in place
+	if refs := idx.GetBacklinks("Alice")[PageKey("Notes")]; &refs[0] != &aliceRefs[0] {
+		t.Error("Unchanged references should not be rewritten")
+	}
+}
+
+func TestRemovePageKeepsIncomingLinks(t *testing.T) {
+	idx := NewBacklinkIndex()
+	idx.AddPage(aliasPage("Syntax Tree", "AST", "Links to [[Parser]]"))
+	idx.AddPage(aliasPage("Notes", "", "Built the [[AST]]", "Read [[Syntax Tree]]"))
+	
+	idx.RemovePage("syntax tree")
+	
+	if _, ok := idx.ForwardLinks[PageKey("Syntax Tree")]; ok {
+		t.Error("Removed page should have no forward links")
+	}
+	if _, ok := idx.BackwardLinks[PageKey("Parser")]; ok {
+		t.Error("Links made by the removed page should be gone")
+	}
+	// Without its page the alias is just another name
+	if refs := idx.GetBacklinks("AST")[PageKey("Notes")]; len(refs) != 1 {
+		t.Errorf("Expected link to AST to stand alone, got %v", refs)
+	}
+	if refs := idx.GetBacklinks("Syntax Tree")[PageKey("Notes")]; len(refs) != 1 {
+		t.Errorf("Expected link to Syntax Tree to remain, got %v", refs)
+	}
+}
+
+func TestRenamePage(t *testing.T) {
+	idx := NewBacklinkIndex()
+	idx.AddPage(aliasPage("Draft", "", "About [[Topic]]"))
+	idx.AddPage(aliasPage("Notes", "", "See [[Draft]]"))
+	
+	idx.RenamePage("Draft", aliasPage("Final", "", "About [[Topic]]"))
+	
+	if refs := idx.GetBacklinks("Topic"); len(refs) != 1 || len(refs[PageKey("Final")]) != 1 {
+		t.Errorf("Expected Topic to be linked from Final only, got %v", refs)
+	}
+	if refs := idx.GetBacklinks("Topic")[PageKey("Final")]; refs[0].PageName != "Final" {
+		t.Errorf("Expected references to carry the new title, got %q", refs[0].PageName)
+	}
+	// Links written as [[Draft]] still name the old page
+	if refs := idx.GetBacklinks("Draft")[PageKey("Notes")]; len(refs) != 1 {
+		t.Errorf("Expected the old name to keep its incoming link, got %v", refs)
+	}
+	if idx.Title("Final") != "Final" {
+		t.Errorf("Title(Final) = %q", idx.Title("Final"))
+	}
+}
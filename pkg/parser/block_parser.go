@@ -23,6 +23,7 @@
 package parser
 
 import (
+	"sort"
 	"strings"
 	"time"
 )
@@ -46,19 +47,27 @@ type Block struct {
 	Properties  map[string]string   // key:: value properties
 	Tags        []string            // #tag references
 	References  []string            // [[page]] references
+	
+	keywords *TodoKeywords // TODO keywords the block was parsed with, nil for the defaults
 }
 
 // Page represents a complete Logseq page
 type Page struct {
-	Name        string
-	Title       string              // Page title (usually from first header)
-	Blocks      []*Block            // Ordered top-level blocks
-	AllBlocks   []*Block            // Flat list of all blocks for easy searching
-	Properties  map[string]string   // Page-level properties (tags::, alias::, etc.)
+	Name          string
+	Title         string            // Page name: title:: property, else filename or header
+	Path          string            // File the page was read from, empty if parsed from a string
+	Hash          string            // ContentHash of the file as read, empty if parsed from a string
+	Encoding      FileEncoding      // Character set, BOM and line endings of the file, restored when it's written
+	Blocks        []*Block          // Ordered top-level blocks
+	AllBlocks     []*Block          // Flat list of all blocks for easy searching
+	Properties    map[string]string // Page-level properties (tags::, alias::, etc.)
+	PropertyOrder []string          // Page property keys in file order
+	Header        string            // "# Header" line at the top of the page, empty if it has none
+	IsJournal     bool              // Daily journal page rather than a regular page
 	
 	// Metadata
-	Created     time.Time
-	Modified    time.Time
+	Created       time.Time
+	Modified      time.Time
 }
 
 
@@ -103,11 +112,19 @@ func (b *Block) updateContent() {
 	// Remove TODO prefix if present before parsing segments
 	contentForSegments := b.Content
 	if b.TodoInfo.TodoState != TodoStateNone || b.TodoInfo.CheckboxState != CheckboxNone {
-		contentForSegments = RemoveTodoPrefix(b.Content)
+		contentForSegments = b.todoKeywords().RemoveTodoPrefix(b.Content)
 	}
 	b.Segments = ParseMarkdownSegments(contentForSegments)
 }
 
+// todoKeywords returns the TODO keywords the block was parsed with
+func (b *Block) todoKeywords() *TodoKeywords {
+	if b.keywords == nil {
+		return DefaultTodoKeywords
+	}
+	return b.keywords
+}
+
 // SetContent updates the block's content and reparses it
 func (b *Block) SetContent(newContent string) {
 	b.Content = newContent
@@ -115,12 +132,17 @@ func (b *Block) SetContent(newContent string) {
 	// Clear cached HTML so it gets regenerated
 	b.HTMLContent = ""
 	
-	// Update lines by re-parsing them
+	// Update lines by re-parsing them with the block's keywords. The first
+	// line is parsed as the bullet it's written out as, so it keeps its
+	// TODO state.
+	keywords := b.todoKeywords()
 	lines := strings.Split(newContent, "\n")
 	b.Lines = make([]Line, len(lines))
 	for i, line := range lines {
-		// Re-parse each line to get updated TODO info and references
-		b.Lines[i] = ParseLine(i+1, line)
+		if i == 0 {
+			line = "- " + line
+		}
+		b.Lines[i] = keywords.ParseLine(i+1, line)
 	}
 	
 	// Update all metadata from the parsed lines
@@ -143,6 +165,45 @@ func (p *Page) GetAllBlocks() []*Block {
 	return allBlocks
 }
 
+// PropertyKeys returns the page property keys in file order, followed by
+// any properties added since parsing in sorted order
+func (p *Page) PropertyKeys() []string {
+	keys := make([]string, 0, len(p.Properties))
+	for _, key := range p.PropertyOrder {
+		if _, ok := p.Properties[key]; ok && !containsString(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	
+	var added []string
+	for key := range p.Properties {
+		if !containsString(keys, key) {
+			added = append(added, key)
+		}
+	}
+	sort.Strings(added)
+	return append(keys, added...)
+}
+
+// Aliases returns the page's alias:: names. Values may be comma separated
+// and written as plain names or [[links]].
+func (p *Page) Aliases() []string {
+	value, ok := p.Properties["alias"]
+	if !ok {
+		return nil
+	}
+	
+	var aliases []string
+	for _, part := range strings.Split(value, ",") {
+		alias := strings.TrimSpace(part)
+		alias = strings.TrimSuffix(strings.TrimPrefix(alias, "[["), "]]")
+		if alias = strings.TrimSpace(alias); alias != "" {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
 // AddChild adds a child block and maintains relationships
 func (b *Block) AddChild(child *Block) {
 	child.Parent = b
@@ -166,7 +227,7 @@ func (b *Block) RenderHTML() string {
 		// If there's TODO info, render it specially
 		if b.TodoInfo.TodoState != TodoStateNone || b.TodoInfo.CheckboxState != CheckboxNone {
 			// Remove the TODO/checkbox prefix for clean rendering
-			contentWithoutPrefix := RemoveTodoPrefix(content)
+			contentWithoutPrefix := b.todoKeywords().RemoveTodoPrefix(content)
 			b.HTMLContent = RenderToHTML(contentWithoutPrefix)
 		} else {
 			b.HTMLContent = RenderToHTML(content)
@@ -30,21 +30,24 @@ func TestCalculateIndentLevel(t *testing.T) {
 	tests := []struct {
 		name     string
 		input    string
+		width    int
 		expected int
 	}{
-		{"no indent", "- Item", 0},
-		{"2 spaces", "  - Item", 1},
-		{"4 spaces", "    - Item", 2},
-		{"6 spaces", "      - Item", 3},
-		{"tab treated as spaces", "\t- Item", 0}, // tabs not counted
-		{"mixed content", "  some text", 1},
+		{"no indent", "- Item", 2, 0},
+		{"2 spaces", "  - Item", 2, 1},
+		{"4 spaces", "    - Item", 2, 2},
+		{"6 spaces", "      - Item", 2, 3},
+		{"tab treated as spaces", "\t- Item", 2, 0}, // tabs not counted
+		{"mixed content", "  some text", 2, 1},
+		{"4 spaces at width 4", "    - Item", 4, 1},
+		{"8 spaces at width 4", "        - Item", 4, 2},
 	}
 	
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := calculateIndentLevel(tt.input)
+			result := calculateIndentLevel(tt.input, tt.width)
 			if result != tt.expected {
-				t.Errorf("calculateIndentLevel(%q) = %d, want %d", tt.input, result, tt.expected)
+				t.Errorf("calculateIndentLevel(%q, %d) = %d, want %d", tt.input, tt.width, result, tt.expected)
 			}
 		})
 	}
@@ -215,4 +218,68 @@ func TestGetAllBlocks(t *testing.T) {
 			t.Errorf("Block ID %s not found in GetAllBlocks result", id)
 		}
 	}
-}
\ No newline at end of file
+}
+
+// TestBlockContinuationLines checks text indented under a block is part of
+// it, and text that isn't indented under one is left out
+func TestBlockContinuationLines(t *testing.T) {
+	input := `- Parent
+  status:: open
+  SCHEDULED: <2025-01-17 Fri>
+  - Child
+    second line of child
+- Sibling
+Loose text`
+
+	result, err := ParseFile(input)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	blocks := result.Page.Blocks
+	if len(blocks) != 2 {
+		t.Fatalf("Top-level blocks = %d, want 2", len(blocks))
+	}
+
+	parent := blocks[0]
+	if want := "Parent\nstatus:: open\nSCHEDULED: <2025-01-17 Fri>"; parent.Content != want {
+		t.Errorf("Parent content = %q, want %q", parent.Content, want)
+	}
+	if parent.Properties["status"] != "open" {
+		t.Errorf("Parent status = %q, want %q", parent.Properties["status"], "open")
+	}
+	if len(parent.Children) != 1 {
+		t.Fatalf("Parent children = %d, want 1", len(parent.Children))
+	}
+
+	// Lines under a child belong to the child, not its parent
+	child := parent.Children[0]
+	if want := "Child\nsecond line of child"; child.Content != want {
+		t.Errorf("Child content = %q, want %q", child.Content, want)
+	}
+	if blocks[1].Content != "Sibling" {
+		t.Errorf("Sibling content = %q, want %q (loose text isn't indented under it)", blocks[1].Content, "Sibling")
+	}
+}
+
+// TestBuildBlockTreeContinuation checks continuation lines are only added
+// to a block when they are indented deeper than it
+func TestBuildBlockTreeContinuation(t *testing.T) {
+	contexts := []parseContext{
+		{Line{Number: 1, Type: TypeBlock, Content: "A"}, 0},
+		{Line{Number: 2, Type: TypeText, Content: "more of A"}, 1},
+		{Line{Number: 3, Type: TypeEmpty}, 0},
+		{Line{Number: 4, Type: TypeText, Content: "and more"}, 2},
+		{Line{Number: 5, Type: TypeText, Content: "not part of A"}, 0},
+	}
+
+	blocks := BuildBlockTree(contexts)
+	if len(blocks) != 1 {
+		t.Fatalf("Expected 1 block, got %d", len(blocks))
+	}
+	if want := "A\nmore of A\nand more"; blocks[0].Content != want {
+		t.Errorf("Content = %q, want %q", blocks[0].Content, want)
+	}
+	if len(blocks[0].Lines) != 3 {
+		t.Errorf("Lines = %d, want 3", len(blocks[0].Lines))
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/rehanog/seq2b/internal/storage"
 )
 
 func TestParseDirectoryWithCache_Basic(t *testing.T) {
@@ -73,7 +75,7 @@ func TestParseDirectoryWithCache_FileModification(t *testing.T) {
 	}
 	
 	// Create initial page
-	pagePath := filepath.Join(pagesDir, "test.md")
+	pagePath := filepath.Join(pagesDir, "test-page.md")
 	originalContent := "# Test Page\n- Original content"
 	if err := os.WriteFile(pagePath, []byte(originalContent), 0644); err != nil {
 		t.Fatal(err)
@@ -85,7 +87,7 @@ func TestParseDirectoryWithCache_FileModification(t *testing.T) {
 		t.Fatal(err)
 	}
 	
-	page1 := result1.Pages["Test Page"]
+	page1 := result1.GetPage("Test Page")
 	if page1 == nil {
 		t.Fatal("Page not found in first parse")
 	}
@@ -115,7 +117,7 @@ func TestParseDirectoryWithCache_FileModification(t *testing.T) {
 		t.Fatal(err)
 	}
 	
-	page2 := result2.Pages["Test Page"]
+	page2 := result2.GetPage("Test Page")
 	if page2 == nil {
 		t.Fatal("Page not found in second parse")
 	}
@@ -239,4 +241,48 @@ func TestParseDirectoryWithCache_CacheFallback(t *testing.T) {
 	} else if len(result.Pages) != 1 {
 		t.Errorf("Expected 1 page even with cache issues, got %d", len(result.Pages))
 	}
-}
\ No newline at end of file
+}
+
+// TestParseDirectoryWithCache_SharedCacheDir checks directories parsed
+// with the same CacheDir, such as pages/ and journals/, keep separate
+// caches that stay valid when the other is parsed
+func TestParseDirectoryWithCache_SharedCacheDir(t *testing.T) {
+	libDir := t.TempDir()
+	writePages(t, libDir, map[string]string{
+		"pages/x.md":    "- Page x",
+		"journals/x.md": "- Journal x",
+	})
+	pagesDir := filepath.Join(libDir, "pages")
+	journalsDir := filepath.Join(libDir, "journals")
+
+	opts := DefaultParseOptions()
+	opts.UseCache = true
+	opts.CacheDir = filepath.Join(t.TempDir(), "cache")
+	if opts.cacheDir(pagesDir) == opts.cacheDir(journalsDir) {
+		t.Fatalf("pages and journals share cache directory %s", opts.cacheDir(pagesDir))
+	}
+
+	for round := 1; round <= 2; round++ {
+		for _, tt := range []struct{ dir, want string }{
+			{pagesDir, "Page x"},
+			{journalsDir, "Journal x"},
+		} {
+			result, err := ParseDirectoryWithOptions(tt.dir, opts)
+			if err != nil {
+				t.Fatalf("round %d: ParseDirectoryWithOptions(%s) error = %v", round, tt.dir, err)
+			}
+			if page := result.GetPage("x"); page == nil || len(page.Blocks) != 1 || page.Blocks[0].Content != tt.want {
+				t.Errorf("round %d: page x in %s = %+v, want %q", round, tt.dir, page, tt.want)
+			}
+		}
+	}
+
+	cache, err := storage.NewCacheManagerIn(pagesDir, opts.cacheDir(pagesDir))
+	if err != nil {
+		t.Fatalf("NewCacheManagerIn() error = %v", err)
+	}
+	defer cache.Close()
+	if valid, err := cache.ValidateCache(); err != nil || !valid {
+		t.Errorf("pages cache valid = %v, %v after parsing journals; want true", valid, err)
+	}
+}
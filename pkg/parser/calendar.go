@@ -0,0 +1,152 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"regexp"
+	"sort"
+	"time"
+)
+
+// DateKind says how a task was tied to a date
+type DateKind string
+
+const (
+	DateScheduled DateKind = "scheduled" // SCHEDULED: <2025-01-20 Mon>
+	DateDeadline  DateKind = "deadline"  // DEADLINE: <2025-01-20 Mon>
+	DateJournal   DateKind = "journal"   // Task written on a journal page
+	DateReference DateKind = "reference" // Task linking to a [[date]] page
+)
+
+// DatedTask is a TODO block associated with a calendar date
+type DatedTask struct {
+	PageName string
+	Block    *Block
+	Date     time.Time
+	Kind     DateKind
+}
+
+// Matches SCHEDULED/DEADLINE timestamps; the weekday and time are optional
+var plannedDatePattern = regexp.MustCompile(`(?m)^(SCHEDULED|DEADLINE): <(\d{4}-\d{2}-\d{2})[^>]*>`)
+
+// ExtractPlannedDates returns the SCHEDULED and DEADLINE dates in block content
+func ExtractPlannedDates(content string) map[DateKind]time.Time {
+	dates := make(map[DateKind]time.Time)
+	for _, match := range plannedDatePattern.FindAllStringSubmatch(content, -1) {
+		date, err := time.Parse("2006-01-02", match[2])
+		if err != nil {
+			continue
+		}
+		if match[1] == "SCHEDULED" {
+			dates[DateScheduled] = date
+		} else {
+			dates[DateDeadline] = date
+		}
+	}
+	return dates
+}
+
+// JournalPagesByDate indexes journal pages by ISO date (2025-01-15). Pages
+// count as journals if they are marked IsJournal or their title is a date.
+func JournalPagesByDate(pages map[string]*Page, format JournalFormat) map[string]*Page {
+	journals := make(map[string]*Page)
+	for _, page := range pages {
+		date, err := format.ParseTitle(page.Title)
+		if err != nil {
+			continue
+		}
+		key := FormatDateISO(date)
+		// Prefer the page from journals/ if a date exists twice
+		if existing, ok := journals[key]; ok && existing.IsJournal && !page.IsJournal {
+			continue
+		}
+		journals[key] = page
+	}
+	return journals
+}
+
+// FindDatedTasks returns TODO blocks dated within [start, end], sorted by
+// date then page. A task is dated by its SCHEDULED or DEADLINE line, else by
+// the journal page it is written on, else by a [[date]] link in its content.
+func FindDatedTasks(pages map[string]*Page, format JournalFormat, start, end time.Time) []DatedTask {
+	var tasks []DatedTask
+	
+	for _, page := range pages {
+		pageDate, pageErr := format.ParseTitle(page.Title)
+		
+		for _, block := range page.AllBlocks {
+			if block.TodoInfo.TodoState == TodoStateNone && block.TodoInfo.CheckboxState == CheckboxNone {
+				continue
+			}
+			
+			var dated []DatedTask
+			for kind, date := range ExtractPlannedDates(block.Content) {
+				dated = append(dated, DatedTask{PageName: page.Title, Block: block, Date: date, Kind: kind})
+			}
+			if len(dated) == 0 && pageErr == nil {
+				dated = append(dated, DatedTask{PageName: page.Title, Block: block, Date: pageDate, Kind: DateJournal})
+			}
+			if len(dated) == 0 {
+				for _, link := range ExtractPageLinks(block.Content) {
+					if date, err := format.ParseTitle(link); err == nil {
+						dated = append(dated, DatedTask{PageName: page.Title, Block: block, Date: date, Kind: DateReference})
+					}
+				}
+			}
+			
+			for _, task := range dated {
+				if IsWithinDateRange(task.Date, start, end) {
+					tasks = append(tasks, task)
+				}
+			}
+		}
+	}
+	
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if !tasks[i].Date.Equal(tasks[j].Date) {
+			return tasks[i].Date.Before(tasks[j].Date)
+		}
+		if tasks[i].PageName != tasks[j].PageName {
+			return tasks[i].PageName < tasks[j].PageName
+		}
+		if tasks[i].Block.ID != tasks[j].Block.ID {
+			return tasks[i].Block.ID < tasks[j].Block.ID
+		}
+		return tasks[i].Kind < tasks[j].Kind
+	})
+	return tasks
+}
+
+// WeekStart returns the Monday of the ISO week containing date
+func WeekStart(date time.Time) time.Time {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// ISOWeekStart returns the Monday of an ISO year/week
+func ISOWeekStart(year, week int) time.Time {
+	// January 4th is always in week 1
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	return WeekStart(jan4).AddDate(0, 0, 7*(week-1))
+}
\ No newline at end of file
@@ -0,0 +1,138 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExtractPlannedDates(t *testing.T) {
+	content := "TODO Write report\nSCHEDULED: <2025-01-17 Fri>\nDEADLINE: <2025-01-20 Mon 10:00>"
+	dates := ExtractPlannedDates(content)
+
+	if got := dates[DateScheduled]; !got.Equal(time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("SCHEDULED = %v, want 2025-01-17", got)
+	}
+	if got := dates[DateDeadline]; !got.Equal(time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("DEADLINE = %v, want 2025-01-20", got)
+	}
+	if len(ExtractPlannedDates("TODO nothing planned")) != 0 {
+		t.Error("Expected no dates for unplanned task")
+	}
+}
+
+// calendarPages builds a small vault with journal and regular pages
+func calendarPages(t *testing.T) map[string]*Page {
+	t.Helper()
+	files := map[string]string{
+		"Jan 15th, 2025": "- TODO Journal task\n- Just a note",
+		"Jan 20th, 2025": "- DONE Finished task",
+		"Project":        "- TODO Planned task\n  SCHEDULED: <2025-01-17 Fri>\n- TODO Linked task for [[Jan 16th, 2025]]\n- TODO Undated task",
+	}
+	pages := make(map[string]*Page)
+	for title, content := range files {
+		result, err := ParseFile(content)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", title, err)
+		}
+		result.Page.Title = title
+		pages[title] = result.Page
+	}
+	return pages
+}
+
+func TestJournalPagesByDate(t *testing.T) {
+	journals := JournalPagesByDate(calendarPages(t), DefaultJournalFormat)
+
+	if len(journals) != 2 {
+		t.Fatalf("Expected 2 journals, got %d", len(journals))
+	}
+	if page := journals["2025-01-15"]; page == nil || page.Title != "Jan 15th, 2025" {
+		t.Errorf("Journal for 2025-01-15 not found")
+	}
+}
+
+func TestFindDatedTasks(t *testing.T) {
+	pages := calendarPages(t)
+	start := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC)
+
+	tasks := FindDatedTasks(pages, DefaultJournalFormat, start, end)
+
+	want := []struct {
+		date string
+		kind DateKind
+	}{
+		{"2025-01-15", DateJournal},
+		{"2025-01-16", DateReference},
+		{"2025-01-17", DateScheduled},
+	}
+	if len(tasks) != len(want) {
+		t.Fatalf("Expected %d tasks, got %d: %v", len(want), len(tasks), tasks)
+	}
+	for i, w := range want {
+		if got := FormatDateISO(tasks[i].Date); got != w.date || tasks[i].Kind != w.kind {
+			t.Errorf("task %d = %s/%s, want %s/%s", i, got, tasks[i].Kind, w.date, w.kind)
+		}
+	}
+
+	// The DONE task on Jan 20th falls outside the range
+	tasks = FindDatedTasks(pages, DefaultJournalFormat, end, end.AddDate(0, 0, 7))
+	if len(tasks) != 1 || tasks[0].PageName != "Jan 20th, 2025" {
+		t.Errorf("Expected only the Jan 20th task, got %v", tasks)
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	tests := []struct {
+		date time.Time
+		want string
+	}{
+		{time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), "2025-01-13"},
+		{time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC), "2025-01-13"},
+		{time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC), "2025-01-13"},
+	}
+	for _, tt := range tests {
+		if got := FormatDateISO(WeekStart(tt.date)); got != tt.want {
+			t.Errorf("WeekStart(%v) = %s, want %s", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestISOWeekStart(t *testing.T) {
+	tests := []struct {
+		year, week int
+		want       string
+	}{
+		{2025, 1, "2024-12-30"},
+		{2025, 3, "2025-01-13"},
+		{2026, 1, "2025-12-29"},
+		{2020, 53, "2020-12-28"},
+	}
+	for _, tt := range tests {
+		if got := FormatDateISO(ISOWeekStart(tt.year, tt.week)); got != tt.want {
+			t.Errorf("ISOWeekStart(%d, %d) = %s, want %s", tt.year, tt.week, got, tt.want)
+		}
+	}
+}
\ No newline at end of file
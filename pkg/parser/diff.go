@@ -0,0 +1,408 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"sort"
+	"strings"
+)
+
+// BlockChangeType says how a block differs between two versions of a page
+type BlockChangeType string
+
+const (
+	BlockAdded   BlockChangeType = "added"
+	BlockRemoved BlockChangeType = "removed"
+	BlockEdited  BlockChangeType = "edited"
+	BlockMoved   BlockChangeType = "moved"
+)
+
+// minSimilarity is how alike two blocks' content must be for one to be
+// taken as an edit of the other
+const minSimilarity = 0.5
+
+// PageDiff is the difference between two versions of a page
+type PageDiff struct {
+	Properties []PropertyChange // Page properties added, removed or changed, by key
+	Blocks     []BlockChange    // Blocks that differ, in the order of the newer version
+}
+
+// PropertyChange is a page property whose value differs. Old is empty for
+// an added property and New for a removed one.
+type PropertyChange struct {
+	Key string
+	Old string
+	New string
+}
+
+// BlockChange is a block that differs between two versions of a page.
+// Paths are the child indexes leading to the block in each version's tree;
+// OldPath is nil for an added block and NewPath for a removed one.
+type BlockChange struct {
+	Type       BlockChangeType
+	Moved      bool // An edited block was moved as well
+	OldPath    []int
+	NewPath    []int
+	OldContent string
+	NewContent string
+}
+
+// diffBlock is a block with its place in a version of a page
+type diffBlock struct {
+	block *Block
+	path  []int
+	index int // Position in document order
+}
+
+// DiffPages compares two versions of a page block by block. Blocks are
+// matched by id:: property, then by identical content, then by similar
+// content or by taking the same place in the tree, so an edited block is
+// reported as edited rather than as one block removed and another added,
+// and a block with a new parent or a new place among its siblings is
+// reported as moved. Unchanged blocks are left out.
+func DiffPages(oldPage, newPage *Page) *PageDiff {
+	oldBlocks := flattenDiffBlocks(oldPage.Blocks, nil, nil)
+	newBlocks := flattenDiffBlocks(newPage.Blocks, nil, nil)
+	matches := matchDiffBlocks(oldBlocks, newBlocks)
+	moved := movedBlocks(oldBlocks, newBlocks, matches)
+	
+	diff := &PageDiff{Properties: diffProperties(oldPage, newPage)}
+	matchedOld := make(map[*Block]*Block, len(matches))
+	for oldBlock, newBlock := range matches {
+		matchedOld[newBlock] = oldBlock
+	}
+	newIndex := make(map[*Block]int, len(newBlocks))
+	for _, b := range newBlocks {
+		newIndex[b.block] = b.index
+	}
+	oldByBlock := make(map[*Block]diffBlock, len(oldBlocks))
+	for _, b := range oldBlocks {
+		oldByBlock[b.block] = b
+	}
+	
+	// Changes are ordered by where they fall in the newer version; a
+	// removed block comes after the nearest block before it that's still
+	// there
+	type orderedChange struct {
+		change BlockChange
+		anchor int
+		sub    int
+	}
+	var changes []orderedChange
+	for _, b := range newBlocks {
+		oldBlock, found := matchedOld[b.block]
+		if !found {
+			changes = append(changes, orderedChange{
+				change: BlockChange{Type: BlockAdded, NewPath: b.path, NewContent: b.block.Content},
+				anchor: b.index,
+			})
+			continue
+		}
+		edited := oldBlock.Content != b.block.Content
+		if !edited && !moved[oldBlock] {
+			continue
+		}
+		change := BlockChange{
+			Type:       BlockMoved,
+			OldPath:    oldByBlock[oldBlock].path,
+			NewPath:    b.path,
+			OldContent: oldBlock.Content,
+			NewContent: b.block.Content,
+		}
+		if edited {
+			change.Type = BlockEdited
+			change.Moved = moved[oldBlock]
+		}
+		changes = append(changes, orderedChange{change: change, anchor: b.index})
+	}
+	anchor := -1
+	for _, b := range oldBlocks {
+		if newBlock, found := matches[b.block]; found {
+			anchor = newIndex[newBlock]
+			continue
+		}
+		changes = append(changes, orderedChange{
+			change: BlockChange{Type: BlockRemoved, OldPath: b.path, OldContent: b.block.Content},
+			anchor: anchor,
+			sub:    b.index + 1,
+		})
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].anchor != changes[j].anchor {
+			return changes[i].anchor < changes[j].anchor
+		}
+		return changes[i].sub < changes[j].sub
+	})
+	for _, c := range changes {
+		diff.Blocks = append(diff.Blocks, c.change)
+	}
+	return diff
+}
+
+// MatchBlocks pairs each block of an older version of a page with the
+// block it became in a newer one, as DiffPages does. Blocks that were
+// removed are left out.
+func MatchBlocks(oldPage, newPage *Page) map[*Block]*Block {
+	return matchDiffBlocks(flattenDiffBlocks(oldPage.Blocks, nil, nil), flattenDiffBlocks(newPage.Blocks, nil, nil))
+}
+
+// flattenDiffBlocks lists blocks in document order with their paths
+func flattenDiffBlocks(blocks []*Block, prefix []int, out []diffBlock) []diffBlock {
+	for i, block := range blocks {
+		path := make([]int, len(prefix)+1)
+		copy(path, prefix)
+		path[len(prefix)] = i
+		out = append(out, diffBlock{block: block, path: path, index: len(out)})
+		out = flattenDiffBlocks(block.Children, path, out)
+	}
+	return out
+}
+
+// matchDiffBlocks pairs old blocks with new ones, most certain matches first
+func matchDiffBlocks(oldBlocks, newBlocks []diffBlock) map[*Block]*Block {
+	matches := make(map[*Block]*Block)
+	matchedNew := make(map[*Block]bool)
+	pair := func(oldBlock, newBlock *Block) {
+		matches[oldBlock] = newBlock
+		matchedNew[newBlock] = true
+	}
+	
+	// The same id:: is the same block, whatever happened to it
+	byID := make(map[string]*Block)
+	for _, b := range newBlocks {
+		if b.block.BlockID != "" {
+			byID[b.block.BlockID] = b.block
+		}
+	}
+	for _, b := range oldBlocks {
+		if newBlock, found := byID[b.block.BlockID]; found && b.block.BlockID != "" && !matchedNew[newBlock] {
+			pair(b.block, newBlock)
+		}
+	}
+	
+	// Identical content, taking repeated content in order
+	byContent := make(map[string][]*Block)
+	for _, b := range newBlocks {
+		if !matchedNew[b.block] {
+			byContent[b.block.Content] = append(byContent[b.block.Content], b.block)
+		}
+	}
+	for _, b := range oldBlocks {
+		if _, found := matches[b.block]; found {
+			continue
+		}
+		if candidates := byContent[b.block.Content]; len(candidates) > 0 {
+			pair(b.block, candidates[0])
+			byContent[b.block.Content] = candidates[1:]
+		}
+	}
+	
+	// Similar content, preferring the closest block when several are as
+	// alike
+	for _, n := range newBlocks {
+		if matchedNew[n.block] {
+			continue
+		}
+		var best *Block
+		bestScore, bestDistance := 0.0, 0
+		for _, o := range oldBlocks {
+			if _, found := matches[o.block]; found {
+				continue
+			}
+			score := contentSimilarity(o.block.Content, n.block.Content)
+			if score < minSimilarity {
+				continue
+			}
+			distance := o.index - n.index
+			if distance < 0 {
+				distance = -distance
+			}
+			if best == nil || score > bestScore || (score == bestScore && distance < bestDistance) {
+				best, bestScore, bestDistance = o.block, score, distance
+			}
+		}
+		if best != nil {
+			pair(best, n.block)
+		}
+	}
+	
+	// Whatever is left in the same place under the same parent was
+	// rewritten in place
+	for _, o := range oldBlocks {
+		if _, found := matches[o.block]; found {
+			continue
+		}
+		var parent *Block
+		if o.block.Parent != nil {
+			var found bool
+			if parent, found = matches[o.block.Parent]; !found {
+				continue
+			}
+		}
+		for _, n := range newBlocks {
+			if !matchedNew[n.block] && n.block.Parent == parent && n.path[len(n.path)-1] == o.path[len(o.path)-1] {
+				pair(o.block, n.block)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// movedBlocks finds matched blocks that have a new parent, or that are out
+// of order with the siblings they had before. Among siblings the longest
+// run still in order stays put, so moving one block doesn't report all
+// the others as moved; where runs are as long, blocks that were also
+// edited are the ones taken to have moved.
+func movedBlocks(oldBlocks, newBlocks []diffBlock, matches map[*Block]*Block) map[*Block]bool {
+	moved := make(map[*Block]bool)
+	oldPosition := make(map[*Block]int, len(oldBlocks))
+	for _, b := range oldBlocks {
+		oldPosition[b.block] = b.path[len(b.path)-1]
+	}
+	matchedOld := make(map[*Block]*Block, len(matches))
+	for oldBlock, newBlock := range matches {
+		matchedOld[newBlock] = oldBlock
+		var parent *Block
+		if oldBlock.Parent != nil {
+			var found bool
+			if parent, found = matches[oldBlock.Parent]; !found {
+				moved[oldBlock] = true
+				continue
+			}
+		}
+		if parent != newBlock.Parent {
+			moved[oldBlock] = true
+		}
+	}
+	
+	// Siblings that kept their parent, grouped under it in new order
+	groups := make(map[*Block][]*Block)
+	for _, b := range newBlocks {
+		if oldBlock, found := matchedOld[b.block]; found && !moved[oldBlock] {
+			groups[b.block.Parent] = append(groups[b.block.Parent], oldBlock)
+		}
+	}
+	for _, siblings := range groups {
+		positions := make([]int, len(siblings))
+		weights := make([]int, len(siblings))
+		for i, oldBlock := range siblings {
+			positions[i] = oldPosition[oldBlock]
+			weights[i] = 1
+			if oldBlock.Content == matches[oldBlock].Content {
+				weights[i] = 2
+			}
+		}
+		inOrder := heaviestIncreasing(positions, weights)
+		for i, oldBlock := range siblings {
+			if !inOrder[i] {
+				moved[oldBlock] = true
+			}
+		}
+	}
+	return moved
+}
+
+// heaviestIncreasing marks the members of the increasing subsequence of
+// values with the largest total weight
+func heaviestIncreasing(values []int, weights []int) []bool {
+	best := make([]int, len(values))     // Weight of the best run ending at each value
+	previous := make([]int, len(values)) // Value before it in that run, -1 for none
+	last := -1
+	for i, v := range values {
+		best[i], previous[i] = weights[i], -1
+		for j := 0; j < i; j++ {
+			if values[j] < v && best[j]+weights[i] > best[i] {
+				best[i], previous[i] = best[j]+weights[i], j
+			}
+		}
+		if last < 0 || best[i] > best[last] {
+			last = i
+		}
+	}
+	in := make([]bool, len(values))
+	for i := last; i >= 0; i = previous[i] {
+		in[i] = true
+	}
+	return in
+}
+
+// contentSimilarity scores how alike two blocks' content is from 0 to 1:
+// the larger of the share of text they have in common at the start and
+// end, and the share of words they have in common. The first catches a
+// changed TODO keyword or a word inserted mid-sentence; the second
+// rearranged text.
+func contentSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	if a == "" || b == "" {
+		return 0
+	}
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	score := float64(prefix+suffix) / float64(max(len(a), len(b)))
+	
+	wordsA := make(map[string]bool)
+	for _, word := range strings.Fields(strings.ToLower(a)) {
+		wordsA[word] = true
+	}
+	wordsB := make(map[string]bool)
+	shared := 0
+	for _, word := range strings.Fields(strings.ToLower(b)) {
+		if !wordsB[word] {
+			wordsB[word] = true
+			if wordsA[word] {
+				shared++
+			}
+		}
+	}
+	if union := len(wordsA) + len(wordsB) - shared; union > 0 {
+		score = max(score, float64(shared)/float64(union))
+	}
+	return score
+}
+
+// diffProperties compares page properties in the newer version's order,
+// followed by those that were removed
+func diffProperties(oldPage, newPage *Page) []PropertyChange {
+	var changes []PropertyChange
+	for _, key := range newPage.PropertyKeys() {
+		oldValue, existed := oldPage.Properties[key]
+		if newValue := newPage.Properties[key]; !existed || oldValue != newValue {
+			changes = append(changes, PropertyChange{Key: key, Old: oldValue, New: newValue})
+		}
+	}
+	for _, key := range oldPage.PropertyKeys() {
+		if _, exists := newPage.Properties[key]; !exists {
+			changes = append(changes, PropertyChange{Key: key, Old: oldPage.Properties[key]})
+		}
+	}
+	return changes
+}
\ No newline at end of file
@@ -0,0 +1,158 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func parseDiffPage(t *testing.T, content string) *Page {
+	t.Helper()
+	result, err := ParseFile(content)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	return result.Page
+}
+
+func TestDiffPages(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want []BlockChange
+	}{
+		{
+			name: "unchanged",
+			old:  "- one\n- two\n",
+			new:  "- one\n- two\n",
+		},
+		{
+			name: "added",
+			old:  "- one\n- two\n",
+			new:  "- one\n- new\n- two\n",
+			want: []BlockChange{{Type: BlockAdded, NewPath: []int{1}, NewContent: "new"}},
+		},
+		{
+			name: "removed",
+			old:  "- one\n- gone\n- two\n",
+			new:  "- one\n- two\n",
+			want: []BlockChange{{Type: BlockRemoved, OldPath: []int{1}, OldContent: "gone"}},
+		},
+		{
+			name: "edited",
+			old:  "- TODO write the report\n- two\n",
+			new:  "- DONE write the report\n- two\n",
+			want: []BlockChange{{Type: BlockEdited, OldPath: []int{0}, NewPath: []int{0}, OldContent: "TODO write the report", NewContent: "DONE write the report"}},
+		},
+		{
+			name: "rewritten in place",
+			old:  "- one\n- something\n- two\n",
+			new:  "- one\n- else entirely\n- two\n",
+			want: []BlockChange{{Type: BlockEdited, OldPath: []int{1}, NewPath: []int{1}, OldContent: "something", NewContent: "else entirely"}},
+		},
+		{
+			name: "moved among siblings",
+			old:  "- one\n- two\n- three\n- four\n",
+			new:  "- one\n- three\n- four\n- two\n",
+			want: []BlockChange{{Type: BlockMoved, OldPath: []int{1}, NewPath: []int{3}, OldContent: "two", NewContent: "two"}},
+		},
+		{
+			name: "moved under another parent with children",
+			old:  "- one\n- two\n  - child\n",
+			new:  "- one\n  - two\n    - child\n",
+			want: []BlockChange{{Type: BlockMoved, OldPath: []int{1}, NewPath: []int{0, 0}, OldContent: "two", NewContent: "two"}},
+		},
+		{
+			name: "edited and moved",
+			old:  "- buy milk and bread\n- one\n",
+			new:  "- one\n- buy oat milk and bread\n",
+			want: []BlockChange{{Type: BlockEdited, Moved: true, OldPath: []int{0}, NewPath: []int{1}, OldContent: "buy milk and bread", NewContent: "buy oat milk and bread"}},
+		},
+		{
+			name: "matched by id",
+			old:  "- first\n  id:: 6650a1b2-0000-4000-8000-000000000001\n- second\n",
+			new:  "- second\n- completely different\n  id:: 6650a1b2-0000-4000-8000-000000000001\n",
+			want: []BlockChange{{
+				Type:       BlockEdited,
+				Moved:      true,
+				OldPath:    []int{0},
+				NewPath:    []int{1},
+				OldContent: "first\nid:: 6650a1b2-0000-4000-8000-000000000001",
+				NewContent: "completely different\nid:: 6650a1b2-0000-4000-8000-000000000001",
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := DiffPages(parseDiffPage(t, tt.old), parseDiffPage(t, tt.new))
+			if !reflect.DeepEqual(diff.Blocks, tt.want) {
+				t.Errorf("DiffPages blocks = %+v, want %+v", diff.Blocks, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffPagesOrder(t *testing.T) {
+	old := parseDiffPage(t, "- a\n- b\n- c\n")
+	updated := parseDiffPage(t, "- new first\n- a\n- c\n- new last\n")
+	diff := DiffPages(old, updated)
+	var got []string
+	for _, change := range diff.Blocks {
+		got = append(got, string(change.Type)+" "+change.OldContent+change.NewContent)
+	}
+	want := []string{"added new first", "removed b", "added new last"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffPages order = %q, want %q", got, want)
+	}
+}
+
+func TestDiffPagesProperties(t *testing.T) {
+	old := parseDiffPage(t, "status:: draft\ntags:: work\n\n- one\n")
+	updated := parseDiffPage(t, "status:: final\nowner:: me\n\n- one\n")
+	diff := DiffPages(old, updated)
+	want := []PropertyChange{
+		{Key: "status", Old: "draft", New: "final"},
+		{Key: "owner", New: "me"},
+		{Key: "tags", Old: "work"},
+	}
+	if !reflect.DeepEqual(diff.Properties, want) {
+		t.Errorf("DiffPages properties = %+v, want %+v", diff.Properties, want)
+	}
+	if len(diff.Blocks) != 0 {
+		t.Errorf("Unexpected block changes %+v", diff.Blocks)
+	}
+}
+
+func TestMatchBlocks(t *testing.T) {
+	old := parseDiffPage(t, "- one\n- two\n- three\n")
+	updated := parseDiffPage(t, "- three\n- one!\n")
+	matches := MatchBlocks(old, updated)
+	if matches[old.Blocks[0]] != updated.Blocks[1] || matches[old.Blocks[2]] != updated.Blocks[0] {
+		t.Errorf("Blocks matched wrongly: %v", matches)
+	}
+	if _, found := matches[old.Blocks[1]]; found {
+		t.Error("Removed block should not be matched")
+	}
+}
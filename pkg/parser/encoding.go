@@ -0,0 +1,122 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"bytes"
+	"strings"
+	
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// Character sets a page file may be written in. UTF-16 files are only
+// recognised by their byte order mark.
+const (
+	CharsetUTF8    = "utf-8"
+	CharsetUTF16LE = "utf-16le"
+	CharsetUTF16BE = "utf-16be"
+)
+
+// Byte order marks, as they appear at the start of a file
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// FileEncoding is how a page file's text is stored: its character set,
+// whether it starts with a byte order mark and whether its lines end in
+// \r\n. Pages are parsed from UTF-8 text with \n line endings and written
+// back as they were read, so files from Windows editors keep their style.
+// The zero value is UTF-8 without a BOM and with \n line endings.
+type FileEncoding struct {
+	Charset string // CharsetUTF8 if empty
+	BOM     bool   // Starts with a byte order mark; always set for UTF-16
+	CRLF    bool   // Lines end in \r\n
+}
+
+// DecodeText returns a file's content as UTF-8 text with \n line endings,
+// and how the file was encoded. A file with both kinds of line ending is
+// taken to use whichever most of its lines do.
+func DecodeText(content []byte) (string, FileEncoding) {
+	var enc FileEncoding
+	switch {
+	case bytes.HasPrefix(content, bomUTF8):
+		enc.BOM = true
+		content = content[len(bomUTF8):]
+	case bytes.HasPrefix(content, bomUTF16LE):
+		enc.Charset, enc.BOM = CharsetUTF16LE, true
+		content = decodeUTF16(content, unicode.LittleEndian)
+	case bytes.HasPrefix(content, bomUTF16BE):
+		enc.Charset, enc.BOM = CharsetUTF16BE, true
+		content = decodeUTF16(content, unicode.BigEndian)
+	}
+	
+	text := string(content)
+	if crlf := strings.Count(text, "\r\n"); crlf > 0 {
+		enc.CRLF = crlf*2 >= strings.Count(text, "\n")
+		text = strings.ReplaceAll(text, "\r\n", "\n")
+	}
+	return text, enc
+}
+
+// decodeUTF16 converts UTF-16 content, starting with its byte order mark,
+// to UTF-8. Invalid sequences become U+FFFD rather than failing the page.
+func decodeUTF16(content []byte, order unicode.Endianness) []byte {
+	decoded, err := utf16Encoding(order).NewDecoder().Bytes(content)
+	if err != nil {
+		return content
+	}
+	return decoded
+}
+
+// utf16Encoding is UTF-16 in the given byte order, with a byte order mark
+func utf16Encoding(order unicode.Endianness) encoding.Encoding {
+	return unicode.UTF16(order, unicode.ExpectBOM)
+}
+
+// EncodeText converts UTF-8 text with \n line endings back to the file's
+// encoding
+func (e FileEncoding) EncodeText(text string) []byte {
+	if e.CRLF {
+		text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\n", "\r\n")
+	}
+	
+	switch e.Charset {
+	case CharsetUTF16LE, CharsetUTF16BE:
+		order := unicode.LittleEndian
+		if e.Charset == CharsetUTF16BE {
+			order = unicode.BigEndian
+		}
+		// The encoder writes the byte order mark
+		if encoded, err := utf16Encoding(order).NewEncoder().Bytes([]byte(text)); err == nil {
+			return encoded
+		}
+		return []byte(text)
+	}
+	if e.BOM {
+		return append(append([]byte{}, bomUTF8...), text...)
+	}
+	return []byte(text)
+}
\ No newline at end of file
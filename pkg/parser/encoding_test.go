@@ -0,0 +1,195 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// utf16 encodes ASCII text as UTF-16 with a byte order mark
+func utf16(text string, bigEndian bool) []byte {
+	var out []byte
+	if bigEndian {
+		out = append(out, 0xFE, 0xFF)
+	} else {
+		out = append(out, 0xFF, 0xFE)
+	}
+	for _, r := range []byte(text) {
+		if bigEndian {
+			out = append(out, 0, r)
+		} else {
+			out = append(out, r, 0)
+		}
+	}
+	return out
+}
+
+func TestDecodeText(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		want    string
+		enc     FileEncoding
+	}{
+		{"lf", []byte("# A\n- b\n"), "# A\n- b\n", FileEncoding{}},
+		{"crlf", []byte("# A\r\n- b\r\n"), "# A\n- b\n", FileEncoding{CRLF: true}},
+		{"utf-8 bom", []byte("\xEF\xBB\xBF# A\n"), "# A\n", FileEncoding{BOM: true}},
+		{"utf-8 bom crlf", []byte("\xEF\xBB\xBF# A\r\n- b\r\n"), "# A\n- b\n", FileEncoding{BOM: true, CRLF: true}},
+		{"mostly crlf", []byte("a\r\nb\r\nc\n"), "a\nb\nc\n", FileEncoding{CRLF: true}},
+		{"mostly lf", []byte("a\r\nb\nc\n"), "a\nb\nc\n", FileEncoding{}},
+		{"lone cr kept", []byte("a\rb\n"), "a\rb\n", FileEncoding{}},
+		{"utf-16le", utf16("# A\r\n- b\r\n", false), "# A\n- b\n", FileEncoding{Charset: CharsetUTF16LE, BOM: true, CRLF: true}},
+		{"utf-16be", utf16("# A\n", true), "# A\n", FileEncoding{Charset: CharsetUTF16BE, BOM: true}},
+		{"empty", nil, "", FileEncoding{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, enc := DecodeText(tt.content)
+			if got != tt.want {
+				t.Errorf("DecodeText() text = %q, want %q", got, tt.want)
+			}
+			if enc != tt.enc {
+				t.Errorf("DecodeText() encoding = %+v, want %+v", enc, tt.enc)
+			}
+		})
+	}
+}
+
+// TestEncodeTextRoundTrip checks files come back byte for byte when their
+// lines all end the same way
+func TestEncodeTextRoundTrip(t *testing.T) {
+	files := map[string][]byte{
+		"lf":             []byte("# A\n- b\n  - c\n"),
+		"crlf":           []byte("# A\r\n- b\r\n  - c\r\n"),
+		"utf-8 bom":      []byte("\xEF\xBB\xBF# A\n- b\n"),
+		"utf-8 bom crlf": []byte("\xEF\xBB\xBF# A\r\n- b\r\n"),
+		"utf-16le crlf":  utf16("# A\r\n- b\r\n", false),
+		"utf-16be":       utf16("# A\n- b\n", true),
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			text, enc := DecodeText(content)
+			if got := enc.EncodeText(text); !bytes.Equal(got, content) {
+				t.Errorf("EncodeText() = %q, want %q", got, content)
+			}
+		})
+	}
+}
+
+func TestEncodeTextNormalisesLineEndings(t *testing.T) {
+	enc := FileEncoding{CRLF: true}
+	if got := string(enc.EncodeText("a\r\nb\nc\n")); got != "a\r\nb\r\nc\r\n" {
+		t.Errorf("EncodeText() = %q, want every line ending in \\r\\n", got)
+	}
+}
+
+// TestParseFileWindowsLineEndings checks a page saved by a Windows editor
+// parses as if it had been written with \n line endings
+func TestParseFileWindowsLineEndings(t *testing.T) {
+	content := "\xEF\xBB\xBF# Windows Page\r\n" +
+		"tags:: notes, windows\r\n" +
+		"\r\n" +
+		"- TODO First block\r\n" +
+		"  status:: open\r\n" +
+		"  - Child with [[Link]]\r\n" +
+		"- Second block\r\n"
+
+	result, err := ParseFile(content)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	page := result.Page
+
+	for _, line := range result.Lines {
+		if strings.ContainsAny(line.Content, "\r\uFEFF") {
+			t.Errorf("line content %q still has a \\r or BOM", line.Content)
+		}
+	}
+	if page.Title != "Windows Page" {
+		t.Errorf("Title = %q, want %q", page.Title, "Windows Page")
+	}
+	if got := page.Properties["tags"]; got != "notes, windows" {
+		t.Errorf("page tags = %q, want %q", got, "notes, windows")
+	}
+	if len(page.Blocks) != 2 {
+		t.Fatalf("got %d top-level blocks, want 2", len(page.Blocks))
+	}
+
+	first := page.Blocks[0]
+	if want := "TODO First block\nstatus:: open"; first.Content != want {
+		t.Errorf("first block = %q, want %q", first.Content, want)
+	}
+	if first.TodoInfo.TodoState != TodoStateTodo {
+		t.Errorf("first block state = %q, want TODO", first.TodoInfo.TodoState)
+	}
+	if got := first.Properties["status"]; got != "open" {
+		t.Errorf("block status = %q, want %q", got, "open")
+	}
+	if len(first.Children) != 1 || first.Children[0].Content != "Child with [[Link]]" {
+		t.Errorf("first block children = %v, want one child ending without \\r", first.Children)
+	}
+	if page.Blocks[1].Content != "Second block" {
+		t.Errorf("second block = %q, want %q", page.Blocks[1].Content, "Second block")
+	}
+	if want := (FileEncoding{BOM: true, CRLF: true}); page.Encoding != want {
+		t.Errorf("Encoding = %+v, want %+v", page.Encoding, want)
+	}
+}
+
+// TestParseDirectoryWindowsVault checks pages from a vault edited on
+// Windows are named and linked like any other
+func TestParseDirectoryWindowsVault(t *testing.T) {
+	dir := t.TempDir()
+	writePages(t, dir, map[string]string{
+		"Windows.md": "\xEF\xBB\xBFtitle:: Windows Title\r\n\r\n- Links to [[Other]]\r\n",
+		"Other.md":   string(utf16("- Plain page\r\n", false)),
+	})
+
+	result, err := ParseDirectory(dir)
+	if err != nil {
+		t.Fatalf("ParseDirectory() error = %v", err)
+	}
+
+	page := result.GetPage("Windows Title")
+	if page == nil {
+		t.Fatalf("page 'Windows Title' not found, got %v", pageTitles(result))
+	}
+	if !page.Encoding.CRLF || !page.Encoding.BOM {
+		t.Errorf("Windows Title encoding = %+v, want BOM and CRLF", page.Encoding)
+	}
+	if backlinks := result.Backlinks.GetBacklinks("Other"); len(backlinks) != 1 {
+		t.Errorf("backlinks to Other = %d, want 1", len(backlinks))
+	}
+
+	other := result.GetPage("Other")
+	if other == nil {
+		t.Fatalf("page 'Other' not found")
+	}
+	if other.Encoding.Charset != CharsetUTF16LE || len(other.Blocks) != 1 || other.Blocks[0].Content != "Plain page" {
+		t.Errorf("Other = %+v with blocks %v, want one UTF-16 block", other.Encoding, other.Blocks)
+	}
+}
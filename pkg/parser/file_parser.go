@@ -23,12 +23,19 @@
 package parser
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
+	"unicode"
+	
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
 	
 	"github.com/rehanog/seq2b/internal/storage"
 )
@@ -42,9 +49,39 @@ type ParseResult struct {
 
 // MultiPageResult represents the result of parsing multiple pages
 type MultiPageResult struct {
-	Pages     map[string]*Page  // Map of page name to page
+	Pages     map[string]*Page  // Map of page key (see PageKey) to page
 	Backlinks *BacklinkIndex    // Cross-page backlink index
-	Errors    []error          // Any parsing errors
+	Errors    []error           // Any parsing errors
+	Files     map[string]string // Map of source file path to page key
+}
+
+// GetPage looks up a page by any spelling of its name: case, whitespace,
+// date format or alias
+func (r *MultiPageResult) GetPage(name string) *Page {
+	return r.Pages[r.Backlinks.Key(name)]
+}
+
+// Merge adds the pages, backlinks and errors of another result into this one.
+// Pages in other replace pages with the same name.
+func (r *MultiPageResult) Merge(other *MultiPageResult) {
+	for name, page := range other.Pages {
+		if existing, ok := r.Pages[name]; ok {
+			r.Errors = append(r.Errors, &DuplicatePageError{Title: page.Title, Path: page.Path, ExistingPath: existing.Path})
+			continue
+		}
+		r.Pages[name] = page
+		r.Backlinks.AddPage(page)
+	}
+	if r.Files == nil {
+		r.Files = make(map[string]string)
+	}
+	for path, key := range other.Files {
+		// Files of duplicate pages were skipped above
+		if r.Pages[key] == other.Pages[key] {
+			r.Files[path] = key
+		}
+	}
+	r.Errors = append(r.Errors, other.Errors...)
 }
 
 // parseContext is used temporarily during parsing
@@ -53,8 +90,11 @@ type parseContext struct {
 	indentLevel int    // Calculated from raw line, used once, discarded
 }
 
-// calculateIndentLevel counts leading spaces and divides by 2 (Logseq standard)
-func calculateIndentLevel(rawLine string) int {
+// DefaultIndentWidth is the number of spaces per nesting level Logseq uses
+const DefaultIndentWidth = 2
+
+// calculateIndentLevel counts leading spaces and divides by the indent width
+func calculateIndentLevel(rawLine string, width int) int {
 	spaces := 0
 	for _, ch := range rawLine {
 		if ch == ' ' {
@@ -63,18 +103,33 @@ func calculateIndentLevel(rawLine string) int {
 			break
 		}
 	}
-	return spaces / 2
+	return spaces / width
 }
 
-// ParseFile parses markdown content into a Page with block structure
+// ParseFile parses markdown content into a Page with block structure. A
+// byte order mark and \r\n line endings are removed first and recorded in
+// the page's Encoding.
 func ParseFile(content string) (*ParseResult, error) {
+	text, encoding := DecodeText([]byte(content))
+	result, err := parseContent(text, DefaultParseOptions())
+	if err != nil {
+		return nil, err
+	}
+	result.Page.Encoding = encoding
+	return result, nil
+}
+
+// parseContent parses markdown text with \n line endings, using a vault's
+// indent width and TODO keywords
+func parseContent(content string, opts ParseOptions) (*ParseResult, error) {
 	lines := []Line{}
 	contexts := []parseContext{}
+	width := opts.indentWidth()
 	
 	// Step 1: Parse all lines and extract indent levels
 	rawLines := strings.Split(content, "\n")
 	for i, rawLine := range rawLines {
-		indentLevel := calculateIndentLevel(rawLine)
+		indentLevel := calculateIndentLevel(rawLine, width)
 		line := ParseLine(i+1, strings.TrimSpace(rawLine))
 		
 		lines = append(lines, line)
@@ -89,17 +144,23 @@ func ParseFile(content string) (*ParseResult, error) {
 	
 	// Step 4: Create page with all blocks and properties
 	page := &Page{
-		Blocks:     blocks,
-		Properties: pageProperties,
-		Created:    time.Now(),
-		Modified:   time.Now(),
+		Blocks:        blocks,
+		Properties:    pageProperties,
+		PropertyOrder: pagePropertyOrder(lines, pageProperties),
+		Header:        pageHeader(lines),
+		Created:       time.Now(),
+		Modified:      time.Now(),
 	}
 	
-	// Extract title from first header if present
+	// A title:: property names the page, otherwise the first header does.
+	// Pages read from files are renamed by applyFileTitle.
+	page.Title = strings.TrimSpace(pageProperties[TitleProperty])
 	for _, line := range lines {
+		if page.Title != "" {
+			break
+		}
 		if line.Type == TypeHeader {
 			page.Title = line.Content
-			break
 		}
 	}
 	
@@ -112,6 +173,41 @@ func ParseFile(content string) (*ParseResult, error) {
 	}, nil
 }
 
+// pagePropertyOrder lists the page-level property keys in the order they
+// appear, so pages can be written back without reshuffling them
+func pagePropertyOrder(lines []Line, properties map[string]string) []string {
+	order := make([]string, 0, len(properties))
+	for _, line := range lines {
+		if line.Type == TypeBlock {
+			break
+		}
+		for key := range line.Properties {
+			if _, ok := properties[key]; ok && !containsString(order, key) {
+				order = append(order, key)
+			}
+		}
+	}
+	return order
+}
+
+// pageHeader returns the header line among the page properties at the top
+// of a page, as written, so pages without one are written back without one
+func pageHeader(lines []Line) string {
+	for _, line := range lines {
+		switch {
+		case line.Type == TypeEmpty:
+			continue
+		case line.Type == TypeHeader:
+			return strings.Repeat("#", line.HeaderLevel) + " " + line.Content
+		case line.Type == TypeText && len(line.Properties) > 0:
+			continue
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
 // extractPageLevelProperties extracts properties that appear at the page level
 // In Logseq, page properties can appear:
 // 1. At the very beginning of the file (before any content)
@@ -184,61 +280,246 @@ func extractPageLevelProperties(lines []Line) map[string]string {
 	return properties
 }
 
+// ParseOptions controls how a directory of pages is parsed
+type ParseOptions struct {
+	JournalFormat  JournalFormat  // Used to name journal pages that have no header
+	UseCache       bool           // Reuse cached pages for unchanged files
+	CacheDir       string         // Holds a cache for each parsed directory, empty for cache/ in the parsed directory
+	Journal        bool           // Directory holds journal pages (e.g. journals/)
+	Workers        int            // Files parsed at once, 0 for one per CPU
+	Progress       ProgressFunc   // Called as each file is parsed, may be nil
+	Ignore         *IgnoreRules   // Paths skipped when scanning, nil to use the directory's .seq2bignore
+	FileNameFormat FileNameFormat // Decodes page titles from filenames
+	IndentWidth    int            // Spaces per nesting level, 0 for DefaultIndentWidth
+	TodoKeywords   *TodoKeywords  // Words marking tasks, nil for DefaultTodoKeywords
+}
+
+// DefaultParseOptions returns the options used by ParseDirectory
+func DefaultParseOptions() ParseOptions {
+	return ParseOptions{
+		JournalFormat:  DefaultJournalFormat,
+		FileNameFormat: DefaultFileNameFormat,
+		IndentWidth:    DefaultIndentWidth,
+		TodoKeywords:   DefaultTodoKeywords,
+	}
+}
+
+// indentWidth returns the configured indent width or the default
+func (o ParseOptions) indentWidth() int {
+	if o.IndentWidth <= 0 {
+		return DefaultIndentWidth
+	}
+	return o.IndentWidth
+}
+
+// applyTodoKeywords reparses a page's tasks if the vault has its own TODO
+// keywords
+func (o ParseOptions) applyTodoKeywords(page *Page) {
+	if o.TodoKeywords != nil && o.TodoKeywords != DefaultTodoKeywords {
+		o.TodoKeywords.Apply(page.Blocks)
+	}
+}
+
+// newBacklinkIndex creates an index that recognises the configured journal
+// title format when canonicalising date pages
+func (o ParseOptions) newBacklinkIndex() *BacklinkIndex {
+	idx := NewBacklinkIndex()
+	idx.KeyFunc = o.JournalFormat.PageKey
+	return idx
+}
+
 // ParseDirectory parses all markdown files in a directory
 func ParseDirectory(dirPath string) (*MultiPageResult, error) {
-	result := &MultiPageResult{
-		Pages:     make(map[string]*Page),
-		Backlinks: NewBacklinkIndex(),
-		Errors:    []error{},
+	return ParseDirectoryWithOptions(dirPath, DefaultParseOptions())
+}
+
+// ParseDirectoryWithOptions parses all markdown files in a directory
+func ParseDirectoryWithOptions(dirPath string, opts ParseOptions) (*MultiPageResult, error) {
+	return ParseDirectoryContext(context.Background(), dirPath, opts)
+}
+
+// ParseDirectoryContext parses all markdown files in a directory and its
+// subdirectories, using opts.Workers goroutines. It stops early with ctx's
+// error if ctx is cancelled.
+func ParseDirectoryContext(ctx context.Context, dirPath string, opts ParseOptions) (*MultiPageResult, error) {
+	if opts.UseCache {
+		return parseDirectoryCached(ctx, dirPath, opts)
 	}
 	
 	// Find all markdown files
-	files, err := filepath.Glob(filepath.Join(dirPath, "*.md"))
+	files, err := findFiles(dirPath, opts)
 	if err != nil {
-		return nil, fmt.Errorf("error finding files: %w", err)
+		return nil, err
 	}
 	
-	// Parse each file
-	for _, filePath := range files {
-		content, err := os.ReadFile(filePath)
-		if err != nil {
-			result.Errors = append(result.Errors, 
-				fmt.Errorf("error reading %s: %w", filePath, err))
-			continue
-		}
-		
-		// Parse the file
-		parseResult, err := ParseFile(string(content))
-		if err != nil {
-			result.Errors = append(result.Errors,
-				fmt.Errorf("error parsing %s: %w", filePath, err))
-			continue
+	return ParseFilesContext(ctx, files, opts)
+}
+
+// findFiles lists the page files in a directory, applying the ignore rules
+// in opts or else the directory's own
+func findFiles(dirPath string, opts ParseOptions) ([]string, error) {
+	rules := opts.Ignore
+	if rules == nil {
+		var err error
+		if rules, err = LoadIgnoreRules(dirPath); err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", IgnoreFileName, err)
 		}
-		
-		// Store the page
-		page := parseResult.Page
-		result.Pages[page.Title] = page
-		
-		// Add to backlink index
-		result.Backlinks.AddPage(page)
+		rules.SkipDir(opts.CachePath(dirPath))
 	}
 	
-	return result, nil
+	files, err := FindPageFiles(dirPath, rules)
+	if err != nil {
+		return nil, fmt.Errorf("error finding files: %w", err)
+	}
+	return files, nil
 }
 
-// ParseDirectoryWithCache parses a directory using cache for unchanged files
-func ParseDirectoryWithCache(dirPath string) (*MultiPageResult, error) {
-	result := &MultiPageResult{
+// newMultiPageResult creates an empty result for the given options
+func newMultiPageResult(opts ParseOptions) *MultiPageResult {
+	return &MultiPageResult{
 		Pages:     make(map[string]*Page),
-		Backlinks: NewBacklinkIndex(),
+		Backlinks: opts.newBacklinkIndex(),
 		Errors:    []error{},
+		Files:     make(map[string]string),
+	}
+}
+
+// addParsed stores a parsed file's page and indexes its links, or records
+// why it couldn't be parsed
+func (r *MultiPageResult) addParsed(filePath string, parsed parsedFile) {
+	if parsed.err != nil {
+		r.Errors = append(r.Errors, parsed.err)
+		return
+	}
+	if parsed.cacheErr != nil {
+		r.Errors = append(r.Errors, parsed.cacheErr)
 	}
 	
+	key := r.Backlinks.rawKey(parsed.page.Title)
+	if existing, ok := r.Pages[key]; ok {
+		r.Errors = append(r.Errors, &DuplicatePageError{Title: parsed.page.Title, Path: filePath, ExistingPath: existing.Path})
+		return
+	}
+	r.Pages[key] = parsed.page
+	r.Files[filePath] = key
+	r.Backlinks.AddPage(parsed.page)
+}
+
+// DuplicatePageError reports a file naming a page that another file
+// already holds. The first file, in path order, keeps the page.
+type DuplicatePageError struct {
+	Title        string
+	Path         string // File that was skipped
+	ExistingPath string // File the page was loaded from
+}
+
+func (e *DuplicatePageError) Error() string {
+	return fmt.Sprintf("duplicate page %q in %s, already loaded from %s", e.Title, e.Path, e.ExistingPath)
+}
+
+// ParsePageFile reads and parses a single markdown file, naming the page
+// as applyFileTitle describes
+func ParsePageFile(filePath string, opts ParseOptions) (*Page, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", filePath, err)
+	}
+	return ParsePageContent(filePath, content, opts)
+}
+
+// ParsePageContent parses content already read from a page file, naming
+// the page as ParsePageFile does
+func ParsePageContent(filePath string, content []byte, opts ParseOptions) (*Page, error) {
+	text, encoding := DecodeText(content)
+	parseResult, err := parseContent(text, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", filePath, err)
+	}
+	
+	page := parseResult.Page
+	opts.applyTodoKeywords(page)
+	page.Hash = ContentHash(content)
+	page.Encoding = encoding
+	applyFileTitle(page, filePath, opts)
+	return page, nil
+}
+
+// ContentHash identifies a version of a page file, so a write can check
+// the file still holds the version that was read
+func ContentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// TitleProperty is the page property that names a page, whatever its
+// filename
+const TitleProperty = "title"
+
+// applyFileTitle names a page read from a file and marks journal pages.
+// As in Logseq, a title:: property names the page and otherwise the
+// filename does, whichever subdirectory it's in; journal files usually
+// contain only blocks. Filenames lose case and spacing, so a header is
+// used for the spelling when the filename is that header's encoded form
+// ("Page A" for page-a.md).
+func applyFileTitle(page *Page, filePath string, opts ParseOptions) {
+	page.Path = filePath
+	date, err := opts.JournalFormat.ParseFileName(filePath)
+	isDateFile := err == nil
+	fileName := opts.FileNameFormat.Decode(filepath.Base(filePath))
+	
+	switch {
+	case strings.TrimSpace(page.Properties[TitleProperty]) != "":
+		page.Title = strings.TrimSpace(page.Properties[TitleProperty])
+	case isDateFile:
+		page.Title = opts.JournalFormat.FormatTitle(date)
+	case page.Title == "" || !isFileNameFor(fileName, page.Title):
+		page.Title = fileName
+	}
+	
+	// Date pages kept alongside regular pages count as journals too
+	page.IsJournal = opts.Journal || (isDateFile && opts.JournalFormat.IsJournalTitle(page.Title))
+}
+
+// ParseDirectoryWithCache parses a directory using cache for unchanged files
+func ParseDirectoryWithCache(dirPath string) (*MultiPageResult, error) {
+	opts := DefaultParseOptions()
+	opts.UseCache = true
+	return ParseDirectoryWithOptions(dirPath, opts)
+}
+
+// cacheDir returns where the cache of dirPath is kept. A shared CacheDir
+// gets a subdirectory for each parsed directory, as a cache holds one
+// directory's pages keyed by their paths within it.
+func (o ParseOptions) cacheDir(dirPath string) string {
+	if o.CacheDir == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(dirPath); err == nil {
+		dirPath = abs
+	}
+	name := filepath.Base(dirPath) + "-" + ContentHash([]byte(filepath.ToSlash(dirPath)))[:12]
+	return filepath.Join(o.CacheDir, name)
+}
+
+// CachePath returns the directory holding the cache of dirPath, so it can be
+// left out when scanning for pages
+func (o ParseOptions) CachePath(dirPath string) string {
+	if dir := o.cacheDir(dirPath); dir != "" {
+		return dir
+	}
+	return storage.DefaultCacheDir(dirPath)
+}
+
+// parseDirectoryCached parses a directory using cache for unchanged files
+func parseDirectoryCached(ctx context.Context, dirPath string, opts ParseOptions) (*MultiPageResult, error) {
+	result := newMultiPageResult(opts)
+	
 	// Initialize cache
-	cache, err := storage.NewCacheManager(dirPath)
+	cache, err := storage.NewCacheManagerIn(dirPath, opts.cacheDir(dirPath))
 	if err != nil {
 		// Fall back to regular parsing if cache fails
-		return ParseDirectory(dirPath)
+		opts.UseCache = false
+		return ParseDirectoryContext(ctx, dirPath, opts)
 	}
 	defer cache.Close()
 	
@@ -257,20 +538,23 @@ func ParseDirectoryWithCache(dirPath string) (*MultiPageResult, error) {
 	}
 	
 	// Find all markdown files
-	files, err := filepath.Glob(filepath.Join(dirPath, "*.md"))
+	files, err := findFiles(dirPath, opts)
 	if err != nil {
-		return nil, fmt.Errorf("error finding files: %w", err)
+		return nil, err
 	}
 	
-	cacheHits := 0
-	cacheMisses := 0
 	startTime := time.Now()
 	
-	// Parse each file
-	for _, filePath := range files {
-		// Extract page name from filename
-		baseName := filepath.Base(filePath)
-		pageName := baseName[:len(baseName)-3] // Remove .md extension
+	// Parse each file, reusing cached pages. The cache is safe for
+	// concurrent use.
+	parsed, err := parseConcurrently(ctx, files, opts, func(filePath string) parsedFile {
+		// Cache entries are named by path, so files with the same name in
+		// different subdirectories don't share one
+		relPath, err := filepath.Rel(dirPath, filePath)
+		if err != nil {
+			relPath = filepath.Base(filePath)
+		}
+		pageName := strings.TrimSuffix(filepath.ToSlash(relPath), ".md")
 		
 		// Try cache first
 		if valid {
@@ -283,10 +567,9 @@ func ParseDirectoryWithCache(dirPath string) (*MultiPageResult, error) {
 				if rawJSON, ok := cachedPage.(json.RawMessage); ok {
 					var page Page
 					if err := json.Unmarshal(rawJSON, &page); err == nil {
-						cacheHits++
-						result.Pages[page.Title] = &page
-						result.Backlinks.AddPage(&page)
-						continue
+						opts.applyTodoKeywords(&page)
+						applyFileTitle(&page, filePath, opts)
+						return parsedFile{page: &page, cached: true}
 					} else {
 						fmt.Printf("Cache unmarshal error for %s: %v\n", pageName, err)
 					}
@@ -297,25 +580,11 @@ func ParseDirectoryWithCache(dirPath string) (*MultiPageResult, error) {
 		}
 		
 		// Cache miss - parse the file
-		cacheMisses++
-		content, err := os.ReadFile(filePath)
-		if err != nil {
-			result.Errors = append(result.Errors, 
-				fmt.Errorf("error reading %s: %w", filePath, err))
-			continue
-		}
-		
-		// Parse the file
-		parseResult, err := ParseFile(string(content))
+		page, err := ParsePageFile(filePath, opts)
 		if err != nil {
-			result.Errors = append(result.Errors,
-				fmt.Errorf("error parsing %s: %w", filePath, err))
-			continue
+			return parsedFile{err: err}
 		}
-		
-		// Store the page
-		page := parseResult.Page
-		result.Pages[page.Title] = page
+		file := parsedFile{page: page}
 		
 		// Extract dependencies
 		var dependencies []string
@@ -326,12 +595,24 @@ func ParseDirectoryWithCache(dirPath string) (*MultiPageResult, error) {
 		// Save to cache
 		if err := cache.SavePage(page, pageName, filePath, dependencies); err != nil {
 			// Log but don't fail
-			result.Errors = append(result.Errors,
-				fmt.Errorf("warning: failed to cache %s: %w", pageName, err))
+			file.cacheErr = fmt.Errorf("warning: failed to cache %s: %w", pageName, err)
 		}
-		
-		// Add to backlink index
-		result.Backlinks.AddPage(page)
+		return file
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	// Merge in file order so the result doesn't depend on scheduling
+	cacheHits := 0
+	cacheMisses := 0
+	for i, file := range parsed {
+		if file.cached {
+			cacheHits++
+		} else {
+			cacheMisses++
+		}
+		result.addParsed(files[i], file)
 	}
 	
 	// Save backlinks to cache
@@ -367,53 +648,87 @@ func extractBlockDependencies(block *Block, dependencies *[]string) {
 
 // ParseFiles parses specific markdown files
 func ParseFiles(filePaths []string) (*MultiPageResult, error) {
-	result := &MultiPageResult{
-		Pages:     make(map[string]*Page),
-		Backlinks: NewBacklinkIndex(),
-		Errors:    []error{},
+	return ParseFilesContext(context.Background(), filePaths, DefaultParseOptions())
+}
+
+// ParseFilesContext parses specific markdown files, using opts.Workers
+// goroutines. Pages are merged in the order of filePaths. It stops early
+// with ctx's error if ctx is cancelled.
+func ParseFilesContext(ctx context.Context, filePaths []string, opts ParseOptions) (*MultiPageResult, error) {
+	parsed, err := parseConcurrently(ctx, filePaths, opts, func(filePath string) parsedFile {
+		page, err := ParsePageFile(filePath, opts)
+		return parsedFile{page: page, err: err}
+	})
+	if err != nil {
+		return nil, err
 	}
 	
-	for _, filePath := range filePaths {
-		content, err := os.ReadFile(filePath)
-		if err != nil {
-			result.Errors = append(result.Errors,
-				fmt.Errorf("error reading %s: %w", filePath, err))
-			continue
-		}
-		
-		// Parse the file
-		parseResult, err := ParseFile(string(content))
-		if err != nil {
-			result.Errors = append(result.Errors,
-				fmt.Errorf("error parsing %s: %w", filePath, err))
-			continue
-		}
-		
-		// Store the page
-		page := parseResult.Page
-		result.Pages[page.Title] = page
-		
-		// Add to backlink index
-		result.Backlinks.AddPage(page)
+	result := newMultiPageResult(opts)
+	for i, file := range parsed {
+		result.addParsed(filePaths[i], file)
 	}
-	
 	return result, nil
 }
 
-// TitleToFilename converts a page title to a filename
+// TitleToFilename converts a page title to the lowercased, hyphenated
+// filename used by earlier versions of seq2b. It loses case and spacing;
+// new files are named with FileNameFormat.Encode.
 func TitleToFilename(title string) string {
 	// Simple conversion: lowercase, replace spaces with hyphens, add .md
 	filename := strings.ToLower(title)
 	filename = strings.ReplaceAll(filename, " ", "-")
 	
+	// Namespace separators get their own encoding so Projects/seq2b and
+	// Projects-seq2b don't collide (Logseq's triple-lowbar format)
+	filename = strings.ReplaceAll(filename, NamespaceSeparator, NamespaceFileSeparator)
+	
 	// Remove any special characters that might be problematic
-	filename = strings.ReplaceAll(filename, "/", "-")
 	filename = strings.ReplaceAll(filename, "\\", "-")
 	filename = strings.ReplaceAll(filename, ":", "-")
 	
 	return filename + ".md"
 }
 
+// DecodeFileName turns a page filename back into a page name using the
+// default FileNameFormat
+func DecodeFileName(filename string) string {
+	return DefaultFileNameFormat.Decode(filename)
+}
+
+// isFileNameFor reports whether a decoded filename could name title: the
+// same letters and digits once case, accents, spacing and punctuation are
+// ignored, as with TitleToFilename or a hand-named file
+func isFileNameFor(name string, title string) bool {
+	return nameLetters(name) == nameLetters(title)
+}
+
+// nameLetters keeps just the case-folded letters and digits of a name,
+// without accents
+func nameLetters(name string) string {
+	var letters strings.Builder
+	for _, r := range norm.NFKD.String(cases.Fold().String(name)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			letters.WriteRune(r)
+		}
+	}
+	return letters.String()
+}
+
+// NamespaceFileSeparator replaces "/" in filenames of namespaced pages
+const NamespaceFileSeparator = "___"
+
+// CandidateFilenames returns the filenames a page may already be stored
+// under, starting with the current encoding. Older versions replaced "/"
+// with "-".
+func CandidateFilenames(title string) []string {
+	filenames := []string{TitleToFilename(title)}
+	legacy := TitleToFilename(strings.ReplaceAll(title, NamespaceSeparator, "-"))
+	if legacy != filenames[0] {
+		filenames = append(filenames, legacy)
+	}
+	return filenames
+}
+
 // BuildBlockTree converts flat lines into hierarchical block structure
 func BuildBlockTree(contexts []parseContext) []*Block {
 	var rootBlocks []*Block
@@ -455,8 +770,28 @@ func BuildBlockTree(contexts []parseContext) []*Block {
 			
 			// Update content
 			newBlock.updateContent()
+			continue
+		}
+		
+		if ctx.line.Type == TypeText {
+			continueBlock(blockStack, ctx)
 		}
 	}
 	
 	return rootBlocks
+}
+
+// continueBlock adds a text line to the innermost open block when it is
+// indented under it, as Logseq writes properties, SCHEDULED/DEADLINE lines
+// and multi-line content. Text that isn't indented under the block is not
+// part of any block.
+func continueBlock(blockStack []*Block, ctx parseContext) {
+	if len(blockStack) == 0 {
+		return
+	}
+	current := blockStack[len(blockStack)-1]
+	if ctx.indentLevel > current.Depth {
+		current.Lines = append(current.Lines, ctx.line)
+		current.updateContent()
+	}
 }
\ No newline at end of file
@@ -0,0 +1,194 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// FileNameFormat is a reversible encoding of page titles as filenames,
+// matching Logseq's :file/name-format. Titles keep their case and spacing;
+// "%" and characters that aren't allowed in filenames are percent-encoded.
+type FileNameFormat string
+
+const (
+	// FileNameTripleLowbar writes "/" as "___", e.g. Projects___seq2b.md.
+	// Logseq's current format.
+	FileNameTripleLowbar FileNameFormat = "triple-lowbar"
+	// FileNameLegacy writes "/" as "%2F", e.g. Projects%2Fseq2b.md. Used
+	// by vaults created with older Logseq versions.
+	FileNameLegacy FileNameFormat = "legacy"
+)
+
+// DefaultFileNameFormat is used by new vaults
+const DefaultFileNameFormat = FileNameTripleLowbar
+
+// reservedFileNameChars are percent-encoded wherever they appear in a title
+const reservedFileNameChars = `%<>:"\|?*#`
+
+var ednFileNameFormatKeyPattern = regexp.MustCompile(`:file/name-format\s+:([\w-]+)`)
+
+// Validate checks the format is one seq2b knows
+func (f FileNameFormat) Validate() error {
+	switch f {
+	case FileNameTripleLowbar, FileNameLegacy:
+		return nil
+	}
+	return fmt.Errorf("unknown file name format %q", string(f))
+}
+
+// orDefault returns the format, or the default for an unset format
+func (f FileNameFormat) orDefault() FileNameFormat {
+	if f == "" {
+		return DefaultFileNameFormat
+	}
+	return f
+}
+
+// Encode returns the filename (with .md extension) for a page title.
+// Decode(Encode(title)) == title for every title, so distinct pages such
+// as "A/B" and "a-b" never share a file.
+func (f FileNameFormat) Encode(title string) string {
+	f = f.orDefault()
+	runes := []rune(title)
+	var name strings.Builder
+	for i, r := range runes {
+		switch {
+		case string(r) == NamespaceSeparator:
+			if f == FileNameLegacy {
+				name.WriteString("%2F")
+			} else {
+				name.WriteString(NamespaceFileSeparator)
+			}
+		case strings.ContainsRune(reservedFileNameChars, r) || r < 0x20 || r == 0x7f:
+			writePercentEncoded(&name, r)
+		case r == '.' && i == 0:
+			// Leading dots would hide the file
+			writePercentEncoded(&name, r)
+		case (r == '.' || r == ' ') && i == len(runes)-1:
+			// Windows drops trailing dots and spaces
+			writePercentEncoded(&name, r)
+		case r == '_' && f == FileNameTripleLowbar && nextToLowbarOrSlash(runes, i):
+			// Only "/" may produce a run of underscores, so a title's own
+			// underscores can't be read back as a separator
+			writePercentEncoded(&name, r)
+		default:
+			name.WriteRune(r)
+		}
+	}
+	return name.String() + ".md"
+}
+
+// writePercentEncoded writes the UTF-8 bytes of r as %XX escapes
+func writePercentEncoded(name *strings.Builder, r rune) {
+	for _, b := range []byte(string(r)) {
+		fmt.Fprintf(name, "%%%02X", b)
+	}
+}
+
+// nextToLowbarOrSlash reports whether the rune before or after i is "_"
+// or "/"
+func nextToLowbarOrSlash(runes []rune, i int) bool {
+	for _, j := range []int{i - 1, i + 1} {
+		if j >= 0 && j < len(runes) && (runes[j] == '_' || string(runes[j]) == NamespaceSeparator) {
+			return true
+		}
+	}
+	return false
+}
+
+// Decode turns a filename back into a page title. Filenames that weren't
+// written by Encode, such as a literal "100%.md", decode as best they can.
+func (f FileNameFormat) Decode(filename string) string {
+	name := strings.TrimSuffix(filename, ".md")
+	if f.orDefault() == FileNameTripleLowbar {
+		name = strings.ReplaceAll(name, NamespaceFileSeparator, NamespaceSeparator)
+	}
+	if decoded, err := url.PathUnescape(name); err == nil {
+		name = decoded
+	}
+	return name
+}
+
+// CandidateFileNames returns every filename a page may already be stored
+// under, this format's encoding first. Pages imported from a vault using
+// the other Logseq format, or written by older versions of seq2b (see
+// CandidateFilenames), keep their files.
+func (f FileNameFormat) CandidateFileNames(title string) []string {
+	f = f.orDefault()
+	other := FileNameLegacy
+	if f == FileNameLegacy {
+		other = FileNameTripleLowbar
+	}
+	
+	names := []string{f.Encode(title), other.Encode(title)}
+	names = append(names, CandidateFilenames(title)...)
+	
+	var unique []string
+	for _, name := range names {
+		if !containsString(unique, name) {
+			unique = append(unique, name)
+		}
+	}
+	return unique
+}
+
+// LoadFileNameFormat reads :file/name-format from a library's
+// logseq/config.edn. As in Logseq, a config without the key is a legacy
+// vault; a library without a config uses the default.
+func LoadFileNameFormat(libraryPath string) (FileNameFormat, error) {
+	content, err := os.ReadFile(filepath.Join(libraryPath, "logseq", "config.edn"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return DefaultFileNameFormat, nil
+		}
+		return DefaultFileNameFormat, fmt.Errorf("error reading config.edn: %w", err)
+	}
+	
+	matches := ednFileNameFormatKeyPattern.FindSubmatch(content)
+	if matches == nil {
+		return FileNameLegacy, nil
+	}
+	format := FileNameFormat(matches[1])
+	if err := format.Validate(); err != nil {
+		return DefaultFileNameFormat, err
+	}
+	return format, nil
+}
+
+// FileNameCollisionError reports a new page whose file already holds a
+// different page, e.g. one renamed by a title:: property
+type FileNameCollisionError struct {
+	Title         string
+	Path          string
+	ExistingTitle string // Page the file holds
+}
+
+func (e *FileNameCollisionError) Error() string {
+	return fmt.Sprintf("cannot create page %q: %s already holds page %q", e.Title, e.Path, e.ExistingTitle)
+}
\ No newline at end of file
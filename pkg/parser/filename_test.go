@@ -0,0 +1,177 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFileNameFormatEncode(t *testing.T) {
+	tests := []struct {
+		format FileNameFormat
+		title  string
+		want   string
+	}{
+		{FileNameTripleLowbar, "Page A", "Page A.md"},
+		{FileNameTripleLowbar, "Projects/seq2b", "Projects___seq2b.md"},
+		{FileNameLegacy, "Projects/seq2b", "Projects%2Fseq2b.md"},
+		{FileNameTripleLowbar, "snake_case", "snake_case.md"},
+		{FileNameTripleLowbar, "a___b", "a%5F%5F%5Fb.md"},
+		{FileNameTripleLowbar, "a_/b", "a%5F___b.md"},
+		{FileNameLegacy, "a___b", "a___b.md"},
+		{FileNameTripleLowbar, "What? Why: 100%", "What%3F Why%3A 100%25.md"},
+		{FileNameTripleLowbar, ".hidden", "%2Ehidden.md"},
+		{FileNameTripleLowbar, "Etc.", "Etc%2E.md"},
+		{"", "Projects/seq2b", "Projects___seq2b.md"},
+	}
+	for _, tt := range tests {
+		if got := tt.format.Encode(tt.title); got != tt.want {
+			t.Errorf("%s Encode(%q) = %q, want %q", tt.format, tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestFileNameFormatDecode(t *testing.T) {
+	tests := []struct {
+		format   FileNameFormat
+		filename string
+		want     string
+	}{
+		{FileNameTripleLowbar, "Projects___seq2b.md", "Projects/seq2b"},
+		{FileNameTripleLowbar, "a%2Fb.md", "a/b"},
+		{FileNameLegacy, "a%2Fb.md", "a/b"},
+		{FileNameLegacy, "a___b.md", "a___b"},
+		{FileNameTripleLowbar, "100%.md", "100%"},
+		{FileNameTripleLowbar, "page-a.md", "page-a"},
+	}
+	for _, tt := range tests {
+		if got := tt.format.Decode(tt.filename); got != tt.want {
+			t.Errorf("%s Decode(%q) = %q, want %q", tt.format, tt.filename, got, tt.want)
+		}
+	}
+}
+
+// TestFileNameFormatRoundTrip checks random titles decode to themselves
+// and that distinct titles never share a filename
+func TestFileNameFormatRoundTrip(t *testing.T) {
+	alphabet := []rune("aB _/%.:?#\\-é")
+	rng := rand.New(rand.NewSource(1))
+	for _, format := range []FileNameFormat{FileNameTripleLowbar, FileNameLegacy} {
+		seen := map[string]string{}
+		for i := 0; i < 5000; i++ {
+			runes := make([]rune, 1+rng.Intn(8))
+			for j := range runes {
+				runes[j] = alphabet[rng.Intn(len(alphabet))]
+			}
+			title := string(runes)
+
+			filename := format.Encode(title)
+			if got := format.Decode(filename); got != title {
+				t.Fatalf("%s: Decode(Encode(%q)) = %q via %q", format, title, got, filename)
+			}
+			if strings.ContainsAny(strings.TrimSuffix(filename, ".md"), `/\:?*"<>|`) {
+				t.Fatalf("%s: Encode(%q) = %q has reserved characters", format, title, filename)
+			}
+			if other, ok := seen[filename]; ok && other != title {
+				t.Fatalf("%s: %q and %q both encode as %q", format, title, other, filename)
+			}
+			seen[filename] = title
+		}
+	}
+
+	if FileNameTripleLowbar.Encode("A/B") == FileNameTripleLowbar.Encode("a-b") {
+		t.Error("A/B and a-b should not collide")
+	}
+}
+
+func TestFileNameFormatCandidates(t *testing.T) {
+	got := FileNameTripleLowbar.CandidateFileNames("Projects/New Idea")
+	want := []string{"Projects___New Idea.md", "Projects%2FNew Idea.md", "projects___new-idea.md", "projects-new-idea.md"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CandidateFileNames = %v, want %v", got, want)
+	}
+	if got := FileNameLegacy.CandidateFileNames("inbox"); !reflect.DeepEqual(got, []string{"inbox.md"}) {
+		t.Errorf("CandidateFileNames for plain title = %v", got)
+	}
+}
+
+func TestLoadFileNameFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string // Empty for no config.edn
+		want    FileNameFormat
+		wantErr bool
+	}{
+		{"no config", "", FileNameTripleLowbar, false},
+		{"triple-lowbar", "{:meta/version 1\n :file/name-format :triple-lowbar}", FileNameTripleLowbar, false},
+		{"key missing", "{:meta/version 1}", FileNameLegacy, false},
+		{"legacy", "{:file/name-format :legacy}", FileNameLegacy, false},
+		{"unknown", "{:file/name-format :kebab}", DefaultFileNameFormat, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.config != "" {
+				writePages(t, dir, map[string]string{"logseq/config.edn": tt.config})
+			}
+			got, err := LoadFileNameFormat(dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadFileNameFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("LoadFileNameFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseDirectoryFileNameFormat checks filenames are decoded with the
+// vault's format
+func TestParseDirectoryFileNameFormat(t *testing.T) {
+	dir := t.TempDir()
+	writePages(t, dir, map[string]string{
+		"a%2Fb.md":     "- Percent-encoded",
+		"snake___x.md": "- Underscores",
+	})
+
+	for _, tt := range []struct {
+		format FileNameFormat
+		want   []string
+	}{
+		{FileNameTripleLowbar, []string{"a/b", "snake/x"}},
+		{FileNameLegacy, []string{"a/b", "snake___x"}},
+	} {
+		opts := DefaultParseOptions()
+		opts.FileNameFormat = tt.format
+		result, err := ParseDirectoryWithOptions(dir, opts)
+		if err != nil {
+			t.Fatalf("ParseDirectoryWithOptions() error = %v", err)
+		}
+		if got := pageTitles(result); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s titles = %v, want %v", tt.format, got, tt.want)
+		}
+	}
+}
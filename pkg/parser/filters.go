@@ -0,0 +1,103 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FiltersProperty is the page property holding backlink filters, written
+// the way Logseq writes it: {"project" true, "done" false}
+const FiltersProperty = "filters"
+
+// BacklinkFilter shows only the references that mention a name, or hides
+// them. A name may be a source page, a namespace or a tag.
+type BacklinkFilter struct {
+	Name    string
+	Include bool // Show only matching references; false hides them
+}
+
+// Matches one "name" true|false entry of a filters:: map
+var filterEntryPattern = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"\s+(true|false)`)
+
+// ParseFilters reads a filters:: property value, sorted by name
+func ParseFilters(value string) ([]BacklinkFilter, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(value, "{") || !strings.HasSuffix(value, "}") {
+		return nil, fmt.Errorf("filters must be a map like {\"page\" true}, got %q", value)
+	}
+	body := value[1 : len(value)-1]
+	
+	var filters []BacklinkFilter
+	for _, match := range filterEntryPattern.FindAllStringSubmatch(body, -1) {
+		name, err := strconv.Unquote(`"` + match[1] + `"`)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter name %q: %w", match[1], err)
+		}
+		filters = append(filters, BacklinkFilter{Name: name, Include: match[2] == "true"})
+	}
+	
+	// Anything besides entries and separators is malformed
+	if rest := strings.Trim(filterEntryPattern.ReplaceAllString(body, ""), " ,\t"); rest != "" {
+		return nil, fmt.Errorf("unexpected %q in filters", rest)
+	}
+	
+	sortFilters(filters)
+	return filters, nil
+}
+
+// FormatFilters writes filters as a filters:: property value, or "" when
+// there are none
+func FormatFilters(filters []BacklinkFilter) string {
+	if len(filters) == 0 {
+		return ""
+	}
+	sorted := append([]BacklinkFilter(nil), filters...)
+	sortFilters(sorted)
+	
+	entries := make([]string, len(sorted))
+	for i, filter := range sorted {
+		entries[i] = fmt.Sprintf("%s %t", strconv.Quote(filter.Name), filter.Include)
+	}
+	return "{" + strings.Join(entries, ", ") + "}"
+}
+
+// BacklinkFilters returns the filters stored in the page's filters::
+// property
+func (p *Page) BacklinkFilters() ([]BacklinkFilter, error) {
+	return ParseFilters(p.Properties[FiltersProperty])
+}
+
+// sortFilters orders filters by name
+func sortFilters(filters []BacklinkFilter) {
+	sort.Slice(filters, func(i, j int) bool {
+		return filters[i].Name < filters[j].Name
+	})
+}
\ No newline at end of file
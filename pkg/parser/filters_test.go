@@ -0,0 +1,81 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFilters(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    []BacklinkFilter
+		wantErr bool
+	}{
+		{"", nil, false},
+		{`{"project" true, "jan 1st, 2025" false}`, []BacklinkFilter{{"jan 1st, 2025", false}, {"project", true}}, false},
+		{`{"say \"hi\"" true}`, []BacklinkFilter{{`say "hi"`, true}}, false},
+		{`{}`, nil, false},
+		{`"project" true`, nil, true},
+		{`{"project" maybe}`, nil, true},
+	}
+	
+	for _, tt := range tests {
+		got, err := ParseFilters(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFilters(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseFilters(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormatFiltersRoundTrip(t *testing.T) {
+	filters := []BacklinkFilter{{"project", true}, {`a "quoted" name`, false}}
+	value := FormatFilters(filters)
+	if value != `{"a \"quoted\" name" false, "project" true}` {
+		t.Errorf("FormatFilters = %s", value)
+	}
+	
+	parsed, err := ParseFilters(value)
+	if err != nil || len(parsed) != 2 || parsed[1] != filters[0] || parsed[0] != filters[1] {
+		t.Errorf("Round trip gave %v, %v", parsed, err)
+	}
+	if FormatFilters(nil) != "" {
+		t.Error("No filters should format as an empty value")
+	}
+}
+
+func TestPageBacklinkFilters(t *testing.T) {
+	result, err := ParseFile("# Hub\nfilters:: {\"done\" false}\n\n- Content")
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	filters, err := result.Page.BacklinkFilters()
+	if err != nil || !reflect.DeepEqual(filters, []BacklinkFilter{{"done", false}}) {
+		t.Errorf("BacklinkFilters() = %v, %v", filters, err)
+	}
+}
@@ -0,0 +1,266 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// IgnoreFileName is the file in a vault's root listing paths that aren't
+// pages, in gitignore syntax
+const IgnoreFileName = ".seq2bignore"
+
+// DefaultIgnorePatterns are ignored in every vault: version control,
+// Logseq's backups, and the trash and page history. The page cache is
+// wherever the vault's settings put it, so it's skipped with SkipDir.
+var DefaultIgnorePatterns = []string{
+	".git/",
+	"logseq/bak/",
+	"logseq/.recycle/",
+	".seq2b/",
+}
+
+// IgnoreRules decides which files and directories under a vault root are
+// skipped when scanning for pages. Patterns follow gitignore: "*" and "?"
+// don't match "/", "**" matches any number of directories, a leading or
+// inner "/" anchors a pattern to the root, a trailing "/" matches only
+// directories and "!" re-includes a path ignored by an earlier pattern.
+type IgnoreRules struct {
+	root  string
+	rules []ignoreRule
+}
+
+// ignoreRule is one compiled pattern
+type ignoreRule struct {
+	pattern *regexp.Regexp
+	negate  bool // Pattern started with "!"
+	dirOnly bool // Pattern ended with "/"
+}
+
+// NewIgnoreRules compiles the default patterns followed by the lines of an
+// ignore file, relative to root
+func NewIgnoreRules(root string, content string) *IgnoreRules {
+	rules := &IgnoreRules{root: root}
+	lines := append(append([]string{}, DefaultIgnorePatterns...), strings.Split(content, "\n")...)
+	for _, line := range lines {
+		if rule, ok := parseIgnoreLine(line); ok {
+			rules.rules = append(rules.rules, rule)
+		}
+	}
+	return rules
+}
+
+// LoadIgnoreRules reads root's .seq2bignore. A vault without one gets
+// just the default patterns.
+func LoadIgnoreRules(root string) (*IgnoreRules, error) {
+	content, err := os.ReadFile(filepath.Join(root, IgnoreFileName))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return NewIgnoreRules(root, string(content)), nil
+}
+
+// SkipDir ignores a directory, and everything in it, whatever the patterns
+// say. It's for directories the app writes to, such as the page cache. A
+// directory outside the root is never scanned, so needs no rule.
+func (r *IgnoreRules) SkipDir(dir string) {
+	root, err := filepath.Abs(r.root)
+	if err != nil {
+		return
+	}
+	if dir, err = filepath.Abs(dir); err != nil {
+		return
+	}
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return
+	}
+	
+	pattern := regexp.MustCompile("^" + regexp.QuoteMeta(filepath.ToSlash(rel)) + "$")
+	r.rules = append(r.rules, ignoreRule{pattern: pattern, dirOnly: true})
+}
+
+// parseIgnoreLine compiles one line of an ignore file. Blank lines and
+// comments give no rule.
+func parseIgnoreLine(line string) (ignoreRule, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+	
+	var rule ignoreRule
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return ignoreRule{}, false
+	}
+	
+	// A slash anywhere but the end anchors the pattern to the root
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	
+	prefix := "^(?:.*/)?"
+	if anchored {
+		prefix = "^"
+	}
+	pattern, err := regexp.Compile(prefix + globToRegexp(line) + "$")
+	if err != nil {
+		return ignoreRule{}, false
+	}
+	rule.pattern = pattern
+	return rule, true
+}
+
+// globToRegexp translates a gitignore glob into a regular expression
+func globToRegexp(glob string) string {
+	var out strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			// Zero or more directories
+			out.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			out.WriteString(".*")
+			i++
+		case c == '*':
+			out.WriteString("[^/]*")
+		case c == '?':
+			out.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				out.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			out.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(glob):
+			i++
+			out.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		default:
+			out.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return out.String()
+}
+
+// Ignored reports whether a path, or any directory above it up to the
+// root, is ignored. Paths outside the root are matched by name only.
+func (r *IgnoreRules) Ignored(path string, isDir bool) bool {
+	if r == nil {
+		return false
+	}
+	
+	rel, err := filepath.Rel(r.root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		rel = filepath.Base(path)
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == "." {
+		return false
+	}
+	
+	// Nothing below an ignored directory can be re-included
+	parts := strings.Split(rel, "/")
+	for i := 1; i < len(parts); i++ {
+		if r.match(strings.Join(parts[:i], "/"), true) {
+			return true
+		}
+	}
+	return r.match(rel, isDir)
+}
+
+// match applies the rules to one slash-separated relative path; the last
+// matching rule decides
+func (r *IgnoreRules) match(rel string, isDir bool) bool {
+	ignored := false
+	for _, rule := range r.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if rule.pattern.MatchString(rel) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// FindPageFiles returns the markdown files under dirPath, in subdirectories
+// too, skipping ignored paths. Files are sorted so parsing is
+// deterministic.
+func FindPageFiles(dirPath string, rules *IgnoreRules) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dirPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dirPath {
+				if errors.Is(err, fs.ErrNotExist) {
+					// Like a glob, a missing directory has no pages
+					return filepath.SkipAll
+				}
+				return err
+			}
+			// Skip unreadable subdirectories rather than failing the scan
+			return nil
+		}
+		if path == dirPath {
+			return nil
+		}
+		
+		if rules.Ignored(path, entry.IsDir()) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	sort.Strings(files)
+	return files, nil
+}
\ No newline at end of file
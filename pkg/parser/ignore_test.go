@@ -0,0 +1,189 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestIgnoreRulesPatterns(t *testing.T) {
+	root := filepath.FromSlash("/vault")
+	tests := []struct {
+		name    string
+		content string
+		path    string
+		isDir   bool
+		want    bool
+	}{
+		{"defaults git", "", ".git", true, true},
+		{"defaults file in git", "", ".git/HEAD.md", false, true},
+		{"defaults logseq bak", "", "logseq/bak/pages/old.md", false, true},
+		{"defaults logseq recycle", "", "logseq/.recycle/20250101T000000.000000000Z.md", false, true},
+		{"defaults seq2b history", "", ".seq2b/history/objects/ab/ab12.md", false, true},
+		{"defaults seq2b trash", "", ".seq2b/trash/20250101T000000.000000000Z.md", false, true},
+		{"defaults keep folders named cache", "", "pages/cache/x.md", false, false},
+		{"defaults keep logseq pages", "", "logseq/custom.md", false, false},
+		{"plain name at any depth", "drafts", "pages/drafts/a.md", false, true},
+		{"extension glob", "*.tmp.md", "a/b/c.tmp.md", false, true},
+		{"star stays in segment", "a*.md", "ab/c.md", false, false},
+		{"anchored leading slash", "/top.md", "sub/top.md", false, false},
+		{"anchored matches root", "/top.md", "top.md", false, true},
+		{"inner slash anchors", "archive/old", "pages/archive/old", true, false},
+		{"inner slash anchors at root", "archive/old", "archive/old/x.md", false, true},
+		{"double star dirs", "**/private/*.md", "a/b/private/x.md", false, true},
+		{"double star trailing", "archive/**", "archive/2020/x.md", false, true},
+		{"question mark", "v?.md", "v1.md", false, true},
+		{"character class", "[ab].md", "b.md", false, true},
+		{"negated class", "[!ab].md", "b.md", false, false},
+		{"negation re-includes", "*.md\n!keep.md", "keep.md", false, false},
+		{"negation order", "!keep.md\n*.md", "keep.md", false, true},
+		{"no re-include under ignored dir", "drafts/\n!drafts/keep.md", "drafts/keep.md", false, true},
+		{"dir only pattern skips files", "notes/", "notes", false, false},
+		{"comments and blanks", "# drafts\n\n", "drafts/a.md", false, false},
+		{"escaped hash", `\#tag.md`, "#tag.md", false, true},
+		{"crlf lines", "drafts\r\nold.md\r\n", "old.md", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := NewIgnoreRules(root, tt.content)
+			path := filepath.Join(root, filepath.FromSlash(tt.path))
+			if got := rules.Ignored(path, tt.isDir); got != tt.want {
+				t.Errorf("Ignored(%q, %v) = %v, want %v", tt.path, tt.isDir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindPageFilesRecursive(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"top.md":                 "# Top",
+		"projects/alpha.md":      "# Alpha",
+		"projects/2025/beta.md":  "# Beta",
+		"drafts/wip.md":          "# WIP",
+		"cache/stale.md":         "# Stale",
+		"projects/cache/kept.md": "# Kept",
+		".git/notes.md":          "# Git",
+		"logseq/bak/pages/x.md":  "# Backup",
+		"projects/readme.txt":    "not a page",
+		IgnoreFileName:           "drafts/\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rules, err := LoadIgnoreRules(root)
+	if err != nil {
+		t.Fatalf("LoadIgnoreRules() error = %v", err)
+	}
+	rules.SkipDir(filepath.Join(root, "cache"))
+	rules.SkipDir(t.TempDir()) // Outside the root
+	got, err := FindPageFiles(root, rules)
+	if err != nil {
+		t.Fatalf("FindPageFiles() error = %v", err)
+	}
+	want := []string{
+		filepath.Join(root, "projects", "2025", "beta.md"),
+		filepath.Join(root, "projects", "alpha.md"),
+		filepath.Join(root, "projects", "cache", "kept.md"),
+		filepath.Join(root, "top.md"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindPageFiles() = %v, want %v", got, want)
+	}
+
+	if got, err := FindPageFiles(filepath.Join(root, "missing"), rules); err != nil || len(got) != 0 {
+		t.Errorf("missing directory: got %v, %v", got, err)
+	}
+}
+
+func TestParseDirectorySkipsCache(t *testing.T) {
+	root := t.TempDir()
+	writePages(t, root, map[string]string{
+		"cache/stale.md":      "- In the page cache",
+		"notes/cache/Kept.md": "- A folder that happens to be called cache",
+		"notes/Everything.md": "- Plain page",
+	})
+
+	result, err := ParseDirectory(root)
+	if err != nil {
+		t.Fatalf("ParseDirectory() error = %v", err)
+	}
+	if got, want := pageTitles(result), []string{"Everything", "Kept"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("titles = %v, want %v", got, want)
+	}
+}
+
+func TestParseDirectoryNestedPageNames(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"projects/alpha.md":        "- Links [[Beta]]",
+		"projects/archive/Beta.md": "# Beta\n\n- Archived",
+		"2025/01/2025_01_15.md":    "- Journal in a subfolder",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := ParseDirectory(root)
+	if err != nil {
+		t.Fatalf("ParseDirectory() error = %v", err)
+	}
+	for _, title := range []string{"alpha", "Beta", "Jan 15th, 2025"} {
+		if result.GetPage(title) == nil {
+			t.Errorf("page %q not found, got %v", title, result.Backlinks.GetAllPages())
+		}
+	}
+	if backlinks := result.Backlinks.GetBacklinks("Beta"); len(backlinks["alpha"]) != 1 {
+		t.Errorf("expected backlink from alpha to Beta, got %v", backlinks)
+	}
+
+	// Moving a file to another folder keeps its name
+	from := filepath.Join(root, "projects", "alpha.md")
+	to := filepath.Join(root, "alpha.md")
+	if err := os.Rename(from, to); err != nil {
+		t.Fatal(err)
+	}
+	result, err = ParseDirectory(root)
+	if err != nil {
+		t.Fatalf("ParseDirectory() error = %v", err)
+	}
+	if result.Files[to] != "alpha" {
+		t.Errorf("moved file key = %q, want alpha", result.Files[to])
+	}
+}
@@ -0,0 +1,385 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JournalFormat describes how journal pages are titled and named on disk.
+// Both formats use the date-fns tokens Logseq uses in config.edn
+// (yyyy, yy, MMMM, MMM, MM, M, dd, d, do, EEEE, EEE, EE, E); text in
+// single quotes is copied literally.
+type JournalFormat struct {
+	TitleFormat    string // e.g. "MMM do, yyyy" -> Jan 15th, 2025
+	FileNameFormat string // e.g. "yyyy_MM_dd" -> 2025_01_15.md
+}
+
+// DefaultJournalFormat matches the titles and filenames seq2b has always written
+var DefaultJournalFormat = JournalFormat{
+	TitleFormat:    "MMM do, yyyy",
+	FileNameFormat: "yyyy-MM-dd",
+}
+
+// Filename formats tried when a journal file doesn't match the configured one.
+// Logseq defaults to yyyy_MM_dd, older seq2b versions wrote ISO dates.
+var fallbackFileNameFormats = []string{"yyyy-MM-dd", "yyyy_MM_dd"}
+
+// Patterns used to pull journal settings out of logseq/config.edn
+var (
+	ednTitleFormatPattern    = regexp.MustCompile(`:journal/page-title-format\s+"([^"]*)"`)
+	ednFileNameFormatPattern = regexp.MustCompile(`:journal/file-name-format\s+"([^"]*)"`)
+)
+
+// Validate checks that both formats only contain supported tokens
+func (f JournalFormat) Validate() error {
+	if _, err := compileDateFormat(f.TitleFormat); err != nil {
+		return fmt.Errorf("invalid journal title format %q: %w", f.TitleFormat, err)
+	}
+	if _, err := compileDateFormat(f.FileNameFormat); err != nil {
+		return fmt.Errorf("invalid journal file name format %q: %w", f.FileNameFormat, err)
+	}
+	return nil
+}
+
+// FormatTitle formats a date as a journal page title
+func (f JournalFormat) FormatTitle(date time.Time) string {
+	return formatWithFallback(f.TitleFormat, DefaultJournalFormat.TitleFormat, date)
+}
+
+// FileName returns the filename (with .md extension) for a journal date
+func (f JournalFormat) FileName(date time.Time) string {
+	return formatWithFallback(f.FileNameFormat, DefaultJournalFormat.FileNameFormat, date) + ".md"
+}
+
+// ParseTitle parses a journal page title. The configured title format is
+// tried first, then the file name format, then the built-in formats
+// understood by ParseDateTitle.
+func (f JournalFormat) ParseTitle(title string) (time.Time, error) {
+	title = strings.TrimSpace(title)
+	for _, format := range []string{f.TitleFormat, f.FileNameFormat} {
+		if date, err := parseWithFormat(format, title); err == nil {
+			return date, nil
+		}
+	}
+	return ParseDateTitle(title)
+}
+
+// ParseFileName extracts the date from a journal filename such as 2025_01_15.md
+func (f JournalFormat) ParseFileName(filename string) (time.Time, error) {
+	name := strings.TrimSuffix(filepath.Base(filename), ".md")
+	
+	formats := append([]string{f.FileNameFormat}, fallbackFileNameFormats...)
+	for _, format := range formats {
+		if date, err := parseWithFormat(format, name); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("not a journal filename: %s", filename)
+}
+
+// IsJournalTitle reports whether a page title names a journal date
+func (f JournalFormat) IsJournalTitle(title string) bool {
+	_, err := f.ParseTitle(title)
+	return err == nil
+}
+
+// CandidateFileNames returns every filename a journal for this date may
+// already be stored under, starting with the configured one
+func (f JournalFormat) CandidateFileNames(date time.Time) []string {
+	names := []string{f.FileName(date)}
+	for _, format := range fallbackFileNameFormats {
+		name := formatWithFallback(format, format, date) + ".md"
+		if !containsString(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// LoadJournalFormat reads the journal formats from a library's
+// logseq/config.edn. Missing keys keep their defaults; a missing file is
+// not an error.
+func LoadJournalFormat(libraryPath string) (JournalFormat, error) {
+	format := DefaultJournalFormat
+	
+	content, err := os.ReadFile(filepath.Join(libraryPath, "logseq", "config.edn"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return format, nil
+		}
+		return format, fmt.Errorf("error reading config.edn: %w", err)
+	}
+	
+	if matches := ednTitleFormatPattern.FindSubmatch(content); matches != nil {
+		format.TitleFormat = string(matches[1])
+	}
+	if matches := ednFileNameFormatPattern.FindSubmatch(content); matches != nil {
+		format.FileNameFormat = string(matches[1])
+	}
+	
+	if err := format.Validate(); err != nil {
+		return DefaultJournalFormat, err
+	}
+	return format, nil
+}
+
+// dateToken is one element of a compiled date format
+type dateToken struct {
+	token   string // date-fns token, empty for literal text
+	literal string
+}
+
+// compiledDateFormat holds the tokens and parsing regex for a format
+type compiledDateFormat struct {
+	tokens  []dateToken
+	pattern *regexp.Regexp
+}
+
+// Supported tokens, longest first so "MMMM" wins over "MM"
+var dateFormatTokens = []string{"yyyy", "yy", "MMMM", "MMM", "MM", "M", "do", "dd", "d", "EEEE", "EEE", "EE", "E"}
+
+var (
+	compiledFormats   = make(map[string]*compiledDateFormat)
+	compiledFormatsMu sync.Mutex
+)
+
+// compileDateFormat tokenizes a format and builds a regex that parses it
+func compileDateFormat(format string) (*compiledDateFormat, error) {
+	compiledFormatsMu.Lock()
+	defer compiledFormatsMu.Unlock()
+	
+	if compiled, ok := compiledFormats[format]; ok {
+		return compiled, nil
+	}
+	if strings.TrimSpace(format) == "" {
+		return nil, fmt.Errorf("empty date format")
+	}
+	
+	var tokens []dateToken
+	var pattern strings.Builder
+	pattern.WriteString("(?i)^")
+	
+	for i := 0; i < len(format); {
+		// Quoted literal text
+		if format[i] == '\'' {
+			end := strings.IndexByte(format[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote")
+			}
+			literal := format[i+1 : i+1+end]
+			tokens = append(tokens, dateToken{literal: literal})
+			pattern.WriteString(regexp.QuoteMeta(literal))
+			i += end + 2
+			continue
+		}
+		
+		matched := ""
+		for _, token := range dateFormatTokens {
+			if strings.HasPrefix(format[i:], token) {
+				matched = token
+				break
+			}
+		}
+		
+		if matched == "" {
+			ch := format[i]
+			if (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') {
+				return nil, fmt.Errorf("unsupported token at %q", format[i:])
+			}
+			tokens = append(tokens, dateToken{literal: string(ch)})
+			pattern.WriteString(regexp.QuoteMeta(string(ch)))
+			i++
+			continue
+		}
+		
+		tokens = append(tokens, dateToken{token: matched})
+		pattern.WriteString(tokenPattern(matched))
+		i += len(matched)
+	}
+	
+	pattern.WriteString("$")
+	compiled := &compiledDateFormat{
+		tokens:  tokens,
+		pattern: regexp.MustCompile(pattern.String()),
+	}
+	compiledFormats[format] = compiled
+	return compiled, nil
+}
+
+// tokenPattern returns the capturing regex for a single token
+func tokenPattern(token string) string {
+	switch token {
+	case "yyyy":
+		return `(\d{4})`
+	case "yy", "MM", "dd":
+		return `(\d{2})`
+	case "M", "d":
+		return `(\d{1,2})`
+	case "do":
+		return `(\d{1,2})(?:st|nd|rd|th)`
+	case "MMMM":
+		return `(january|february|march|april|may|june|july|august|september|october|november|december)`
+	case "MMM":
+		return `(jan|feb|mar|apr|may|jun|jul|aug|sept|sep|oct|nov|dec)`
+	case "EEEE":
+		return `(monday|tuesday|wednesday|thursday|friday|saturday|sunday)`
+	default: // EEE, EE, E
+		return `(mon|tue|wed|thu|fri|sat|sun)`
+	}
+}
+
+// formatWithFallback formats a date, using fallback if format is invalid
+func formatWithFallback(format, fallback string, date time.Time) string {
+	compiled, err := compileDateFormat(format)
+	if err != nil {
+		compiled, _ = compileDateFormat(fallback)
+	}
+	return compiled.format(date)
+}
+
+// format renders a date using the compiled tokens
+func (c *compiledDateFormat) format(date time.Time) string {
+	var sb strings.Builder
+	for _, tok := range c.tokens {
+		switch tok.token {
+		case "":
+			sb.WriteString(tok.literal)
+		case "yyyy":
+			sb.WriteString(fmt.Sprintf("%04d", date.Year()))
+		case "yy":
+			sb.WriteString(fmt.Sprintf("%02d", date.Year()%100))
+		case "MMMM":
+			sb.WriteString(date.Month().String())
+		case "MMM":
+			sb.WriteString(date.Format("Jan"))
+		case "MM":
+			sb.WriteString(fmt.Sprintf("%02d", int(date.Month())))
+		case "M":
+			sb.WriteString(strconv.Itoa(int(date.Month())))
+		case "dd":
+			sb.WriteString(fmt.Sprintf("%02d", date.Day()))
+		case "d":
+			sb.WriteString(strconv.Itoa(date.Day()))
+		case "do":
+			sb.WriteString(strconv.Itoa(date.Day()) + getOrdinalSuffix(date.Day()))
+		case "EEEE":
+			sb.WriteString(date.Weekday().String())
+		default: // EEE, EE, E
+			sb.WriteString(date.Format("Mon"))
+		}
+	}
+	return sb.String()
+}
+
+// parseWithFormat parses s using a date-fns style format
+func parseWithFormat(format, s string) (time.Time, error) {
+	compiled, err := compileDateFormat(format)
+	if err != nil {
+		return time.Time{}, err
+	}
+	
+	matches := compiled.pattern.FindStringSubmatch(s)
+	if matches == nil {
+		return time.Time{}, fmt.Errorf("%q does not match format %q", s, format)
+	}
+	
+	year, month, day := -1, -1, -1
+	weekday := -1
+	group := 1
+	for _, tok := range compiled.tokens {
+		if tok.token == "" {
+			continue
+		}
+		value := matches[group]
+		group++
+		
+		switch tok.token {
+		case "yyyy":
+			year, _ = strconv.Atoi(value)
+		case "yy":
+			yy, _ := strconv.Atoi(value)
+			year = 2000 + yy
+		case "MMMM", "MMM":
+			month = monthFromName(value)
+		case "MM", "M":
+			month, _ = strconv.Atoi(value)
+		case "dd", "d", "do":
+			day, _ = strconv.Atoi(value)
+		default:
+			weekday = weekdayFromName(value)
+		}
+	}
+	
+	if year < 0 || month < 1 || month > 12 || day < 1 {
+		return time.Time{}, fmt.Errorf("format %q does not describe a full date", format)
+	}
+	
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Day() != day {
+		return time.Time{}, fmt.Errorf("invalid day %d for %s %d", day, time.Month(month), year)
+	}
+	if weekday >= 0 && int(date.Weekday()) != weekday {
+		return time.Time{}, fmt.Errorf("%s is not a %s", FormatDateISO(date), time.Weekday(weekday))
+	}
+	return date, nil
+}
+
+// monthFromName maps a full or abbreviated month name to its number
+func monthFromName(name string) int {
+	name = strings.ToLower(name)
+	for m := time.January; m <= time.December; m++ {
+		if strings.HasPrefix(strings.ToLower(m.String()), name[:3]) {
+			return int(m)
+		}
+	}
+	return -1
+}
+
+// weekdayFromName maps a full or abbreviated weekday name to time.Weekday
+func weekdayFromName(name string) int {
+	name = strings.ToLower(name)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.HasPrefix(strings.ToLower(d.String()), name[:3]) {
+			return int(d)
+		}
+	}
+	return -1
+}
+
+// containsString checks if a string slice contains a value
+func containsString(slice []string, value string) bool {
+	for _, v := range slice {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
\ No newline at end of file
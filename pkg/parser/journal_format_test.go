@@ -0,0 +1,313 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestJournalFormatRoundTrip(t *testing.T) {
+	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		format   JournalFormat
+		title    string
+		filename string
+	}{
+		{
+			name:     "default",
+			format:   DefaultJournalFormat,
+			title:    "Jan 15th, 2025",
+			filename: "2025-01-15.md",
+		},
+		{
+			name:     "logseq underscores",
+			format:   JournalFormat{TitleFormat: "MMM do, yyyy", FileNameFormat: "yyyy_MM_dd"},
+			title:    "Jan 15th, 2025",
+			filename: "2025_01_15.md",
+		},
+		{
+			name:     "weekday with dots",
+			format:   JournalFormat{TitleFormat: "EEEE, dd.MM.yyyy", FileNameFormat: "yyyy_MM_dd"},
+			title:    "Wednesday, 15.01.2025",
+			filename: "2025_01_15.md",
+		},
+		{
+			name:     "full month",
+			format:   JournalFormat{TitleFormat: "MMMM d, yyyy", FileNameFormat: "yyyyMMdd"},
+			title:    "January 15, 2025",
+			filename: "20250115.md",
+		},
+		{
+			name:     "quoted literal",
+			format:   JournalFormat{TitleFormat: "'Day' d 'of' MMMM yyyy", FileNameFormat: "yyyy-MM-dd"},
+			title:    "Day 15 of January 2025",
+			filename: "2025-01-15.md",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.format.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if got := tt.format.FormatTitle(date); got != tt.title {
+				t.Errorf("FormatTitle() = %q, want %q", got, tt.title)
+			}
+			if got := tt.format.FileName(date); got != tt.filename {
+				t.Errorf("FileName() = %q, want %q", got, tt.filename)
+			}
+
+			parsed, err := tt.format.ParseTitle(tt.title)
+			if err != nil || !parsed.Equal(date) {
+				t.Errorf("ParseTitle(%q) = %v, %v; want %v", tt.title, parsed, err, date)
+			}
+			parsed, err = tt.format.ParseFileName(tt.filename)
+			if err != nil || !parsed.Equal(date) {
+				t.Errorf("ParseFileName(%q) = %v, %v; want %v", tt.filename, parsed, err, date)
+			}
+		})
+	}
+}
+
+func TestJournalFormatParsing(t *testing.T) {
+	format := JournalFormat{TitleFormat: "EEEE, dd.MM.yyyy", FileNameFormat: "yyyy_MM_dd"}
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"configured title", "Wednesday, 15.01.2025", false},
+		{"lowercase weekday", "wednesday, 15.01.2025", false},
+		{"wrong weekday", "Monday, 15.01.2025", true},
+		{"builtin format still works", "Jan 15th, 2025", false},
+		{"file name format as title", "2025_01_15", false},
+		{"invalid day", "Friday, 31.02.2025", true},
+		{"not a date", "Project Notes", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := format.ParseTitle(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseTitle(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJournalFormatFallbackFileNames(t *testing.T) {
+	format := JournalFormat{TitleFormat: "MMM do, yyyy", FileNameFormat: "yyyyMMdd"}
+	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	for _, name := range []string{"20250115.md", "2025-01-15.md", "2025_01_15.md"} {
+		parsed, err := format.ParseFileName(name)
+		if err != nil || !parsed.Equal(date) {
+			t.Errorf("ParseFileName(%q) = %v, %v; want %v", name, parsed, err, date)
+		}
+	}
+
+	candidates := format.CandidateFileNames(date)
+	want := []string{"20250115.md", "2025-01-15.md", "2025_01_15.md"}
+	if len(candidates) != len(want) {
+		t.Fatalf("CandidateFileNames() = %v, want %v", candidates, want)
+	}
+	for i := range want {
+		if candidates[i] != want[i] {
+			t.Errorf("CandidateFileNames()[%d] = %q, want %q", i, candidates[i], want[i])
+		}
+	}
+}
+
+func TestJournalFormatValidate(t *testing.T) {
+	invalid := []JournalFormat{
+		{TitleFormat: "", FileNameFormat: "yyyy-MM-dd"},
+		{TitleFormat: "MMM do, yyyy", FileNameFormat: "YYYY-mm-DD"},
+		{TitleFormat: "'unterminated", FileNameFormat: "yyyy-MM-dd"},
+	}
+	for _, format := range invalid {
+		if err := format.Validate(); err == nil {
+			t.Errorf("Validate(%+v) expected error", format)
+		}
+	}
+}
+
+func TestLoadJournalFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// No config.edn: defaults
+	format, err := LoadJournalFormat(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadJournalFormat() error = %v", err)
+	}
+	if format != DefaultJournalFormat {
+		t.Errorf("LoadJournalFormat() = %+v, want defaults", format)
+	}
+
+	configDir := filepath.Join(tmpDir, "logseq")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := `{:meta/version 1
+ :journal/page-title-format "EEEE, dd.MM.yyyy"
+ :journal/file-name-format "yyyy_MM_dd"
+ :preferred-format :markdown}`
+	if err := os.WriteFile(filepath.Join(configDir, "config.edn"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	format, err = LoadJournalFormat(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadJournalFormat() error = %v", err)
+	}
+	if format.TitleFormat != "EEEE, dd.MM.yyyy" || format.FileNameFormat != "yyyy_MM_dd" {
+		t.Errorf("LoadJournalFormat() = %+v", format)
+	}
+}
+
+func TestParseDirectoryNamesHeaderlessJournals(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Logseq journal files have no header
+	if err := os.WriteFile(filepath.Join(tmpDir, "2025_01_15.md"), []byte("- Worked on [[Parser]]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := DefaultParseOptions()
+	opts.JournalFormat = JournalFormat{TitleFormat: "EEEE, dd.MM.yyyy", FileNameFormat: "yyyy_MM_dd"}
+	result, err := ParseDirectoryWithOptions(tmpDir, opts)
+	if err != nil {
+		t.Fatalf("ParseDirectoryWithOptions() error = %v", err)
+	}
+
+	page := result.GetPage("Wednesday, 15.01.2025")
+	if page == nil {
+		t.Fatalf("journal page not found, got pages %v", result.Backlinks.GetAllPages())
+	}
+	if len(page.Blocks) != 1 {
+		t.Errorf("expected 1 block, got %d", len(page.Blocks))
+	}
+	if backlinks := result.Backlinks.GetBacklinks("Parser"); len(backlinks["2025-01-15"]) != 1 {
+		t.Errorf("expected backlink from journal to Parser, got %v", backlinks)
+	}
+}
+
+func TestParseDirectoryMarksJournals(t *testing.T) {
+	libDir := t.TempDir()
+	pagesDir := filepath.Join(libDir, "pages")
+	journalsDir := filepath.Join(libDir, "journals")
+	for _, dir := range []string{pagesDir, journalsDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files := map[string]string{
+		filepath.Join(pagesDir, "project.md"):       "# Project\n\n- Notes",
+		filepath.Join(pagesDir, "2025-01-10.md"):    "# Jan 10th, 2025\n\n- Legacy journal in pages/",
+		filepath.Join(journalsDir, "2025_01_15.md"): "- Worked on [[Project]]",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := ParseDirectory(pagesDir)
+	if err != nil {
+		t.Fatalf("ParseDirectory() error = %v", err)
+	}
+
+	opts := DefaultParseOptions()
+	opts.Journal = true
+	journals, err := ParseDirectoryWithOptions(journalsDir, opts)
+	if err != nil {
+		t.Fatalf("ParseDirectoryWithOptions() error = %v", err)
+	}
+	result.Merge(journals)
+
+	tests := []struct {
+		title   string
+		journal bool
+	}{
+		{"Project", false},
+		{"Jan 10th, 2025", true},
+		{"Jan 15th, 2025", true},
+	}
+	for _, tt := range tests {
+		page := result.GetPage(tt.title)
+		if page == nil {
+			t.Errorf("page %q not found after merge", tt.title)
+			continue
+		}
+		if page.IsJournal != tt.journal {
+			t.Errorf("page %q IsJournal = %v, want %v", tt.title, page.IsJournal, tt.journal)
+		}
+	}
+
+	if backlinks := result.Backlinks.GetBacklinks("Project"); len(backlinks["2025-01-15"]) != 1 {
+		t.Errorf("expected merged backlink from journal to Project, got %v", backlinks)
+	}
+
+	wantFiles := map[string]string{
+		filepath.Join(pagesDir, "project.md"):       "project",
+		filepath.Join(pagesDir, "2025-01-10.md"):    "2025-01-10",
+		filepath.Join(journalsDir, "2025_01_15.md"): "2025-01-15",
+	}
+	if !reflect.DeepEqual(result.Files, wantFiles) {
+		t.Errorf("Files = %v, want %v", result.Files, wantFiles)
+	}
+}
+
+func TestParsePageFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "2025_01_15.md")
+	if err := os.WriteFile(path, []byte("- Worked on [[Project]]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := DefaultParseOptions()
+	opts.Journal = true
+	page, err := ParsePageFile(path, opts)
+	if err != nil {
+		t.Fatalf("ParsePageFile() error = %v", err)
+	}
+	if page.Title != "Jan 15th, 2025" || !page.IsJournal {
+		t.Errorf("got title %q, journal %v", page.Title, page.IsJournal)
+	}
+	if page.Hash != ContentHash([]byte("- Worked on [[Project]]")) {
+		t.Errorf("Hash = %q, want the hash of the file content", page.Hash)
+	}
+	if page.Hash == ContentHash([]byte("- Worked on [[Project]]\n")) {
+		t.Error("different content should hash differently")
+	}
+
+	if _, err := ParsePageFile(filepath.Join(t.TempDir(), "missing.md"), opts); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -61,8 +61,14 @@ var (
 	blockRefPattern    = regexp.MustCompile(`\(\(([a-fA-F0-9\-]+)\)\)`)
 )
 
-// ParseLine analyzes a single line and returns its type and content
+// ParseLine analyzes a single line and returns its type and content, using
+// the default TODO keywords
 func ParseLine(number int, line string) Line {
+	return DefaultTodoKeywords.ParseLine(number, line)
+}
+
+// ParseLine analyzes a single line and returns its type and content
+func (k *TodoKeywords) ParseLine(number int, line string) Line {
 	trimmed := strings.TrimSpace(line)
 	
 	// Empty line
@@ -95,7 +101,7 @@ func ParseLine(number int, line string) Line {
 		blockText := strings.TrimSpace(trimmed[1:])
 		
 		// Parse TODO information from the block content
-		todoInfo := ParseTodoInfo(blockText)
+		todoInfo := k.ParseTodoInfo(blockText)
 		
 		// Extract page references
 		references := extractPageReferences(blockText)
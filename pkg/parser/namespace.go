@@ -0,0 +1,220 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"sort"
+	"strings"
+)
+
+// NamespaceSeparator splits namespaced titles such as Projects/seq2b/Parser
+const NamespaceSeparator = "/"
+
+// SplitNamespace returns the parts of a namespaced title, ignoring empty
+// parts and surrounding whitespace
+func SplitNamespace(title string) []string {
+	var parts []string
+	for _, part := range strings.Split(title, NamespaceSeparator) {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// NamespaceParent returns the namespace a title belongs to, or "" for a
+// top-level page
+func NamespaceParent(title string) string {
+	parts := SplitNamespace(title)
+	if len(parts) < 2 {
+		return ""
+	}
+	return strings.Join(parts[:len(parts)-1], NamespaceSeparator)
+}
+
+// NamespaceAncestors returns every enclosing namespace of a title, outermost
+// first: Projects/seq2b/Parser -> [Projects, Projects/seq2b]
+func NamespaceAncestors(title string) []string {
+	parts := SplitNamespace(title)
+	ancestors := make([]string, 0, len(parts))
+	for i := 1; i < len(parts); i++ {
+		ancestors = append(ancestors, strings.Join(parts[:i], NamespaceSeparator))
+	}
+	return ancestors
+}
+
+// NamespaceNode is a page or intermediate namespace in the hierarchy
+type NamespaceNode struct {
+	Name     string           // Full name, e.g. Projects/seq2b
+	Title    string           // Last part of the name, e.g. seq2b
+	Exists   bool             // A page with this name exists
+	Parent   *NamespaceNode   // Nil for top-level nodes
+	Children []*NamespaceNode // Sorted by title
+}
+
+// NamespaceTree indexes pages by namespace. Namespaces without a page of
+// their own (Projects when only Projects/seq2b exists) appear as nodes with
+// Exists false.
+type NamespaceTree struct {
+	Roots []*NamespaceNode // Top-level nodes, sorted by title
+	
+	// KeyFunc maps page names to lookup keys; PageKey when nil
+	KeyFunc func(string) string
+	
+	nodes map[string]*NamespaceNode
+}
+
+// BuildNamespaceTree builds the namespace hierarchy of a set of pages
+func BuildNamespaceTree(pages map[string]*Page, keyFunc func(string) string) *NamespaceTree {
+	tree := &NamespaceTree{
+		KeyFunc: keyFunc,
+		nodes:   make(map[string]*NamespaceNode),
+	}
+	
+	for _, page := range pages {
+		node := tree.ensure(SplitNamespace(page.Title))
+		if node != nil {
+			node.Exists = true
+		}
+	}
+	
+	sortNamespaceNodes(tree.Roots)
+	return tree
+}
+
+func (t *NamespaceTree) key(name string) string {
+	if t.KeyFunc != nil {
+		return t.KeyFunc(name)
+	}
+	return PageKey(name)
+}
+
+// ensure returns the node for a namespace path, creating missing ancestors
+func (t *NamespaceTree) ensure(parts []string) *NamespaceNode {
+	var parent *NamespaceNode
+	for i := range parts {
+		name := strings.Join(parts[:i+1], NamespaceSeparator)
+		key := t.key(name)
+		node, ok := t.nodes[key]
+		if !ok {
+			node = &NamespaceNode{Name: name, Title: parts[i], Parent: parent}
+			t.nodes[key] = node
+			if parent == nil {
+				t.Roots = append(t.Roots, node)
+			} else {
+				parent.Children = append(parent.Children, node)
+			}
+		}
+		parent = node
+	}
+	return parent
+}
+
+func sortNamespaceNodes(nodes []*NamespaceNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return strings.ToLower(nodes[i].Title) < strings.ToLower(nodes[j].Title)
+	})
+	for _, node := range nodes {
+		sortNamespaceNodes(node.Children)
+	}
+}
+
+// Node returns the node for a page or namespace name
+func (t *NamespaceTree) Node(name string) *NamespaceNode {
+	return t.nodes[t.key(strings.Join(SplitNamespace(name), NamespaceSeparator))]
+}
+
+// Children returns the names of the direct children of a namespace
+func (t *NamespaceTree) Children(name string) []string {
+	node := t.Node(name)
+	if node == nil {
+		return nil
+	}
+	children := make([]string, len(node.Children))
+	for i, child := range node.Children {
+		children[i] = child.Name
+	}
+	return children
+}
+
+// Ancestors returns the enclosing namespaces of a page, outermost first
+func (t *NamespaceTree) Ancestors(name string) []*NamespaceNode {
+	node := t.Node(name)
+	if node == nil {
+		return nil
+	}
+	var ancestors []*NamespaceNode
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		ancestors = append([]*NamespaceNode{parent}, ancestors...)
+	}
+	return ancestors
+}
+
+// Subtree returns the names of every node below a namespace, depth first.
+// With pagesOnly, namespaces without a page of their own are left out.
+func (t *NamespaceTree) Subtree(name string, pagesOnly bool) []string {
+	node := t.Node(name)
+	if node == nil {
+		return nil
+	}
+	var names []string
+	var walk func(nodes []*NamespaceNode)
+	walk = func(nodes []*NamespaceNode) {
+		for _, child := range nodes {
+			if child.Exists || !pagesOnly {
+				names = append(names, child.Name)
+			}
+			walk(child.Children)
+		}
+	}
+	walk(node.Children)
+	return names
+}
+
+// InNamespace reports whether title lies below namespace (at any depth)
+func InNamespace(title, namespace string) bool {
+	parts := SplitNamespace(title)
+	nsParts := SplitNamespace(namespace)
+	if len(nsParts) == 0 || len(parts) <= len(nsParts) {
+		return false
+	}
+	for i, part := range nsParts {
+		if PageKey(part) != PageKey(parts[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetNamespaceBacklinks returns the backlinks to a namespace page and every
+// page below it, keyed by target page key and then source page key
+func (idx *BacklinkIndex) GetNamespaceBacklinks(namespace string) map[string]map[string][]BlockReference {
+	result := make(map[string]map[string][]BlockReference)
+	namespaceKey := idx.Key(namespace)
+	for targetKey, sources := range idx.BackwardLinks {
+		if targetKey == namespaceKey || InNamespace(idx.Title(targetKey), namespace) {
+			result[targetKey] = sources
+		}
+	}
+	return result
+}
\ No newline at end of file
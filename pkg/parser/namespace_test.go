@@ -0,0 +1,109 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNamespaceHelpers(t *testing.T) {
+	if got := SplitNamespace(" Projects / seq2b/Parser/"); !reflect.DeepEqual(got, []string{"Projects", "seq2b", "Parser"}) {
+		t.Errorf("SplitNamespace = %v", got)
+	}
+	if got := NamespaceParent("Projects/seq2b/Parser"); got != "Projects/seq2b" {
+		t.Errorf("NamespaceParent = %q", got)
+	}
+	if got := NamespaceParent("Projects"); got != "" {
+		t.Errorf("NamespaceParent of top-level page = %q", got)
+	}
+	if got := NamespaceAncestors("Projects/seq2b/Parser"); !reflect.DeepEqual(got, []string{"Projects", "Projects/seq2b"}) {
+		t.Errorf("NamespaceAncestors = %v", got)
+	}
+	if !InNamespace("Projects/seq2b/Parser", "Projects") || InNamespace("Projects", "Projects") || InNamespace("Projectsx/a", "Projects") {
+		t.Error("InNamespace gave wrong results")
+	}
+}
+
+func TestNamespaceTree(t *testing.T) {
+	pages := map[string]*Page{}
+	for _, title := range []string{"Projects/seq2b/Parser", "Projects/seq2b/UI", "Projects/Archive", "Projects/seq2b", "Inbox"} {
+		pages[title] = &Page{Title: title}
+	}
+	tree := BuildNamespaceTree(pages, nil)
+
+	if len(tree.Roots) != 2 || tree.Roots[0].Title != "Inbox" || tree.Roots[1].Title != "Projects" {
+		t.Fatalf("Unexpected roots: %+v", tree.Roots)
+	}
+	if tree.Roots[1].Exists {
+		t.Error("Projects has no page of its own")
+	}
+
+	if got := tree.Children("Projects"); !reflect.DeepEqual(got, []string{"Projects/Archive", "Projects/seq2b"}) {
+		t.Errorf("Children = %v", got)
+	}
+	if got := tree.Subtree("Projects", false); !reflect.DeepEqual(got, []string{"Projects/Archive", "Projects/seq2b", "Projects/seq2b/Parser", "Projects/seq2b/UI"}) {
+		t.Errorf("Subtree = %v", got)
+	}
+
+	ancestors := tree.Ancestors("Projects/seq2b/Parser")
+	if len(ancestors) != 2 || ancestors[0].Name != "Projects" || ancestors[1].Name != "Projects/seq2b" || !ancestors[1].Exists {
+		t.Errorf("Unexpected ancestors: %+v", ancestors)
+	}
+	if tree.Node("Missing") != nil {
+		t.Error("Expected nil node for unknown namespace")
+	}
+}
+
+func TestNamespaceFilenames(t *testing.T) {
+	if got := TitleToFilename("Projects/seq2b"); got != "projects___seq2b.md" {
+		t.Errorf("TitleToFilename = %q", got)
+	}
+	if TitleToFilename("Projects/seq2b") == TitleToFilename("Projects-seq2b") {
+		t.Error("Namespaced and hyphenated titles must not collide")
+	}
+	if got := CandidateFilenames("Projects/seq2b"); !reflect.DeepEqual(got, []string{"projects___seq2b.md", "projects-seq2b.md"}) {
+		t.Errorf("CandidateFilenames = %v", got)
+	}
+	if got := CandidateFilenames("Inbox"); len(got) != 1 {
+		t.Errorf("CandidateFilenames for plain title = %v", got)
+	}
+}
+
+func TestGetNamespaceBacklinks(t *testing.T) {
+	idx := NewBacklinkIndex()
+	notes := &Page{Title: "Notes", Blocks: []*Block{
+		{ID: "block-1", Content: "[[Projects]] and [[Projects/seq2b]]"},
+		{ID: "block-2", Content: "[[Projects/seq2b/Parser]] but not [[Projectsx]]"},
+	}}
+	notes.AllBlocks = notes.Blocks
+	idx.AddPage(notes)
+
+	backlinks := idx.GetNamespaceBacklinks("Projects")
+	if len(backlinks) != 3 {
+		t.Errorf("Expected 3 targets in namespace, got %v", backlinks)
+	}
+	if _, ok := backlinks[PageKey("Projectsx")]; ok {
+		t.Error("Projectsx is not in the Projects namespace")
+	}
+}
@@ -0,0 +1,320 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Clock returns the current time. It is injectable so relative dates can be
+// resolved deterministically in tests.
+type Clock func() time.Time
+
+// NaturalDateParser resolves phrases like "next friday", "in 3 days" or
+// "last monday of march" relative to its clock
+type NaturalDateParser struct {
+	Now Clock
+}
+
+// NewNaturalDateParser creates a parser using the given clock (time.Now if nil)
+func NewNaturalDateParser(now Clock) *NaturalDateParser {
+	if now == nil {
+		now = time.Now
+	}
+	return &NaturalDateParser{Now: now}
+}
+
+// ParseNaturalDate parses a natural-language date relative to the current time
+func ParseNaturalDate(phrase string) (time.Time, error) {
+	return NewNaturalDateParser(nil).Parse(phrase)
+}
+
+// Regular expressions for natural-language phrases
+var (
+	relativeOffsetPattern = regexp.MustCompile(`^in (\w+) (day|week|month|year)s?$`)
+	agoOffsetPattern      = regexp.MustCompile(`^(\w+) (day|week|month|year)s? ago$`)
+	relativeUnitPattern   = regexp.MustCompile(`^(next|last|this) (week|month|year)$`)
+	weekdayPattern        = regexp.MustCompile(`^(?:(next|last|this) )?(\w+)$`)
+	nthWeekdayPattern     = regexp.MustCompile(`^(first|second|third|fourth|fifth|1st|2nd|3rd|4th|5th|last) (\w+) of (.+)$`)
+	monthYearPattern      = regexp.MustCompile(`^(\w+)(?: (\d{4}))?$`)
+	monthDayPattern       = regexp.MustCompile(`^(?:(\w+) (\d{1,2})(?:st|nd|rd|th)?|(\d{1,2})(?:st|nd|rd|th)? (?:of )?(\w+))(?:,? (\d{4}))?$`)
+)
+
+// Small number words accepted in offsets ("in two weeks", "a day ago")
+var numberWords = map[string]int{
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+}
+
+// Ordinals used in "first friday of june" style phrases
+var ordinalWords = map[string]int{
+	"first": 1, "1st": 1, "second": 2, "2nd": 2, "third": 3, "3rd": 3,
+	"fourth": 4, "4th": 4, "fifth": 5, "5th": 5, "last": -1,
+}
+
+// Parse resolves a phrase to a date (midnight UTC, like ParseDateTitle).
+// Absolute dates in any format ParseDateTitle understands are accepted too.
+func (p *NaturalDateParser) Parse(phrase string) (time.Time, error) {
+	text := normalizePhrase(phrase)
+	if text == "" {
+		return time.Time{}, fmt.Errorf("empty date phrase")
+	}
+	
+	now := p.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	
+	switch text {
+	case "today", "now":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "day after tomorrow", "the day after tomorrow":
+		return today.AddDate(0, 0, 2), nil
+	case "day before yesterday", "the day before yesterday":
+		return today.AddDate(0, 0, -2), nil
+	}
+	
+	// "in 3 days", "in two weeks"
+	if m := relativeOffsetPattern.FindStringSubmatch(text); m != nil {
+		if n, ok := parseCount(m[1]); ok {
+			return addUnits(today, m[2], n), nil
+		}
+	}
+	
+	// "3 days ago", "a week ago"
+	if m := agoOffsetPattern.FindStringSubmatch(text); m != nil {
+		if n, ok := parseCount(m[1]); ok {
+			return addUnits(today, m[2], -n), nil
+		}
+	}
+	
+	// "next week", "last month", "this year"
+	if m := relativeUnitPattern.FindStringSubmatch(text); m != nil {
+		switch m[1] {
+		case "next":
+			return addUnits(today, m[2], 1), nil
+		case "last":
+			return addUnits(today, m[2], -1), nil
+		default:
+			return today, nil
+		}
+	}
+	
+	// "friday", "next friday", "last monday", "this sunday"
+	if m := weekdayPattern.FindStringSubmatch(text); m != nil {
+		if isWeekdayName(m[2]) {
+			weekday := time.Weekday(weekdayFromName(m[2]))
+			return resolveWeekday(today, weekday, m[1]), nil
+		}
+	}
+	
+	// "last monday of march", "first friday of next month"
+	if m := nthWeekdayPattern.FindStringSubmatch(text); m != nil {
+		if isWeekdayName(m[2]) {
+			year, month, err := resolveMonth(today, m[3])
+			if err != nil {
+				return time.Time{}, err
+			}
+			weekday := time.Weekday(weekdayFromName(m[2]))
+			return nthWeekdayOfMonth(year, month, weekday, ordinalWords[m[1]])
+		}
+	}
+	
+	// "march 15", "15th of march", "march 15, 2026"
+	if m := monthDayPattern.FindStringSubmatch(text); m != nil {
+		monthName, dayText := m[1], m[2]
+		if monthName == "" {
+			monthName, dayText = m[4], m[3]
+		}
+		if isMonthName(monthName) {
+			day, _ := strconv.Atoi(dayText)
+			month := time.Month(monthFromName(monthName))
+			year := today.Year()
+			if m[5] != "" {
+				year, _ = strconv.Atoi(m[5])
+			}
+			date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+			if date.Day() != day {
+				return time.Time{}, fmt.Errorf("invalid day %d for %s", day, month)
+			}
+			return date, nil
+		}
+	}
+	
+	// Fall back to absolute date formats
+	if date, err := ParseDateTitle(strings.TrimSpace(phrase)); err == nil {
+		return date, nil
+	}
+	
+	return time.Time{}, fmt.Errorf("could not understand date: %s", phrase)
+}
+
+// normalizePhrase lowercases and collapses whitespace
+func normalizePhrase(phrase string) string {
+	text := strings.ToLower(strings.TrimSpace(phrase))
+	text = strings.TrimRight(text, ".!")
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// parseCount parses "3" or "three" into a number
+func parseCount(s string) (int, bool) {
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 {
+		return n, true
+	}
+	n, ok := numberWords[s]
+	return n, ok
+}
+
+// addUnits adds n days, weeks, months or years to a date
+func addUnits(date time.Time, unit string, n int) time.Time {
+	switch unit {
+	case "week":
+		return date.AddDate(0, 0, 7*n)
+	case "month":
+		return date.AddDate(0, n, 0)
+	case "year":
+		return date.AddDate(n, 0, 0)
+	default:
+		return date.AddDate(0, 0, n)
+	}
+}
+
+// resolveWeekday finds a weekday relative to today:
+//   "friday"      - the coming friday, today if today is friday
+//   "next friday" - the first friday after today
+//   "last friday" - the most recent friday before today
+//   "this friday" - the friday of the current Monday-based week
+func resolveWeekday(today time.Time, weekday time.Weekday, modifier string) time.Time {
+	diff := int(weekday) - int(today.Weekday())
+	switch modifier {
+	case "next":
+		if diff <= 0 {
+			diff += 7
+		}
+	case "last":
+		if diff >= 0 {
+			diff -= 7
+		}
+	case "this":
+		// Monday-based week: Sunday is day 7
+		fromMonday := func(d time.Weekday) int { return (int(d) + 6) % 7 }
+		diff = fromMonday(weekday) - fromMonday(today.Weekday())
+	default:
+		if diff < 0 {
+			diff += 7
+		}
+	}
+	return today.AddDate(0, 0, diff)
+}
+
+// resolveMonth interprets "march", "march 2026", "this month", "next month"
+// or "last month". A bare month name means its next occurrence, so in
+// October "march" is March of the following year.
+func resolveMonth(today time.Time, text string) (int, time.Month, error) {
+	switch text {
+	case "this month", "the month":
+		return today.Year(), today.Month(), nil
+	case "next month":
+		next := time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		return next.Year(), next.Month(), nil
+	case "last month":
+		prev := time.Date(today.Year(), today.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+		return prev.Year(), prev.Month(), nil
+	}
+	
+	m := monthYearPattern.FindStringSubmatch(text)
+	if m == nil || !isMonthName(m[1]) {
+		return 0, 0, fmt.Errorf("unknown month: %s", text)
+	}
+	month := time.Month(monthFromName(m[1]))
+	if m[2] != "" {
+		year, _ := strconv.Atoi(m[2])
+		return year, month, nil
+	}
+	if month < today.Month() {
+		return today.Year() + 1, month, nil
+	}
+	return today.Year(), month, nil
+}
+
+// nthWeekdayOfMonth returns the nth weekday of a month; n == -1 means the last
+func nthWeekdayOfMonth(year int, month time.Month, weekday time.Weekday, n int) (time.Time, error) {
+	if n == -1 {
+		last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+		diff := int(last.Weekday()) - int(weekday)
+		if diff < 0 {
+			diff += 7
+		}
+		return last.AddDate(0, 0, -diff), nil
+	}
+	
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	diff := int(weekday) - int(first.Weekday())
+	if diff < 0 {
+		diff += 7
+	}
+	date := first.AddDate(0, 0, diff+7*(n-1))
+	if date.Month() != month {
+		return time.Time{}, fmt.Errorf("%s %d has no %d %s", month, year, n, weekday)
+	}
+	return date, nil
+}
+
+// isWeekdayName checks for a full or three-letter weekday name
+func isWeekdayName(name string) bool {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		full := strings.ToLower(d.String())
+		if name == full || name == full[:3] {
+			return true
+		}
+	}
+	return false
+}
+
+// isMonthName checks for a full or three-letter month name
+func isMonthName(name string) bool {
+	for m := time.January; m <= time.December; m++ {
+		full := strings.ToLower(m.String())
+		if name == full || name == full[:3] || (m == time.September && name == "sept") {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatScheduled formats a date as a Logseq SCHEDULED line
+// e.g. SCHEDULED: <2025-01-17 Fri>
+func FormatScheduled(date time.Time) string {
+	return fmt.Sprintf("SCHEDULED: <%s %s>", FormatDateISO(date), date.Format("Mon"))
+}
+
+// FormatDeadline formats a date as a Logseq DEADLINE line
+func FormatDeadline(date time.Time) string {
+	return fmt.Sprintf("DEADLINE: <%s %s>", FormatDateISO(date), date.Format("Mon"))
+}
\ No newline at end of file
@@ -0,0 +1,124 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNaturalDateParser(t *testing.T) {
+	// Wednesday, Jan 15th 2025, mid-morning local time
+	clock := func() time.Time {
+		return time.Date(2025, 1, 15, 10, 30, 0, 0, time.Local)
+	}
+	p := NewNaturalDateParser(clock)
+
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		phrase   string
+		expected time.Time
+	}{
+		{"today", date(2025, 1, 15)},
+		{"Tomorrow", date(2025, 1, 16)},
+		{"yesterday", date(2025, 1, 14)},
+		{"day after tomorrow", date(2025, 1, 17)},
+		{"in 3 days", date(2025, 1, 18)},
+		{"in two weeks", date(2025, 1, 29)},
+		{"in 1 month", date(2025, 2, 15)},
+		{"3 days ago", date(2025, 1, 12)},
+		{"a week ago", date(2025, 1, 8)},
+		{"next week", date(2025, 1, 22)},
+		{"last month", date(2024, 12, 15)},
+		{"friday", date(2025, 1, 17)},
+		{"wednesday", date(2025, 1, 15)},
+		{"next friday", date(2025, 1, 17)},
+		{"next wednesday", date(2025, 1, 22)},
+		{"last monday", date(2025, 1, 13)},
+		{"last wednesday", date(2025, 1, 8)},
+		{"this sunday", date(2025, 1, 19)},
+		{"this monday", date(2025, 1, 13)},
+		{"next fri", date(2025, 1, 17)},
+		{"last monday of march", date(2025, 3, 31)},
+		{"first friday of next month", date(2025, 2, 7)},
+		{"second tuesday of june 2026", date(2026, 6, 9)},
+		{"last friday of this month", date(2025, 1, 31)},
+		{"march 15", date(2025, 3, 15)},
+		{"15th of march 2026", date(2026, 3, 15)},
+		{"  Next   Week. ", date(2025, 1, 22)},
+		{"Jan 20th, 2025", date(2025, 1, 20)},
+		{"2025-02-01", date(2025, 2, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase, func(t *testing.T) {
+			got, err := p.Parse(tt.phrase)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.phrase, err)
+			}
+			if !got.Equal(tt.expected) {
+				t.Errorf("Parse(%q) = %s, want %s", tt.phrase, FormatDateISO(got), FormatDateISO(tt.expected))
+			}
+		})
+	}
+}
+
+func TestNaturalDateParserErrors(t *testing.T) {
+	p := NewNaturalDateParser(func() time.Time {
+		return time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	})
+
+	for _, phrase := range []string{"", "a", "someday", "fifth monday of february", "in many days", "february 30"} {
+		if got, err := p.Parse(phrase); err == nil {
+			t.Errorf("Parse(%q) = %s, expected error", phrase, FormatDateISO(got))
+		}
+	}
+}
+
+func TestMonthNameDefaultsToNextOccurrence(t *testing.T) {
+	// In October, "march" refers to the coming March
+	p := NewNaturalDateParser(func() time.Time {
+		return time.Date(2025, 10, 18, 0, 0, 0, 0, time.UTC)
+	})
+
+	got, err := p.Parse("last monday of march")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if want := time.Date(2026, 3, 30, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Parse() = %s, want %s", FormatDateISO(got), FormatDateISO(want))
+	}
+}
+
+func TestFormatScheduled(t *testing.T) {
+	date := time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)
+	if got := FormatScheduled(date); got != "SCHEDULED: <2025-01-17 Fri>" {
+		t.Errorf("FormatScheduled() = %q", got)
+	}
+	if got := FormatDeadline(date); got != "DEADLINE: <2025-01-17 Fri>" {
+		t.Errorf("FormatDeadline() = %q", got)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,203 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// writePages writes files relative to dir
+func writePages(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// pageTitles lists the titles of the parsed pages
+func pageTitles(result *MultiPageResult) []string {
+	titles := []string{}
+	for _, page := range result.Pages {
+		titles = append(titles, page.Title)
+	}
+	sort.Strings(titles)
+	return titles
+}
+
+func TestPageIdentity(t *testing.T) {
+	dir := t.TempDir()
+	writePages(t, dir, map[string]string{
+		"no-header.md":        "- Just blocks",
+		"another.md":          "- More blocks",
+		"page-a.md":           "# Page A\n\n- Header spells the filename",
+		"cafe.md":             "# Café\n\n- Accents and case are ignored when matching",
+		"notes.md":            "# Meeting minutes\n\n- A header that isn't the page name",
+		"renamed.md":          "title:: The Real Name\n\n- Named by property",
+		"headed.md":           "# Headed\ntitle:: Property Wins\n\n- Property beats header",
+		"Projects___seq2b.md": "- Namespaced",
+		"a%2Fb.md":            "- Percent-encoded",
+		"sub/folder/deep.md":  "- Nested",
+		"2025_01_15.md":       "# Some header\n\n- Journal file",
+	})
+
+	result, err := ParseDirectory(dir)
+	if err != nil {
+		t.Fatalf("ParseDirectory() error = %v", err)
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("unexpected errors %v", result.Errors)
+	}
+
+	want := []string{"Café", "Jan 15th, 2025", "Page A", "Projects/seq2b", "Property Wins", "The Real Name", "a/b", "another", "deep", "no-header", "notes"}
+	sort.Strings(want)
+	if got := pageTitles(result); !reflect.DeepEqual(got, want) {
+		t.Errorf("titles = %v, want %v", got, want)
+	}
+	if result.GetPage("Headed") != nil {
+		t.Error("the header shouldn't name a page with a title:: property")
+	}
+
+	page := result.GetPage("deep")
+	if want := filepath.Join(dir, "sub", "folder", "deep.md"); page == nil || page.Path != want {
+		t.Errorf("deep page = %+v, want Path %s", page, want)
+	}
+}
+
+func TestPageIdentityWithCache(t *testing.T) {
+	dir := t.TempDir()
+	writePages(t, dir, map[string]string{
+		"no-header.md": "- Just blocks",
+		"page-a.md":    "# Page A\n\n- Header spells the filename",
+		"renamed.md":   "title:: The Real Name\n\n- Named by property",
+	})
+
+	plain, err := ParseDirectory(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for run := 0; run < 2; run++ {
+		cached, err := ParseDirectoryWithCache(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := pageTitles(cached), pageTitles(plain); !reflect.DeepEqual(got, want) {
+			t.Errorf("run %d: cached titles = %v, want %v", run, got, want)
+		}
+		if page := cached.GetPage("no-header"); page == nil || page.Path != filepath.Join(dir, "no-header.md") {
+			t.Errorf("run %d: cached page = %+v", run, page)
+		}
+	}
+}
+
+func TestDuplicatePagesAreReported(t *testing.T) {
+	dir := t.TempDir()
+	writePages(t, dir, map[string]string{
+		"a.md":         "title:: Same\n\n- First",
+		"b.md":         "title:: same\n\n- Second",
+		"sub/other.md": "- Elsewhere",
+		"other.md":     "- Top level",
+	})
+
+	result, err := ParseDirectory(dir)
+	if err != nil {
+		t.Fatalf("ParseDirectory() error = %v", err)
+	}
+	if len(result.Pages) != 2 {
+		t.Errorf("got pages %v, want Same and other", pageTitles(result))
+	}
+	if page := result.GetPage("Same"); page == nil || page.Path != filepath.Join(dir, "a.md") {
+		t.Errorf("expected the first file to keep the page, got %+v", page)
+	}
+
+	var paths []string
+	for _, err := range result.Errors {
+		var duplicate *DuplicatePageError
+		if !errors.As(err, &duplicate) {
+			t.Errorf("unexpected error %v", err)
+			continue
+		}
+		paths = append(paths, duplicate.Path)
+	}
+	want := []string{filepath.Join(dir, "b.md"), filepath.Join(dir, "sub", "other.md")}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("duplicates reported for %v, want %v", paths, want)
+	}
+	if _, ok := result.Files[filepath.Join(dir, "b.md")]; ok {
+		t.Error("the skipped file shouldn't be listed in Files")
+	}
+}
+
+func TestParseFileTitleProperty(t *testing.T) {
+	result, err := ParseFile("# Header\ntitle:: From Property\n\n- Block")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Page.Title != "From Property" || result.Page.Path != "" {
+		t.Errorf("got title %q, path %q", result.Page.Title, result.Page.Path)
+	}
+}
+
+func TestPageHeader(t *testing.T) {
+	tests := map[string]string{
+		"# Header\ntitle:: From Property\n\n- Block": "# Header",
+		"tags:: a\n\n## Second Level\n\n- Block":     "## Second Level",
+		"tags:: a\n\n- Block":                        "",
+		"- Block\n# Header after the first block":    "",
+		"Loose text\n# Header after it\n- Block":     "",
+	}
+	for content, want := range tests {
+		result, err := ParseFile(content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Page.Header != want {
+			t.Errorf("Header of %q = %q, want %q", content, result.Page.Header, want)
+		}
+	}
+}
+
+func TestDecodeFileName(t *testing.T) {
+	tests := map[string]string{
+		"page.md":             "page",
+		"Projects___seq2b.md": "Projects/seq2b",
+		"a%2Fb.md":            "a/b",
+		"100%.md":             "100%",
+		"what%3F.md":          "what?",
+	}
+	for filename, want := range tests {
+		if got := DecodeFileName(filename); got != want {
+			t.Errorf("DecodeFileName(%q) = %q, want %q", filename, got, want)
+		}
+	}
+}
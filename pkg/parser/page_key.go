@@ -0,0 +1,57 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"strings"
+	
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// PageKey returns the canonical key identifying the page a name refers to.
+// Every spelling of a date ([[Jan 15th, 2025]], [[2025-01-15]],
+// [[January 15, 2025]]) maps to the ISO date, so links to a day all land on
+// one journal page. Other names are normalised with NormalizePageName.
+func PageKey(name string) string {
+	return DefaultJournalFormat.PageKey(name)
+}
+
+// PageKey is like the package-level PageKey but also recognises dates
+// written in the vault's journal title format
+func (f JournalFormat) PageKey(name string) string {
+	name = strings.TrimSpace(name)
+	if date, err := f.ParseTitle(name); err == nil {
+		return FormatDateISO(date)
+	}
+	return NormalizePageName(name)
+}
+
+// NormalizePageName folds case, collapses runs of whitespace to a single
+// space and converts to Unicode NFC, so [[Project]], [[project]] and
+// [[ Project ]] (or a decomposed "é") name the same page
+func NormalizePageName(name string) string {
+	name = strings.Join(strings.Fields(name), " ")
+	name = cases.Fold().String(name)
+	return norm.NFC.String(name)
+}
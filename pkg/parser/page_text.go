@@ -0,0 +1,118 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"strings"
+)
+
+// Page text is edited in place for changes that touch files the user may
+// not have open, such as renames, so that loose text, indentation and
+// anything else the parser doesn't keep are written back as they were.
+// Text has \n line endings, as DecodeText returns it.
+
+// pageHead returns the number of lines at the start of page text that hold
+// its header and page properties, as extractPageLevelProperties reads
+// them, with the index of the header and of the last property line, or -1
+// if there is none
+func pageHead(lines []string) (end int, header int, lastProperty int) {
+	end, header, lastProperty = 0, -1, -1
+	for i, raw := range lines {
+		line := ParseLine(i+1, raw)
+		switch {
+		case line.Type == TypeEmpty:
+			continue
+		case line.Type == TypeHeader && header < 0:
+			header = i
+		case line.Type == TypeText && len(line.Properties) > 0:
+			lastProperty = i
+		default:
+			return end, header, lastProperty
+		}
+		end = i + 1
+	}
+	return end, header, lastProperty
+}
+
+// SetPageProperty sets a page property in page text. The property's line
+// is rewritten in place, or a line is added after the page's other
+// properties or its header. An empty value removes the line.
+func SetPageProperty(text string, key string, value string) string {
+	lines := strings.Split(text, "\n")
+	end, header, lastProperty := pageHead(lines)
+	for i := 0; i < end; i++ {
+		matches := propertyPattern.FindStringSubmatch(strings.TrimSpace(lines[i]))
+		if matches == nil || matches[1] != key {
+			continue
+		}
+		if value == "" {
+			return strings.Join(append(lines[:i:i], lines[i+1:]...), "\n")
+		}
+		indent := lines[i][:len(lines[i])-len(strings.TrimLeft(lines[i], " \t"))]
+		lines[i] = indent + key + ":: " + value
+		return strings.Join(lines, "\n")
+	}
+	if value == "" {
+		return text
+	}
+	
+	at := lastProperty + 1
+	added := []string{key + ":: " + value}
+	if lastProperty < 0 {
+		at = header + 1
+		if at < len(lines) && strings.TrimSpace(lines[at]) != "" {
+			// Keep the page's first property apart from what follows
+			added = append(added, "")
+		}
+	}
+	lines = append(lines[:at], append(added, lines[at:]...)...)
+	return strings.Join(lines, "\n")
+}
+
+// RenameHeader rewrites the first header in page text, which parseContent
+// takes as the title, if it names the page being renamed. Its level is
+// kept. isOld is as for RenameReferences.
+func RenameHeader(text string, isOld func(name string) bool, newName string) string {
+	lines := strings.Split(text, "\n")
+	for i, raw := range lines {
+		line := ParseLine(i+1, raw)
+		if line.Type != TypeHeader {
+			continue
+		}
+		if isOld(line.Content) {
+			lines[i] = raw[:strings.Index(raw, "#")+line.HeaderLevel] + " " + newName
+		}
+		break
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SplitPageHead splits page text into its head, the header and page
+// properties, and the rest. The blank lines between them belong to
+// neither.
+func SplitPageHead(text string) (head string, body string) {
+	lines := strings.Split(text, "\n")
+	end, _, _ := pageHead(lines)
+	body = strings.TrimLeft(strings.Join(lines[end:], "\n"), "\n")
+	return strings.Join(lines[:end], "\n"), body
+}
\ No newline at end of file
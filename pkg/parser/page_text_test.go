@@ -0,0 +1,117 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetPageProperty(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		key   string
+		value string
+		want  string
+	}{
+		{"replaced in place", "# A\nalias:: x\ntags:: t\n\n- b\n", "alias", "x, y", "# A\nalias:: x, y\ntags:: t\n\n- b\n"},
+		{"added after properties", "# A\ntags:: t\n\n- b\n", "alias", "x", "# A\ntags:: t\nalias:: x\n\n- b\n"},
+		{"added after header", "# A\n- b\n", "alias", "x", "# A\nalias:: x\n\n- b\n"},
+		{"added after header and blank line", "# A\n\n- b\n", "alias", "x", "# A\nalias:: x\n\n- b\n"},
+		{"added without header", "- b\n", "alias", "x", "alias:: x\n\n- b\n"},
+		{"added to empty page", "", "alias", "x", "alias:: x\n"},
+		{"removed", "# A\nalias:: x\ntags:: t\n\n- b\n", "alias", "", "# A\ntags:: t\n\n- b\n"},
+		{"removing a missing property", "# A\n- b\n", "alias", "", "# A\n- b\n"},
+		{"block properties untouched", "# A\n\n- b\n  alias:: x\n", "alias", "y", "# A\nalias:: y\n\n- b\n  alias:: x\n"},
+		{"loose text untouched", "# A\nIntro alias:: x\n- b\n", "alias", "y", "# A\nalias:: y\n\nIntro alias:: x\n- b\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SetPageProperty(tt.text, tt.key, tt.value); got != tt.want {
+				t.Errorf("SetPageProperty() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetPagePropertyParses(t *testing.T) {
+	text := SetPageProperty("# Plans\n- TODO Ship it\n", "alias", "roadmap")
+	result, err := ParseFile(text)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	if aliases := result.Page.Aliases(); len(aliases) != 1 || aliases[0] != "roadmap" {
+		t.Errorf("Aliases() = %v, want [roadmap]", aliases)
+	}
+	if len(result.Page.Blocks) != 1 {
+		t.Errorf("got %d blocks, want 1", len(result.Page.Blocks))
+	}
+}
+
+func TestRenameHeader(t *testing.T) {
+	isOld := func(name string) bool { return strings.EqualFold(name, "project") }
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"renamed", "# Project\n- b\n", "# Venture\n- b\n"},
+		{"level kept", "## project\n", "## Venture\n"},
+		{"after loose text", "Intro\n# Project\n", "Intro\n# Venture\n"},
+		{"other header", "# Notes\n# Project\n", "# Notes\n# Project\n"},
+		{"no header", "- [[Project]]\n", "- [[Project]]\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenameHeader(tt.text, isOld, "Venture"); got != tt.want {
+				t.Errorf("RenameHeader() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitPageHead(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		head string
+		body string
+	}{
+		{"header and properties", "# A\ntags:: t\n\nIntro\n- b\n", "# A\ntags:: t", "Intro\n- b\n"},
+		{"properties first", "title:: A\n\n- b\n", "title:: A", "- b\n"},
+		{"no head", "- b\n  - c\n", "", "- b\n  - c\n"},
+		{"head only", "# A\n", "# A", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			head, body := SplitPageHead(tt.text)
+			if head != tt.head || body != tt.body {
+				t.Errorf("SplitPageHead() = %q, %q; want %q, %q", head, body, tt.head, tt.body)
+			}
+		})
+	}
+}
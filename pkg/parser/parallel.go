@@ -0,0 +1,104 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// ProgressFunc reports how many of the files being parsed are done
+type ProgressFunc func(done, total int)
+
+// parsedFile is the outcome of parsing one file
+type parsedFile struct {
+	page     *Page
+	err      error // Reading or parsing failed, page is nil
+	cached   bool  // Page came from the cache
+	cacheErr error // Page parsed but couldn't be cached
+}
+
+// parseConcurrently runs parse on every file with a bounded pool of
+// workers. Results come back in the order of files, whatever order they
+// finished in, so merging them is deterministic. Progress is reported from
+// the calling goroutine. If ctx is cancelled the files already handed to
+// workers are finished and ctx.Err() is returned.
+func parseConcurrently(ctx context.Context, files []string, opts ParseOptions, parse func(filePath string) parsedFile) ([]parsedFile, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(files) {
+		workers = len(files)
+	}
+	
+	results := make([]parsedFile, len(files))
+	jobs := make(chan int)
+	finished := make(chan int, len(files)) // Buffered so workers never wait on a cancelled caller
+	
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = parse(files[i])
+				finished <- i
+			}
+		}()
+	}
+	
+	go func() {
+		defer close(jobs)
+		for i := range files {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	
+	for done := 1; done <= len(files); done++ {
+		select {
+		case <-finished:
+			if opts.Progress != nil {
+				opts.Progress(done, len(files))
+			}
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, ctx.Err()
+		}
+	}
+	wg.Wait()
+	
+	return results, nil
+}
\ No newline at end of file
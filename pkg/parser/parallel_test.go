@@ -0,0 +1,167 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// writeVault writes n linked pages to a temporary directory
+func writeVault(tb testing.TB, n int) string {
+	tb.Helper()
+	dir := tb.TempDir()
+	for i := 0; i < n; i++ {
+		content := fmt.Sprintf("# Page %d\n\n- Links [[Page %d]] and [[Page %d]]\n  - Child of %d #tag%d\n- DONE Task on [[Page %d]]\n",
+			i, (i+1)%n, (i*7)%n, i, i%5, (i*3)%n)
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("page-%d.md", i)), []byte(content), 0644); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestParseDirectoryWorkersGiveSameResult(t *testing.T) {
+	dir := writeVault(t, 300)
+
+	// Unreadable files are reported in file order too
+	for _, name := range []string{"broken-a.md", "broken-b.md"} {
+		if err := os.Symlink(filepath.Join(dir, "missing"), filepath.Join(dir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	parse := func(workers int) *MultiPageResult {
+		opts := DefaultParseOptions()
+		opts.Workers = workers
+		result, err := ParseDirectoryWithOptions(dir, opts)
+		if err != nil {
+			t.Fatalf("ParseDirectoryWithOptions(workers=%d) error = %v", workers, err)
+		}
+		return result
+	}
+
+	sequential := parse(1)
+	for _, workers := range []int{2, 8, 0} {
+		parallel := parse(workers)
+		if len(parallel.Pages) != 300 {
+			t.Fatalf("workers=%d: got %d pages, want 300", workers, len(parallel.Pages))
+		}
+		if !reflect.DeepEqual(parallel.Files, sequential.Files) {
+			t.Errorf("workers=%d: files differ", workers)
+		}
+		if !reflect.DeepEqual(parallel.Backlinks.BackwardLinks, sequential.Backlinks.BackwardLinks) {
+			t.Errorf("workers=%d: backlinks differ", workers)
+		}
+		if !reflect.DeepEqual(parallel.Backlinks.ForwardLinks, sequential.Backlinks.ForwardLinks) {
+			t.Errorf("workers=%d: forward links differ", workers)
+		}
+		if fmt.Sprint(parallel.Errors) != fmt.Sprint(sequential.Errors) || len(parallel.Errors) != 2 {
+			t.Errorf("workers=%d: errors %v, want %v", workers, parallel.Errors, sequential.Errors)
+		}
+		for key, page := range sequential.Pages {
+			if other := parallel.Pages[key]; other == nil || len(other.AllBlocks) != len(page.AllBlocks) {
+				t.Errorf("workers=%d: page %q differs", workers, key)
+			}
+		}
+	}
+}
+
+func TestParseFilesContextProgress(t *testing.T) {
+	dir := writeVault(t, 50)
+	files, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var calls []int
+	opts := DefaultParseOptions()
+	opts.Workers = 4
+	opts.Progress = func(done, total int) {
+		if total != len(files) {
+			t.Errorf("progress total = %d, want %d", total, len(files))
+		}
+		calls = append(calls, done)
+	}
+
+	result, err := ParseFilesContext(context.Background(), files, opts)
+	if err != nil {
+		t.Fatalf("ParseFilesContext() error = %v", err)
+	}
+	if len(result.Pages) != len(files) {
+		t.Errorf("got %d pages, want %d", len(result.Pages), len(files))
+	}
+	if len(calls) != len(files) || !sort.IntsAreSorted(calls) || calls[len(calls)-1] != len(files) {
+		t.Errorf("progress calls = %v", calls)
+	}
+}
+
+func TestParseDirectoryContextCancelled(t *testing.T) {
+	dir := writeVault(t, 200)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ParseDirectoryContext(ctx, dir, DefaultParseOptions()); !errors.Is(err, context.Canceled) {
+		t.Errorf("already cancelled: error = %v, want context.Canceled", err)
+	}
+
+	// Cancelling part way through stops the parse
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	opts := DefaultParseOptions()
+	opts.Workers = 2
+	opts.Progress = func(done, total int) {
+		if done == 10 {
+			cancel()
+		}
+	}
+	result, err := ParseDirectoryContext(ctx, dir, opts)
+	if !errors.Is(err, context.Canceled) || result != nil {
+		t.Errorf("cancelled during parse: got result %v, error %v", result != nil, err)
+	}
+}
+
+func BenchmarkParseDirectory(b *testing.B) {
+	dir := writeVault(b, 2000)
+	for _, workers := range []int{1, 0} {
+		name := fmt.Sprintf("workers=%d", workers)
+		if workers == 0 {
+			name = "workers=cpus"
+		}
+		b.Run(name, func(b *testing.B) {
+			opts := DefaultParseOptions()
+			opts.Workers = workers
+			for i := 0; i < b.N; i++ {
+				if _, err := ParseDirectoryWithOptions(dir, opts); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// PageRefProperties are the properties whose comma separated values name
+// pages, written either as plain names or as [[links]]
+var PageRefProperties = []string{"alias", "tags"}
+
+// tagNamePattern matches names that can be written as a bare #tag
+var tagNamePattern = regexp.MustCompile(`^[a-zA-Z0-9\-_/]+$`)
+
+// RenameReferences rewrites the references to a page in text: [[Old]]
+// links, which covers #[[Old]] and [label]([[Old]]), #Old tags, and plain
+// entries of page-ref property lines such as "alias:: Old". isOld reports
+// whether a referenced name is the page being renamed, so the caller
+// decides how names are compared. It returns the new text and the number
+// of references rewritten; those already spelled newName are left alone.
+func RenameReferences(text string, isOld func(name string) bool, newName string) (string, int) {
+	count := 0
+	
+	text = pageRefPattern.ReplaceAllStringFunc(text, func(link string) string {
+		if link == "[[" + newName + "]]" || !isOld(link[2 : len(link)-2]) {
+			return link
+		}
+		count++
+		return "[[" + newName + "]]"
+	})
+	
+	newTag := "#" + newName
+	if !tagNamePattern.MatchString(newName) {
+		newTag = "#[[" + newName + "]]"
+	}
+	var rewritten strings.Builder
+	last := 0
+	for _, match := range tagPattern.FindAllStringSubmatchIndex(text, -1) {
+		// match[2:4] is the tag name; the # comes just before it
+		name := text[match[2]:match[3]]
+		if "#" + name == newTag || !isOld(name) {
+			continue
+		}
+		rewritten.WriteString(text[last : match[2]-1])
+		rewritten.WriteString(newTag)
+		last = match[3]
+		count++
+	}
+	rewritten.WriteString(text[last:])
+	text = rewritten.String()
+	
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		matches := propertyPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil || !containsString(PageRefProperties, matches[1]) {
+			continue
+		}
+		value, n := RenamePropertyValue(matches[2], isOld, newName)
+		if n > 0 {
+			indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+			lines[i] = indent + matches[1] + ":: " + value
+			count += n
+		}
+	}
+	return strings.Join(lines, "\n"), count
+}
+
+// RenamePropertyValue rewrites the plain entries of a comma separated
+// page-ref property value that name the old page. [[Links]] are left to
+// RenameReferences.
+func RenamePropertyValue(value string, isOld func(name string) bool, newName string) (string, int) {
+	count := 0
+	entries := strings.Split(value, ",")
+	for i, entry := range entries {
+		name := strings.TrimSpace(entry)
+		if name == "" || name == newName || strings.HasPrefix(name, "[[") || !isOld(name) {
+			continue
+		}
+		entries[i] = strings.Replace(entry, name, newName, 1)
+		count++
+	}
+	return strings.Join(entries, ","), count
+}
+
+// RenamePageReferences rewrites the references to a page throughout
+// another page's blocks and page properties, as RenameReferences
+// describes, and returns how many were rewritten. AllBlocks is refreshed.
+func RenamePageReferences(page *Page, isOld func(name string) bool, newName string) int {
+	count := 0
+	for key, value := range page.Properties {
+		value, n := RenameReferences(value, isOld, newName)
+		if containsString(PageRefProperties, key) {
+			var plain int
+			value, plain = RenamePropertyValue(value, isOld, newName)
+			n += plain
+		}
+		if n > 0 {
+			page.Properties[key] = value
+			count += n
+		}
+	}
+	
+	for _, block := range page.GetAllBlocks() {
+		content, n := RenameReferences(block.Content, isOld, newName)
+		if n > 0 {
+			block.SetContent(content)
+			count += n
+		}
+	}
+	page.AllBlocks = page.GetAllBlocks()
+	return count
+}
+
+// AddAlias adds a name to a page's alias:: property unless it's already
+// there
+func AddAlias(page *Page, alias string, keyFunc func(string) string) {
+	for _, existing := range page.Aliases() {
+		if keyFunc(existing) == keyFunc(alias) {
+			return
+		}
+	}
+	if page.Properties == nil {
+		page.Properties = make(map[string]string)
+	}
+	if value := strings.TrimSpace(page.Properties["alias"]); value != "" {
+		page.Properties["alias"] = value + ", " + alias
+	} else {
+		page.Properties["alias"] = alias
+	}
+}
+
+// RemoveAlias drops a name from a page's alias:: property, e.g. when the
+// page is renamed to one of its aliases
+func RemoveAlias(page *Page, alias string, keyFunc func(string) string) {
+	var kept []string
+	for _, existing := range page.Aliases() {
+		if keyFunc(existing) != keyFunc(alias) {
+			kept = append(kept, existing)
+		}
+	}
+	if len(kept) == len(page.Aliases()) {
+		return
+	}
+	if len(kept) == 0 {
+		delete(page.Properties, "alias")
+		return
+	}
+	page.Properties["alias"] = strings.Join(kept, ", ")
+}
+
+// TagsReference reports whether a page names another in a #tag or a
+// page-ref property. [[Links]] are found with the backlink index.
+func TagsReference(page *Page, isOld func(name string) bool) bool {
+	properties := []map[string]string{page.Properties}
+	for _, block := range page.GetAllBlocks() {
+		for _, tag := range block.Tags {
+			if isOld(tag) {
+				return true
+			}
+		}
+		properties = append(properties, block.Properties)
+	}
+	
+	for _, props := range properties {
+		for _, key := range PageRefProperties {
+			for _, entry := range strings.Split(props[key], ",") {
+				if name := strings.TrimSpace(entry); name != "" && isOld(name) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
\ No newline at end of file
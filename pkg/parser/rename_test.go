@@ -0,0 +1,109 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+// isProject matches any spelling of the page "Project"
+func isProject(name string) bool {
+	return PageKey(name) == PageKey("Project")
+}
+
+func TestRenameReferences(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		newName string
+		want    string
+		count   int
+	}{
+		{"link", "See [[Project]] and [[project]]", "Plan", "See [[Plan]] and [[Plan]]", 2},
+		{"labelled link", "[the plan]([[Project]])", "Plan", "[the plan]([[Plan]])", 1},
+		{"bracketed tag", "Tagged #[[Project]]", "Big Plan", "Tagged #[[Big Plan]]", 1},
+		{"tag", "#project at start, then #Project", "plan", "#plan at start, then #plan", 2},
+		{"tag needing brackets", "Tagged #project", "Big Plan", "Tagged #[[Big Plan]]", 1},
+		{"longer tag", "Tagged #projects and #project/x", "Plan", "Tagged #projects and #project/x", 0},
+		{"other pages", "[[Projects]] [[Other]] #other", "Plan", "[[Projects]] [[Other]] #other", 0},
+		{"property line", "tags:: Project, other\nalias:: [[project]]", "Plan", "tags:: Plan, other\nalias:: [[Plan]]", 2},
+		{"other property", "status:: Project", "Plan", "status:: Project", 0},
+		{"already renamed", "[[Plan]] #plan", "Plan", "[[Plan]] #plan", 0},
+		{"case only", "[[project]] [[Project]]", "Project", "[[Project]] [[Project]]", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, count := RenameReferences(tt.text, isProject, tt.newName)
+			if got != tt.want || count != tt.count {
+				t.Errorf("RenameReferences(%q) = %q, %d; want %q, %d", tt.text, got, count, tt.want, tt.count)
+			}
+		})
+	}
+}
+
+func TestRenamePageReferences(t *testing.T) {
+	result, err := ParseFile("# Notes\ntags:: Project, Other\nrelated:: [[Project]]\n\n- See [[Project]]\n  - Child #project\n- Unrelated")
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := result.Page
+
+	if !TagsReference(page, isProject) {
+		t.Error("TagsReference should find the tag and property")
+	}
+	if count := RenamePageReferences(page, isProject, "Plan"); count != 4 {
+		t.Errorf("RenamePageReferences count = %d, want 4", count)
+	}
+	if page.Properties["tags"] != "Plan, Other" || page.Properties["related"] != "[[Plan]]" {
+		t.Errorf("Properties = %v", page.Properties)
+	}
+	if page.Blocks[0].Content != "See [[Plan]]" || page.Blocks[0].Children[0].Content != "Child #Plan" {
+		t.Errorf("Blocks = %q, %q", page.Blocks[0].Content, page.Blocks[0].Children[0].Content)
+	}
+	if !reflect.DeepEqual(page.Blocks[0].References, []string{"Plan"}) {
+		t.Errorf("References not reparsed: %v", page.Blocks[0].References)
+	}
+	if TagsReference(page, isProject) {
+		t.Error("TagsReference after rename should be false")
+	}
+}
+
+func TestAddRemoveAlias(t *testing.T) {
+	page := &Page{Title: "Plan"}
+	AddAlias(page, "Project", PageKey)
+	AddAlias(page, "project", PageKey)
+	AddAlias(page, "Scheme", PageKey)
+	if got := page.Properties["alias"]; got != "Project, Scheme" {
+		t.Errorf("alias = %q", got)
+	}
+
+	RemoveAlias(page, "PROJECT", PageKey)
+	if got := page.Properties["alias"]; got != "Scheme" {
+		t.Errorf("alias after remove = %q", got)
+	}
+	RemoveAlias(page, "Scheme", PageKey)
+	if _, ok := page.Properties["alias"]; ok {
+		t.Error("empty alias property should be removed")
+	}
+}
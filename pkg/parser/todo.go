@@ -23,6 +23,7 @@
 package parser
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 )
@@ -60,34 +61,83 @@ type TodoInfo struct {
 	Priority      string // A, B, C, etc. from TODO [#A]
 }
 
-var (
-	// Regex to match TODO states at the beginning of a block
-	// Matches: TODO, DOING, DONE, WAITING, WAIT, CANCELED, CANCELLED, LATER, NOW
-	todoStateRegex = regexp.MustCompile(`^(TODO|DOING|DONE|WAITING|WAIT|CANCELED|CANCELLED|LATER|NOW)\s+`)
-	
-	// Regex to match TODO with priority
-	// Matches: TODO [#A], DONE [#B], etc.
-	todoPriorityRegex = regexp.MustCompile(`^(TODO|DOING|DONE|WAITING|WAIT|CANCELED|CANCELLED|LATER|NOW)\s+\[#([A-Z])\]\s+`)
-	
-	// Regex to match checkboxes
-	// Matches: [ ], [x], [X], [-]
-	checkboxRegex = regexp.MustCompile(`^\[([ xX\-])\]\s+`)
-)
+// TodoKeywords are the words that mark a block as a task when they start
+// it, such as TODO and DONE
+type TodoKeywords struct {
+	words    []string
+	state    *regexp.Regexp // Matches a keyword at the start of a block
+	priority *regexp.Regexp // Matches a keyword followed by a priority
+}
 
-// ParseTodoInfo extracts TODO information from block content
+// DefaultTodoKeywords are the keywords Logseq recognises
+var DefaultTodoKeywords = mustTodoKeywords("TODO", "DOING", "DONE", "WAITING", "WAIT", "CANCELED", "CANCELLED", "LATER", "NOW")
+
+// todoKeywordPattern is what a keyword may look like: upper case, so it
+// isn't mistaken for the first word of a sentence
+var todoKeywordPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_-]*$`)
+
+// Regex to match checkboxes
+// Matches: [ ], [x], [X], [-]
+var checkboxRegex = regexp.MustCompile(`^\[([ xX\-])\]\s+`)
+
+// NewTodoKeywords returns a set of keywords, checking that there is at
+// least one and that each is an upper case word listed once
+func NewTodoKeywords(words ...string) (*TodoKeywords, error) {
+	if len(words) == 0 {
+		return nil, fmt.Errorf("no TODO keywords")
+	}
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		if !todoKeywordPattern.MatchString(word) {
+			return nil, fmt.Errorf("invalid TODO keyword %q: must be an upper case word such as TODO", word)
+		}
+		if containsString(words[:i], word) {
+			return nil, fmt.Errorf("TODO keyword %q is listed twice", word)
+		}
+		quoted[i] = regexp.QuoteMeta(word)
+	}
+	alternatives := strings.Join(quoted, "|")
+	return &TodoKeywords{
+		words:    append([]string(nil), words...),
+		state:    regexp.MustCompile(`^(` + alternatives + `)\s+`),
+		priority: regexp.MustCompile(`^(` + alternatives + `)\s+\[#([A-Z])\]\s+`),
+	}, nil
+}
+
+// mustTodoKeywords is NewTodoKeywords for keywords known to be valid
+func mustTodoKeywords(words ...string) *TodoKeywords {
+	keywords, err := NewTodoKeywords(words...)
+	if err != nil {
+		panic(err)
+	}
+	return keywords
+}
+
+// Words returns the keywords in the order they were given
+func (k *TodoKeywords) Words() []string {
+	return append([]string(nil), k.words...)
+}
+
+// ParseTodoInfo extracts TODO information from block content using the
+// default keywords
 func ParseTodoInfo(content string) TodoInfo {
+	return DefaultTodoKeywords.ParseTodoInfo(content)
+}
+
+// ParseTodoInfo extracts TODO information from block content
+func (k *TodoKeywords) ParseTodoInfo(content string) TodoInfo {
 	info := TodoInfo{}
 	trimmed := strings.TrimSpace(content)
 	
 	// Check for TODO with priority first
-	if matches := todoPriorityRegex.FindStringSubmatch(trimmed); len(matches) > 0 {
+	if matches := k.priority.FindStringSubmatch(trimmed); len(matches) > 0 {
 		info.TodoState = TodoState(matches[1])
 		info.Priority = matches[2]
 		return info
 	}
 	
 	// Check for TODO state without priority
-	if matches := todoStateRegex.FindStringSubmatch(trimmed); len(matches) > 0 {
+	if matches := k.state.FindStringSubmatch(trimmed); len(matches) > 0 {
 		info.TodoState = TodoState(matches[1])
 		return info
 	}
@@ -108,15 +158,21 @@ func ParseTodoInfo(content string) TodoInfo {
 }
 
 // RemoveTodoPrefix removes TODO state and checkbox prefixes from content
+// using the default keywords
 func RemoveTodoPrefix(content string) string {
+	return DefaultTodoKeywords.RemoveTodoPrefix(content)
+}
+
+// RemoveTodoPrefix removes TODO state and checkbox prefixes from content
+func (k *TodoKeywords) RemoveTodoPrefix(content string) string {
 	trimmed := strings.TrimSpace(content)
 	
 	// Remove TODO with priority
-	if todoPriorityRegex.MatchString(trimmed) {
-		trimmed = todoPriorityRegex.ReplaceAllString(trimmed, "")
-	} else if todoStateRegex.MatchString(trimmed) {
+	if k.priority.MatchString(trimmed) {
+		trimmed = k.priority.ReplaceAllString(trimmed, "")
+	} else if k.state.MatchString(trimmed) {
 		// Remove TODO without priority
-		trimmed = todoStateRegex.ReplaceAllString(trimmed, "")
+		trimmed = k.state.ReplaceAllString(trimmed, "")
 	}
 	
 	// Remove checkbox
@@ -127,6 +183,20 @@ func RemoveTodoPrefix(content string) string {
 	return trimmed
 }
 
+// Apply reparses the TODO state of blocks and their children with these
+// keywords. Blocks are parsed with the default keywords, so pages from a
+// vault with its own keywords are passed through this.
+func (k *TodoKeywords) Apply(blocks []*Block) {
+	for _, b := range blocks {
+		b.keywords = k
+		if len(b.Lines) > 0 && b.Lines[0].Type == TypeBlock {
+			b.Lines[0].TodoInfo = k.ParseTodoInfo(b.Lines[0].Content)
+		}
+		b.updateContent()
+		k.Apply(b.Children)
+	}
+}
+
 // GetTodoBlocks returns all blocks with TODO states or checkboxes
 func GetTodoBlocks(blocks []*Block) []*Block {
 	var todoBlocks []*Block
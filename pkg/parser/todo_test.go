@@ -23,6 +23,7 @@
 package parser
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -232,4 +233,80 @@ func TestNestedTodoBlocks(t *testing.T) {
 			}
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestNewTodoKeywords(t *testing.T) {
+	keywords, err := NewTodoKeywords("TODO", "BLOCKED", "DONE")
+	if err != nil {
+		t.Fatalf("NewTodoKeywords failed: %v", err)
+	}
+	if info := keywords.ParseTodoInfo("BLOCKED [#A] waiting on review"); info.TodoState != "BLOCKED" || info.Priority != "A" {
+		t.Errorf("Custom keyword not recognised: %+v", info)
+	}
+	if info := keywords.ParseTodoInfo("LATER not a keyword here"); info.TodoState != TodoStateNone {
+		t.Errorf("Keyword outside the set recognised: %+v", info)
+	}
+	if got := keywords.RemoveTodoPrefix("BLOCKED waiting on review"); got != "waiting on review" {
+		t.Errorf("RemoveTodoPrefix = %q", got)
+	}
+
+	for _, words := range [][]string{
+		{},
+		{"todo"},
+		{"TO DO"},
+		{"TODO", "DONE", "TODO"},
+		{"(TODO|.*)"},
+	} {
+		if _, err := NewTodoKeywords(words...); err == nil {
+			t.Errorf("NewTodoKeywords(%q) should fail", words)
+		}
+	}
+}
+
+func TestParseWithTodoKeywords(t *testing.T) {
+	keywords, _ := NewTodoKeywords("TODO", "BLOCKED", "DONE")
+	opts := DefaultParseOptions()
+	opts.TodoKeywords = keywords
+	page, err := ParsePageContent("tasks.md", []byte("- BLOCKED on review\n  - LATER plain text\n"), opts)
+	if err != nil {
+		t.Fatalf("ParsePageContent failed: %v", err)
+	}
+	block := page.Blocks[0]
+	if block.TodoInfo.TodoState != "BLOCKED" {
+		t.Errorf("Expected BLOCKED state, got %q", block.TodoInfo.TodoState)
+	}
+	if len(block.Segments) == 0 || block.Segments[0].Content != "on review" {
+		t.Errorf("Keyword should be left out of the rendered text: %+v", block.Segments)
+	}
+	if child := block.Children[0]; child.TodoInfo.TodoState != TodoStateNone {
+		t.Errorf("LATER isn't a keyword in this vault, got %q", child.TodoInfo.TodoState)
+	}
+
+	// Edits are parsed with the same keywords
+	block.SetContent("BLOCKED [#A] on a second review\nSCHEDULED: <2025-01-17 Fri>")
+	if block.TodoInfo.TodoState != "BLOCKED" || block.TodoInfo.Priority != "A" {
+		t.Errorf("Edited block lost its custom keyword: %+v", block.TodoInfo)
+	}
+	if len(block.Segments) == 0 || !strings.HasPrefix(block.Segments[0].Content, "on a second review") {
+		t.Errorf("Keyword should be left out of the edited text: %+v", block.Segments)
+	}
+	block.SetContent("LATER isn't a task here")
+	if block.TodoInfo.TodoState != TodoStateNone {
+		t.Errorf("Edit recognised a keyword outside the set: %q", block.TodoInfo.TodoState)
+	}
+}
+
+func TestParseWithIndentWidth(t *testing.T) {
+	opts := DefaultParseOptions()
+	opts.IndentWidth = 4
+	page, err := ParsePageContent("outline.md", []byte("- parent\n    - child\n        - grandchild\n    - second child\n"), opts)
+	if err != nil {
+		t.Fatalf("ParsePageContent failed: %v", err)
+	}
+	if len(page.Blocks) != 1 || len(page.Blocks[0].Children) != 2 {
+		t.Fatalf("Expected one parent with two children, got %d top-level blocks", len(page.Blocks))
+	}
+	if grandchild := page.Blocks[0].Children[0].Children; len(grandchild) != 1 || grandchild[0].Content != "grandchild" {
+		t.Errorf("Grandchild not nested: %+v", grandchild)
+	}
+}
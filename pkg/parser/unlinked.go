@@ -0,0 +1,346 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Mention is a plain-text occurrence of a page title or alias
+type Mention struct {
+	Target string // Title of the mentioned page
+	Link   string // Title or alias that matched, as written on the page
+	Text   string // Matched text as written in the block
+	Start  int    // Byte offset of the mention in the block content
+	End    int
+}
+
+// UnlinkedReference is a mention of a page that is not yet a [[link]]
+type UnlinkedReference struct {
+	Mention
+	PageName string // The page containing the mention
+	BlockID  string
+}
+
+// UnlinkedIndex finds unlinked references to every page in a vault
+type UnlinkedIndex struct {
+	// References: target page key -> mentions of it in other pages
+	References map[string][]UnlinkedReference
+	
+	// KeyFunc maps page names to the keys above; PageKey when nil
+	KeyFunc func(string) string
+	
+	matcher *mentionMatcher
+}
+
+// Text that never counts as a mention: links, tags, code, URLs and
+// property lines
+var mentionMaskPattern = regexp.MustCompile(
+	"#?\\[\\[.*?\\]\\]|#[^\\s#\\[]+|`[^`]*`|\\[[^\\]]*\\]\\([^)]*\\)|https?://\\S+|(?m)^[\\w-]+::.*$")
+
+// Shorter titles match too much ordinary text to be useful
+const minMentionLength = 2
+
+// BuildUnlinkedIndex scans every block for plain-text mentions of page
+// titles and aliases. Matching is case-insensitive and respects word
+// boundaries; when mentions overlap the longest one wins.
+func BuildUnlinkedIndex(pages map[string]*Page, keyFunc func(string) string) *UnlinkedIndex {
+	idx := &UnlinkedIndex{
+		References: make(map[string][]UnlinkedReference),
+		KeyFunc:    keyFunc,
+	}
+	
+	titles := make([]string, 0, len(pages))
+	for name := range pages {
+		titles = append(titles, name)
+	}
+	sort.Strings(titles)
+	
+	idx.matcher = newMentionMatcher()
+	for _, title := range titles {
+		page := pages[title]
+		idx.matcher.add(page.Title, page.Title)
+		for _, alias := range page.Aliases() {
+			idx.matcher.add(alias, page.Title)
+		}
+	}
+	idx.matcher.build()
+	
+	// Keys are computed once per page rather than per mention
+	keys := make(map[string]string, len(titles))
+	for _, title := range titles {
+		keys[pages[title].Title] = idx.key(pages[title].Title)
+	}
+	
+	for _, title := range titles {
+		page := pages[title]
+		sourceKey := keys[page.Title]
+		
+		for _, block := range page.AllBlocks {
+			mentions := idx.FindMentions(block.Content)
+			if len(mentions) == 0 {
+				continue
+			}
+			linked := idx.linkedKeys(block)
+			for _, mention := range mentions {
+				targetKey := keys[mention.Target]
+				if targetKey == sourceKey || linked[targetKey] {
+					continue
+				}
+				idx.References[targetKey] = append(idx.References[targetKey], UnlinkedReference{
+					Mention:  mention,
+					PageName: page.Title,
+					BlockID:  block.ID,
+				})
+			}
+		}
+	}
+	
+	return idx
+}
+
+func (idx *UnlinkedIndex) key(pageName string) string {
+	if idx.KeyFunc != nil {
+		return idx.KeyFunc(pageName)
+	}
+	return PageKey(pageName)
+}
+
+// linkedKeys returns the keys of the pages a block already links to
+func (idx *UnlinkedIndex) linkedKeys(block *Block) map[string]bool {
+	linked := make(map[string]bool)
+	for _, link := range ExtractPageLinks(block.Content) {
+		linked[idx.key(link)] = true
+	}
+	return linked
+}
+
+// GetUnlinkedReferences returns the unlinked mentions of a page
+func (idx *UnlinkedIndex) GetUnlinkedReferences(pageName string) []UnlinkedReference {
+	return idx.References[idx.key(pageName)]
+}
+
+// FindMentions returns the page mentions in text, in order of appearance
+func (idx *UnlinkedIndex) FindMentions(text string) []Mention {
+	if idx.matcher == nil || text == "" {
+		return nil
+	}
+	
+	// Runes are matched lower-cased; offsets maps rune index to byte offset
+	runes := make([]rune, 0, len(text))
+	offsets := make([]int, 0, len(text)+1)
+	for offset, r := range text {
+		runes = append(runes, unicode.ToLower(r))
+		offsets = append(offsets, offset)
+	}
+	offsets = append(offsets, len(text))
+	
+	// Masked spans are only worth finding once something has matched
+	var masked [][]int
+	maskFound := false
+	isMasked := func(start, end int) bool {
+		if !maskFound {
+			// Every masked construct contains one of these characters
+			if strings.ContainsAny(text, "[#`:") {
+				masked = mentionMaskPattern.FindAllStringIndex(text, -1)
+			}
+			maskFound = true
+		}
+		for _, span := range masked {
+			if start < span[1] && end > span[0] {
+				return true
+			}
+		}
+		return false
+	}
+	
+	type candidate struct {
+		start, end int // Rune indices
+		pattern    int
+	}
+	var candidates []candidate
+	idx.matcher.search(runes, func(end, pattern int) {
+		start := end - len(idx.matcher.patterns[pattern].runes)
+		if isWordRune(runes, start-1) || isWordRune(runes, end) {
+			return
+		}
+		if isMasked(offsets[start], offsets[end]) {
+			return
+		}
+		candidates = append(candidates, candidate{start, end, pattern})
+	})
+	
+	// Leftmost-longest, non-overlapping
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].start != candidates[j].start {
+			return candidates[i].start < candidates[j].start
+		}
+		if candidates[i].end != candidates[j].end {
+			return candidates[i].end > candidates[j].end
+		}
+		return candidates[i].pattern < candidates[j].pattern
+	})
+	
+	var mentions []Mention
+	lastEnd := -1
+	for _, c := range candidates {
+		if c.start < lastEnd {
+			continue
+		}
+		pattern := idx.matcher.patterns[c.pattern]
+		mentions = append(mentions, Mention{
+			Target: pattern.target,
+			Link:   pattern.text,
+			Text:   text[offsets[c.start]:offsets[c.end]],
+			Start:  offsets[c.start],
+			End:    offsets[c.end],
+		})
+		lastEnd = c.end
+	}
+	return mentions
+}
+
+// isWordRune reports whether the rune at i continues a word
+func isWordRune(runes []rune, i int) bool {
+	if i < 0 || i >= len(runes) {
+		return false
+	}
+	r := runes[i]
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// mentionMatcher is an Aho-Corasick automaton matching every page title in
+// a single pass over the text
+type mentionMatcher struct {
+	patterns []mentionPattern
+	nodes    []matcherNode
+	seen     map[string]bool
+}
+
+type mentionPattern struct {
+	text   string // Pattern as written
+	target string // Page the pattern refers to
+	runes  []rune // Lower-cased pattern
+}
+
+type matcherNode struct {
+	next    map[rune]int
+	fail    int
+	pattern int // Pattern ending at this node, or -1
+	output  int // Nearest node on the fail chain that ends a pattern, or -1
+}
+
+func newMentionMatcher() *mentionMatcher {
+	return &mentionMatcher{
+		nodes: []matcherNode{{next: make(map[rune]int), pattern: -1, output: -1}},
+		seen:  make(map[string]bool),
+	}
+}
+
+// add inserts a pattern; the first page to claim a spelling keeps it
+func (m *mentionMatcher) add(text, target string) {
+	text = strings.TrimSpace(text)
+	
+	// Lower-case rune by rune so offsets line up with FindMentions
+	runes := make([]rune, 0, len(text))
+	for _, r := range text {
+		runes = append(runes, unicode.ToLower(r))
+	}
+	if len(runes) < minMentionLength || m.seen[string(runes)] {
+		return
+	}
+	m.seen[string(runes)] = true
+	
+	node := 0
+	for _, r := range runes {
+		child, ok := m.nodes[node].next[r]
+		if !ok {
+			child = len(m.nodes)
+			m.nodes = append(m.nodes, matcherNode{next: make(map[rune]int), pattern: -1, output: -1})
+			m.nodes[node].next[r] = child
+		}
+		node = child
+	}
+	m.nodes[node].pattern = len(m.patterns)
+	m.patterns = append(m.patterns, mentionPattern{text: text, target: target, runes: runes})
+}
+
+// build computes fail and output links breadth first
+func (m *mentionMatcher) build() {
+	queue := []int{}
+	for _, child := range m.nodes[0].next {
+		m.nodes[child].fail = 0
+		queue = append(queue, child)
+	}
+	
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		
+		for r, child := range m.nodes[node].next {
+			fail := m.nodes[node].fail
+			for fail != 0 {
+				if _, ok := m.nodes[fail].next[r]; ok {
+					break
+				}
+				fail = m.nodes[fail].fail
+			}
+			if next, ok := m.nodes[fail].next[r]; ok {
+				fail = next
+			}
+			m.nodes[child].fail = fail
+			
+			if m.nodes[fail].pattern >= 0 {
+				m.nodes[child].output = fail
+			} else {
+				m.nodes[child].output = m.nodes[fail].output
+			}
+			queue = append(queue, child)
+		}
+	}
+}
+
+// search calls found with the end rune index and pattern of every match
+func (m *mentionMatcher) search(text []rune, found func(end, pattern int)) {
+	node := 0
+	for i, r := range text {
+		for node != 0 {
+			if _, ok := m.nodes[node].next[r]; ok {
+				break
+			}
+			node = m.nodes[node].fail
+		}
+		if next, ok := m.nodes[node].next[r]; ok {
+			node = next
+		}
+		
+		for out := node; out >= 0; out = m.nodes[out].output {
+			if m.nodes[out].pattern >= 0 {
+				found(i+1, m.nodes[out].pattern)
+			}
+		}
+	}
+}
\ No newline at end of file
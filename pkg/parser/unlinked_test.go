@@ -0,0 +1,143 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package parser
+
+import (
+	"fmt"
+	"testing"
+)
+
+// mentionPages builds pages from title -> block contents
+func mentionPages(contents map[string][]string) map[string]*Page {
+	pages := make(map[string]*Page)
+	for title, blocks := range contents {
+		page := &Page{Title: title, Properties: map[string]string{}}
+		for i, content := range blocks {
+			page.Blocks = append(page.Blocks, &Block{ID: fmt.Sprintf("block-%d", i+1), Content: content})
+		}
+		page.AllBlocks = page.Blocks
+		pages[title] = page
+	}
+	return pages
+}
+
+func TestFindMentions(t *testing.T) {
+	pages := mentionPages(map[string][]string{
+		"Parser":        nil,
+		"Parser Design": nil,
+		"Go":            nil,
+		"Rust":          nil,
+	})
+	idx := BuildUnlinkedIndex(pages, nil)
+
+	tests := []struct {
+		name string
+		text string
+		want []string // Targets in order
+	}{
+		{"case insensitive", "the parser is fast", []string{"Parser"}},
+		{"word boundaries", "parsers and goroutines", nil},
+		{"longest match wins", "see parser design notes", []string{"Parser Design"}},
+		{"several mentions", "Go and Rust, then go again", []string{"Go", "Rust", "Go"}},
+		{"existing links are skipped", "[[Parser]] and #Rust and #[[Go]]", nil},
+		{"inline code is skipped", "run `parser` then Rust", []string{"Rust"}},
+		{"urls are skipped", "https://example.com/rust", nil},
+		{"property lines are skipped", "tags:: rust", nil},
+		{"punctuation is a boundary", "(Parser), Go.", []string{"Parser", "Go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mentions := idx.FindMentions(tt.text)
+			if len(mentions) != len(tt.want) {
+				t.Fatalf("FindMentions(%q) = %+v, want targets %v", tt.text, mentions, tt.want)
+			}
+			for i, mention := range mentions {
+				if mention.Target != tt.want[i] {
+					t.Errorf("mention %d target = %q, want %q", i, mention.Target, tt.want[i])
+				}
+				if tt.text[mention.Start:mention.End] != mention.Text {
+					t.Errorf("mention %d offsets %d..%d don't match %q", i, mention.Start, mention.End, mention.Text)
+				}
+			}
+		})
+	}
+}
+
+func TestFindMentionsUnicodeOffsets(t *testing.T) {
+	idx := BuildUnlinkedIndex(mentionPages(map[string][]string{"Café": nil}), nil)
+
+	text := "Über das CAFÉ reden"
+	mentions := idx.FindMentions(text)
+	if len(mentions) != 1 {
+		t.Fatalf("Expected 1 mention, got %+v", mentions)
+	}
+	if text[mentions[0].Start:mentions[0].End] != "CAFÉ" {
+		t.Errorf("Mention text = %q, want CAFÉ", text[mentions[0].Start:mentions[0].End])
+	}
+}
+
+func TestBuildUnlinkedIndex(t *testing.T) {
+	pages := mentionPages(map[string][]string{
+		"Parser": {"The parser turns markdown into blocks"},
+		"Notes": {
+			"Rewrote the parser today",
+			"Linked [[Parser]] already, parser mention ignored",
+			"Asked the AST guy",
+		},
+		"Syntax Tree": nil,
+	})
+	pages["Syntax Tree"].Properties["alias"] = "AST, [[Abstract Syntax Tree]]"
+
+	idx := BuildUnlinkedIndex(pages, nil)
+
+	refs := idx.GetUnlinkedReferences("Parser")
+	if len(refs) != 1 {
+		t.Fatalf("Expected 1 unlinked reference to Parser, got %+v", refs)
+	}
+	if refs[0].PageName != "Notes" || refs[0].BlockID != "block-1" || refs[0].Text != "parser" {
+		t.Errorf("Unexpected reference: %+v", refs[0])
+	}
+
+	// Aliases count as mentions of the page they belong to
+	refs = idx.GetUnlinkedReferences("Syntax Tree")
+	if len(refs) != 1 || refs[0].Link != "AST" || refs[0].BlockID != "block-3" {
+		t.Errorf("Expected alias mention of Syntax Tree, got %+v", refs)
+	}
+}
+
+func BenchmarkBuildUnlinkedIndex(b *testing.B) {
+	contents := make(map[string][]string)
+	for i := 0; i < 10000; i++ {
+		contents[fmt.Sprintf("Topic %d", i)] = []string{
+			fmt.Sprintf("Notes about topic %d and topic %d", (i+1)%10000, (i+7)%10000),
+			fmt.Sprintf("Linked [[Topic %d]] with more text to scan", (i+3)%10000),
+		}
+	}
+	pages := mentionPages(contents)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BuildUnlinkedIndex(pages, nil)
+	}
+}
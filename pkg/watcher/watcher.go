@@ -0,0 +1,258 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package watcher reports changes to markdown files in a vault, including
+// its subdirectories. Bursts of
+// events (editors often write a file several times when saving) are
+// collected and reported together once the directory has been quiet for a
+// short delay.
+package watcher
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDelay is how long to wait after the last event before reporting
+const DefaultDelay = 200 * time.Millisecond
+
+// IgnoreFunc reports whether a file or directory should not be watched
+type IgnoreFunc func(path string, isDir bool) bool
+
+// Watcher watches directory trees for created, changed, renamed and deleted
+// markdown files
+type Watcher struct {
+	fs       *fsnotify.Watcher
+	delay    time.Duration
+	ignore   IgnoreFunc
+	onChange func(paths []string)
+	
+	mu      sync.Mutex
+	dirs    map[string]bool // Directories being watched
+	pending map[string]bool // Paths changed since the last report
+	timer   *time.Timer
+	closed  bool
+	done    chan struct{}
+}
+
+// New starts watching dirs and their subdirectories, except those ignore
+// (which may be nil) skips. onChange is called from a background goroutine
+// with the sorted paths of the markdown files that changed; a path is
+// reported whether the file was written, created or removed, so callers
+// should check what is on disk. A directory that is removed or renamed is
+// reported by its own path, since its files may not be reported one by
+// one.
+func New(dirs []string, delay time.Duration, ignore IgnoreFunc, onChange func(paths []string)) (*Watcher, error) {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	
+	if delay <= 0 {
+		delay = DefaultDelay
+	}
+	if ignore == nil {
+		ignore = func(string, bool) bool { return false }
+	}
+	
+	w := &Watcher{
+		fs:       fsWatcher,
+		delay:    delay,
+		ignore:   ignore,
+		onChange: onChange,
+		dirs:     make(map[string]bool),
+		pending:  make(map[string]bool),
+		done:     make(chan struct{}),
+	}
+	
+	for _, dir := range dirs {
+		if err := fsWatcher.Add(dir); err != nil {
+			fsWatcher.Close()
+			return nil, err
+		}
+		w.dirs[filepath.Clean(dir)] = true
+		w.addTree(dir, false)
+	}
+	
+	go w.run()
+	return w, nil
+}
+
+// addTree watches the subdirectories of dir. When report is set the page
+// files found are reported too, for directories created or moved in while
+// watching.
+func (w *Watcher) addTree(dir string, report bool) {
+	filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if path == dir {
+			return nil
+		}
+		
+		if w.ignore(path, entry.IsDir()) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			if err := w.fs.Add(path); err == nil {
+				w.mu.Lock()
+				w.dirs[filepath.Clean(path)] = true
+				w.mu.Unlock()
+			}
+		} else if report && isPageFile(path) {
+			w.add(path)
+		}
+		return nil
+	})
+}
+
+// Close stops watching. Changes not yet reported are dropped.
+func (w *Watcher) Close() error {
+	w.mu.Lock()
+	w.closed = true
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.mu.Unlock()
+	
+	err := w.fs.Close()
+	<-w.done
+	return err
+}
+
+// run receives events until the fsnotify watcher is closed
+func (w *Watcher) run() {
+	defer close(w.done)
+	
+	for {
+		select {
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			w.handle(event)
+		case _, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// handle records the page files and directories an event affects
+func (w *Watcher) handle(event fsnotify.Event) {
+	path := filepath.Clean(event.Name)
+	
+	if event.Has(fsnotify.Create) {
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			if !w.ignore(path, true) {
+				w.fs.Add(path)
+				w.mu.Lock()
+				w.dirs[path] = true
+				w.mu.Unlock()
+				w.addTree(path, true)
+			}
+			return
+		}
+	}
+	
+	if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+		if w.forgetDir(path) {
+			w.add(path)
+			return
+		}
+	}
+	
+	if isPageFile(path) && event.Op != fsnotify.Chmod && !w.ignore(path, false) {
+		w.add(path)
+	}
+}
+
+// forgetDir stops tracking a removed or renamed directory and those below
+// it, reporting whether path was a watched directory
+func (w *Watcher) forgetDir(path string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	
+	if !w.dirs[path] {
+		return false
+	}
+	prefix := path + string(filepath.Separator)
+	for dir := range w.dirs {
+		if dir == path || strings.HasPrefix(dir, prefix) {
+			delete(w.dirs, dir)
+			w.fs.Remove(dir)
+		}
+	}
+	return true
+}
+
+// add records a changed path and restarts the quiet period
+func (w *Watcher) add(path string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	
+	if w.closed {
+		return
+	}
+	
+	w.pending[filepath.Clean(path)] = true
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.timer = time.AfterFunc(w.delay, w.flush)
+}
+
+// flush reports the paths collected since the last report
+func (w *Watcher) flush() {
+	w.mu.Lock()
+	if w.closed || len(w.pending) == 0 {
+		w.mu.Unlock()
+		return
+	}
+	paths := make([]string, 0, len(w.pending))
+	for path := range w.pending {
+		paths = append(paths, path)
+	}
+	w.pending = make(map[string]bool)
+	w.mu.Unlock()
+	
+	sort.Strings(paths)
+	w.onChange(paths)
+}
+
+// isPageFile reports whether path names a markdown page. Editors' hidden
+// swap and backup files are ignored.
+func isPageFile(path string) bool {
+	name := filepath.Base(path)
+	return strings.HasSuffix(name, ".md") && !strings.HasPrefix(name, ".")
+}
\ No newline at end of file
@@ -0,0 +1,220 @@
+// MIT License
+//
+// Copyright (c) 2025 Rehan
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// watch starts a watcher on dir and returns a channel of reported batches
+func watch(t *testing.T, dir string) <-chan []string {
+	t.Helper()
+	return watchIgnoring(t, dir, nil)
+}
+
+// watchIgnoring starts a watcher on dir that skips what ignore matches
+func watchIgnoring(t *testing.T, dir string, ignore IgnoreFunc) <-chan []string {
+	t.Helper()
+
+	batches := make(chan []string, 10)
+	w, err := New([]string{dir}, 50*time.Millisecond, ignore, func(paths []string) {
+		batches <- paths
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { w.Close() })
+	return batches
+}
+
+func next(t *testing.T, batches <-chan []string) []string {
+	t.Helper()
+
+	select {
+	case paths := <-batches:
+		return paths
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change reported")
+		return nil
+	}
+}
+
+func TestWatcherDebouncesWrites(t *testing.T) {
+	dir := t.TempDir()
+	batches := watch(t, dir)
+
+	page := filepath.Join(dir, "page.md")
+	other := filepath.Join(dir, "other.md")
+	for i := 0; i < 5; i++ {
+		if err := os.WriteFile(page, []byte("- edit\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(other, []byte("- other\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := next(t, batches)
+	if want := []string{other, page}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reported %v, want %v", got, want)
+	}
+
+	select {
+	case extra := <-batches:
+		t.Errorf("unexpected second report %v", extra)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestWatcherReportsRemovalAndIgnoresOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	page := filepath.Join(dir, "page.md")
+	if err := os.WriteFile(page, []byte("- text\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	batches := watch(t, dir)
+
+	for _, name := range []string{"notes.txt", ".page.md.swp", ".hidden.md"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Remove(page); err != nil {
+		t.Fatal(err)
+	}
+
+	got := next(t, batches)
+	if want := []string{page}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reported %v, want %v", got, want)
+	}
+}
+
+func TestCloseStopsReports(t *testing.T) {
+	dir := t.TempDir()
+	batches := make(chan []string, 10)
+	w, err := New([]string{dir}, 50*time.Millisecond, nil, func(paths []string) {
+		batches <- paths
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "page.md"), []byte("- text\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case paths := <-batches:
+		t.Errorf("reported %v after Close", paths)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestNewFailsForMissingDirectory(t *testing.T) {
+	_, err := New([]string{filepath.Join(t.TempDir(), "missing")}, 0, nil, func([]string) {})
+	if err == nil {
+		t.Error("expected an error watching a missing directory")
+	}
+}
+
+func TestWatcherSubdirectories(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "projects")
+	ignored := filepath.Join(dir, "cache")
+	for _, sub := range []string{existing, ignored} {
+		if err := os.Mkdir(sub, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	batches := watchIgnoring(t, dir, func(path string, isDir bool) bool {
+		return filepath.Base(path) == "cache"
+	})
+
+	// A file in an existing subdirectory, and in one created while
+	// watching, but not in an ignored one
+	inExisting := filepath.Join(existing, "alpha.md")
+	if err := os.WriteFile(inExisting, []byte("- alpha\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(ignored, "stale.md"), []byte("- stale\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got := next(t, batches)
+	if want := []string{inExisting}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reported %v, want %v", got, want)
+	}
+
+	created := filepath.Join(dir, "archive", "2024")
+	if err := os.MkdirAll(created, 0755); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond) // Let the new directories be watched
+	inCreated := filepath.Join(created, "beta.md")
+	if err := os.WriteFile(inCreated, []byte("- beta\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got = next(t, batches)
+	if want := []string{inCreated}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reported %v, want %v", got, want)
+	}
+
+	// Removing a directory reports the directory
+	if err := os.RemoveAll(existing); err != nil {
+		t.Fatal(err)
+	}
+	got = next(t, batches)
+	found := false
+	for _, path := range got {
+		found = found || path == existing
+	}
+	if !found {
+		t.Errorf("reported %v, want it to include %s", got, existing)
+	}
+}
+
+func TestWatcherReportsMovedInDirectory(t *testing.T) {
+	dir := t.TempDir()
+	outside := t.TempDir()
+	moved := filepath.Join(outside, "imported")
+	if err := os.MkdirAll(moved, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(moved, "page.md"), []byte("- page\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	batches := watch(t, dir)
+
+	if err := os.Rename(moved, filepath.Join(dir, "imported")); err != nil {
+		t.Fatal(err)
+	}
+	got := next(t, batches)
+	if want := []string{filepath.Join(dir, "imported", "page.md")}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reported %v, want %v", got, want)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"time"
 	
 	"github.com/rehanog/seq2b/pkg/parser"
@@ -16,6 +17,7 @@ func main() {
 		vaultPath = flag.String("vault", "", "Path to test vault")
 		runs      = flag.Int("runs", 3, "Number of benchmark runs")
 		clearCache = flag.Bool("clear-cache", false, "Clear cache before benchmarking")
+		workers    = flag.Int("workers", 0, "Parser workers for the parallel benchmark (0 = one per CPU)")
 	)
 	flag.Parse()
 	
@@ -55,6 +57,9 @@ func main() {
 		}
 	}
 	
+	// Benchmark parallel parsing against a single worker
+	benchmarkWorkers(pagesDir, *runs, *workers, len(files))
+	
 	// Benchmark cold start (no cache)
 	fmt.Println("=== Cold Start Benchmark (no cache) ===")
 	coldTimes := make([]time.Duration, *runs)
@@ -153,6 +158,43 @@ func main() {
 	}
 }
 
+// benchmarkWorkers compares parsing with one worker and with a pool of
+// workers, without the cache
+func benchmarkWorkers(pagesDir string, runs int, workers int, fileCount int) {
+	poolSize := workers
+	if poolSize <= 0 {
+		poolSize = runtime.GOMAXPROCS(0)
+	}
+	fmt.Printf("=== Parallel Parsing Benchmark (no cache, %d workers) ===\n", poolSize)
+	
+	average := func(workers int) time.Duration {
+		opts := parser.DefaultParseOptions()
+		opts.Workers = workers
+		
+		var total time.Duration
+		for i := 0; i < runs; i++ {
+			start := time.Now()
+			result, err := parser.ParseDirectoryWithOptions(pagesDir, opts)
+			elapsed := time.Since(start)
+			
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing: %v\n", err)
+				continue
+			}
+			total += elapsed
+			fmt.Printf("Run %d, %d worker(s): %v (%d pages)\n", i+1, workers, elapsed, len(result.Pages))
+		}
+		return total / time.Duration(runs)
+	}
+	
+	sequential := average(1)
+	parallel := average(poolSize)
+	
+	fmt.Printf("\nSequential average: %v (%.2f pages/sec)\n", sequential, float64(fileCount)/sequential.Seconds())
+	fmt.Printf("Parallel average: %v (%.2f pages/sec)\n", parallel, float64(fileCount)/parallel.Seconds())
+	fmt.Printf("Speedup: %.2fx with %d workers\n\n", float64(sequential)/float64(parallel), poolSize)
+}
+
 func countBacklinks(index *parser.BacklinkIndex) int {
 	count := 0
 	for _, backlinks := range index.BackwardLinks {
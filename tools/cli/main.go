@@ -25,8 +25,10 @@ package main
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	
+	"github.com/rehanog/seq2b/pkg/graph"
 	"github.com/rehanog/seq2b/pkg/parser"
 )
 
@@ -34,6 +36,20 @@ func main() {
 	// Check if we have a directory or file argument
 	if len(os.Args) < 2 {
 		fmt.Println("Usage: seq2b <file.md> or seq2b <directory>")
+		fmt.Println("       seq2b date <phrase>   (e.g. seq2b date next friday)")
+		fmt.Println("       seq2b graph <directory> <command>   (seq2b graph help for commands)")
+		return
+	}
+	
+	// Resolve natural-language dates
+	if isSubcommand(os.Args[1], "date") {
+		handleDate(strings.Join(os.Args[2:], " "))
+		return
+	}
+	
+	// Analyse the link graph of a directory
+	if isSubcommand(os.Args[1], "graph") {
+		handleGraph(os.Args[2:])
 		return
 	}
 	
@@ -52,6 +68,17 @@ func main() {
 	}
 }
 
+// isSubcommand reports whether arg is the subcommand name. A file or
+// directory of that name is opened instead, so a library called "date"
+// still works; "./date" names it either way.
+func isSubcommand(arg string, name string) bool {
+	if arg != name {
+		return false
+	}
+	_, err := os.Stat(arg)
+	return err != nil
+}
+
 func handleSingleFile(filename string) {
 	// Read the file
 	content, err := os.ReadFile(filename)
@@ -148,8 +175,8 @@ func handleDirectory(dirPath string) {
 	
 	// Show page summaries
 	fmt.Println("\nPages:")
-	for pageName, page := range result.Pages {
-		fmt.Printf("  %s - %d blocks\n", pageName, len(page.AllBlocks))
+	for _, page := range result.Pages {
+		fmt.Printf("  %s - %d blocks\n", page.Title, len(page.AllBlocks))
 	}
 	
 	// Show backlinks for each page
@@ -168,7 +195,7 @@ func handleDirectory(dirPath string) {
 				for i, ref := range refs {
 					blockIDs[i] = ref.BlockID
 				}
-				fmt.Printf("    - %s (in blocks: %s)\n", sourcePage, strings.Join(blockIDs, ", "))
+				fmt.Printf("    - %s (in blocks: %s)\n", result.Backlinks.Title(sourcePage), strings.Join(blockIDs, ", "))
 			}
 		} else {
 			fmt.Println("  ← No incoming references")
@@ -178,7 +205,7 @@ func handleDirectory(dirPath string) {
 		if len(forwardLinks) > 0 {
 			fmt.Println("  → References:")
 			for targetPage, refs := range forwardLinks {
-				fmt.Printf("    - %s (%d times)\n", targetPage, len(refs))
+				fmt.Printf("    - %s (%d times)\n", result.Backlinks.Title(targetPage), len(refs))
 			}
 		} else {
 			fmt.Println("  → No outgoing references")
@@ -202,6 +229,119 @@ func handleDirectory(dirPath string) {
 	fmt.Printf("Orphan pages: %d\n", orphanCount)
 }
 
+// handleDate resolves a natural-language date and prints its journal forms
+func handleDate(phrase string) {
+	date, err := parser.ParseNaturalDate(phrase)
+	if err != nil {
+		fmt.Printf("Error parsing date: %v\n", err)
+		return
+	}
+	
+	fmt.Printf("Date: %s (%s)\n", parser.FormatDateISO(date), date.Weekday())
+	fmt.Printf("Journal page: [[%s]]\n", parser.FormatDateForPage(date))
+	fmt.Printf("Journal file: %s\n", parser.GetDatePageFilename(date))
+	fmt.Printf("Scheduled: %s\n", parser.FormatScheduled(date))
+	fmt.Printf("Relative: %s\n", parser.RelativeDateString(date))
+}
+
+// graphUsage describes the graph subcommands
+const graphUsage = `Usage: seq2b graph <directory> <command>
+Commands:
+  components             connected groups of pages, largest first
+  rank [n]               top n pages by PageRank (default 20)
+  hubs [n]               top n hubs and authorities (default 20)
+  path <from> <to>       shortest chain of links between two pages
+  near <page> [n]        pages within n links of a page (default 2)
+  suggest <page> [n]     up to n pages to link, by co-citation (default 10)`
+
+// handleGraph runs a graph analysis over a parsed directory
+func handleGraph(args []string) {
+	if len(args) < 2 {
+		fmt.Println(graphUsage)
+		return
+	}
+	
+	result, err := parser.ParseDirectory(args[0])
+	if err != nil {
+		fmt.Printf("Error parsing directory: %v\n", err)
+		return
+	}
+	g := graph.New(result.Backlinks)
+	command, params := args[1], args[2:]
+	
+	// count reads an optional numeric argument
+	count := func(index, fallback int) int {
+		if index < len(params) {
+			if n, err := strconv.Atoi(params[index]); err == nil {
+				return n
+			}
+		}
+		return fallback
+	}
+	
+	switch {
+	case command == "components":
+		for i, component := range g.Components() {
+			fmt.Printf("%d. (%d pages) %s\n", i+1, len(component), strings.Join(component, ", "))
+		}
+	
+	case command == "rank":
+		for _, score := range topScores(g.PageRank(0.85, 100, 1e-6), count(0, 20)) {
+			fmt.Printf("  %.5f  %s\n", score.Score, score.Page)
+		}
+	
+	case command == "hubs":
+		hubs, authorities := g.HITS(50)
+		fmt.Println("Hubs:")
+		for _, score := range topScores(hubs, count(0, 20)) {
+			fmt.Printf("  %.5f  %s\n", score.Score, score.Page)
+		}
+		fmt.Println("Authorities:")
+		for _, score := range topScores(authorities, count(0, 20)) {
+			fmt.Printf("  %.5f  %s\n", score.Score, score.Page)
+		}
+	
+	case command == "path" && len(params) >= 2:
+		path, err := g.ShortestPath(params[0], params[1])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("%s (%d links)\n", strings.Join(path, " → "), len(path)-1)
+	
+	case command == "near" && len(params) >= 1:
+		hops, err := g.Neighbourhood(params[0], count(1, 2))
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		for _, hop := range hops {
+			fmt.Printf("  %d  %s\n", hop.Distance, hop.Page)
+		}
+	
+	case command == "suggest" && len(params) >= 1:
+		suggestions, err := g.SuggestLinks(params[0], count(1, 10))
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		for _, suggestion := range suggestions {
+			fmt.Printf("  %s (cited with it by %s)\n", suggestion.Page, strings.Join(suggestion.CitedWith, ", "))
+		}
+	
+	default:
+		fmt.Println(graphUsage)
+	}
+}
+
+// topScores returns at most n scores
+func topScores(scores []graph.Score, n int) []graph.Score {
+	if n < len(scores) {
+		return scores[:n]
+	}
+	return scores
+}
+
 // printBlockTree recursively prints the block hierarchy
 func printBlockTree(blocks []*parser.Block, indent string) {
 	for _, block := range blocks {
@@ -127,10 +127,15 @@ func generatePageNames(count int) []string {
 		}
 	}
 	
-	// Generate remaining pages
+	// Generate remaining pages. Numbers range at least as high as the page
+	// count so large vaults don't run out of unique names.
+	numRange := 1000
+	if count > numRange {
+		numRange = count
+	}
 	for len(names) < count {
 		template := pageTemplates[rand.Intn(len(pageTemplates))]
-		num := rand.Intn(1000)
+		num := rand.Intn(numRange)
 		name := fmt.Sprintf("%s %d", template, num)
 		
 		if !used[name] {